@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"fmt"
+
+	"discord-bot/internal/app/features/link"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// linkCommand links a Riot account to the invoking Discord user.
+var linkCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "link",
+		Description: "Link a Riot account to your Discord user",
+		Options:     lookupOptions[:3],
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		if !deferResponse(s, i) {
+			return
+		}
+		options := optionsByName(i.ApplicationCommandData().Options)
+		var message *discordgo.MessageEmbed
+		id, err := riotIDOption(options)
+		if err == nil {
+			message, err = link.LinkAccount(interactionUserID(i), id.Name, id.Tag, stringOption(options, "region", ""))
+		}
+		if err != nil {
+			errormessage := fmt.Sprintf("Failed to link account: %v", err)
+			s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Content: &errormessage,
+			})
+			return
+		}
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Embeds: &[]*discordgo.MessageEmbed{message},
+		})
+	},
+	Autocomplete: summonerAutocomplete,
+}
+
+// unlinkCommand removes a linked Riot account from the invoking Discord user.
+var unlinkCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "unlink",
+		Description: "Unlink a Riot account from your Discord user",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "name",
+				Description: "Ingame Name or full Riot ID (Name#TAG)",
+				Required:    true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "tag",
+				Description: "Riot Tag (optional if the name is Name#TAG)",
+			},
+		},
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		options := optionsByName(i.ApplicationCommandData().Options)
+		id, err := riotIDOption(options)
+		if err == nil {
+			err = link.UnlinkAccount(interactionUserID(i), id.Name, id.Tag)
+		}
+		content := fmt.Sprintf("%s has been unlinked", id)
+		if err != nil {
+			content = fmt.Sprintf("Failed to unlink account: %v", err)
+		}
+		respondEphemeral(s, i, &discordgo.InteractionResponseData{Content: content})
+	},
+}
+
+// meCommand shows all Riot accounts linked to the invoking Discord user.
+var meCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "me",
+		Description: "Show the Riot accounts linked to your Discord user",
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		message, err := link.Me(interactionUserID(i))
+		if err != nil {
+			respondEphemeral(s, i, &discordgo.InteractionResponseData{Content: fmt.Sprintf("Failed to show linked accounts: %v", err)})
+			return
+		}
+		respondEphemeral(s, i, &discordgo.InteractionResponseData{Embeds: []*discordgo.MessageEmbed{message}})
+	},
+}
+
+// mentionsCommand opts the invoking Discord user in or out of mentions in notifications.
+var mentionsCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "mentions",
+		Description: "Get mentioned in the notifications of your linked accounts",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "enabled",
+				Description: "Mention me in notifications",
+				Required:    true,
+			},
+		},
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		enabled := i.ApplicationCommandData().Options[0].BoolValue()
+		content := "You will no longer be mentioned in notifications"
+		if enabled {
+			content = "You will be mentioned in the notifications of your linked accounts"
+		}
+		if err := link.SetMentions(interactionUserID(i), enabled); err != nil {
+			content = fmt.Sprintf("Failed to update mentions: %v", err)
+		}
+		respondEphemeral(s, i, &discordgo.InteractionResponseData{Content: content})
+	},
+}
+
+// verifyCommand proves ownership of a Riot account with a profile icon challenge.
+var verifyCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "verify",
+		Description: "Prove that you own a Riot account and link it to your Discord user",
+		Options:     lookupOptions[:3],
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		if !deferResponse(s, i) {
+			return
+		}
+		options := optionsByName(i.ApplicationCommandData().Options)
+		var challenge *link.Challenge
+		var message *discordgo.MessageEmbed
+		id, err := riotIDOption(options)
+		if err == nil {
+			challenge, message, err = link.StartVerification(interactionUserID(i), id.Name, id.Tag, stringOption(options, "region", ""))
+		}
+		if err != nil {
+			errormessage := fmt.Sprintf("Failed to start verification: %v", err)
+			s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Content: &errormessage,
+			})
+			return
+		}
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Embeds: &[]*discordgo.MessageEmbed{message},
+		})
+
+		// Polling takes minutes, so it must not block the interaction handler
+		go func() {
+			message, err := link.AwaitVerification(challenge)
+			if err != nil {
+				errormessage := fmt.Sprintf("Verification failed: %v", err)
+				s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+					Content: &errormessage,
+					Embeds:  &[]*discordgo.MessageEmbed{},
+				})
+				return
+			}
+			s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Embeds: &[]*discordgo.MessageEmbed{message},
+			})
+		}()
+	},
+	Autocomplete: summonerAutocomplete,
+}
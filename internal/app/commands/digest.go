@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"discord-bot/internal/app/features/digest"
+	digestTypes "discord-bot/types/digest"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// digestCommand configures scheduled weekly and season digests for the channel.
+var digestCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "digest",
+		Description: "Configure scheduled digests for this channel",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "set",
+				Description: "Schedule a digest for this channel",
+				Options: []*discordgo.ApplicationCommandOption{
+					digestPeriodOption,
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "day",
+						Description: "Day to post the digest (default: sunday)",
+						Choices: func() []*discordgo.ApplicationCommandOptionChoice {
+							choices := []*discordgo.ApplicationCommandOptionChoice{{Name: "daily", Value: "daily"}}
+							for _, day := range digest.Weekdays {
+								choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: day, Value: day})
+							}
+							return choices
+						}(),
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "time",
+						Description: "Time to post the digest as HH:MM (default: 18:00)",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "timezone",
+						Description: "IANA timezone, e.g. Europe/Berlin (default: UTC)",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "content",
+						Description: "Comma separated: " + strings.Join(digestTypes.AllSections, ","),
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "cron",
+						Description: "Cron expression, overrides day and time (e.g. \"0 18 * * 0\")",
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "remove",
+				Description: "Remove a scheduled digest",
+				Options:     []*discordgo.ApplicationCommandOption{digestPeriodOption},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "show",
+				Description: "Show the digests scheduled for this channel",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "preview",
+				Description: "Post a digest for the current period now",
+				Options:     []*discordgo.ApplicationCommandOption{digestPeriodOption},
+			},
+		},
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		subcommand := i.ApplicationCommandData().Options[0]
+		options := optionsByName(subcommand.Options)
+		period := stringOption(options, "period", digestTypes.PeriodWeekly)
+
+		var message *discordgo.MessageEmbed
+		var content string
+		var err error
+		switch subcommand.Name {
+		case "set":
+			cronExpression := stringOption(options, "cron", "")
+			if cronExpression == "" {
+				cronExpression, err = digest.CronFromDayTime(stringOption(options, "day", "sunday"), stringOption(options, "time", "18:00"))
+			}
+			if err == nil {
+				message, err = digest.SetSchedule(i.ChannelID, i.GuildID, period, cronExpression, stringOption(options, "timezone", "UTC"), stringOption(options, "content", ""))
+			}
+		case "remove":
+			err = digest.RemoveSchedule(i.ChannelID, period)
+			content = fmt.Sprintf("The %s digest has been removed", period)
+		case "show":
+			message, err = digest.ShowSchedules(i.ChannelID)
+		case "preview":
+			message, err = digest.BuildDigest(i.ChannelID, period, digestTypes.AllSections, time.Now())
+		}
+
+		if err != nil {
+			content = fmt.Sprintf("Failed to %s digest: %v", subcommand.Name, err)
+			message = nil
+		}
+		response := &discordgo.InteractionResponseData{Content: content}
+		if message != nil {
+			response.Embeds = []*discordgo.MessageEmbed{message}
+		}
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: response,
+		})
+	},
+}
+
+var digestPeriodOption = &discordgo.ApplicationCommandOption{
+	Type:        discordgo.ApplicationCommandOptionString,
+	Name:        "period",
+	Description: "Period the digest summarizes",
+	Required:    true,
+	Choices: []*discordgo.ApplicationCommandOptionChoice{
+		{Name: "weekly", Value: digestTypes.PeriodWeekly},
+		{Name: "season", Value: digestTypes.PeriodSeason},
+	},
+}
@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"fmt"
+
+	"discord-bot/internal/app/features/clash"
+	"discord-bot/internal/app/features/live"
+	"discord-bot/internal/app/features/lookup"
+	"discord-bot/internal/app/features/mastery"
+	"discord-bot/types/riotid"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// rankCommand looks up the ranks of any Riot ID without tracking it.
+var rankCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "rank",
+		Description: "Show the ranks of any summoner without tracking them",
+		Options:     lookupOptions,
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		respondWithLookup(s, i, lookup.Rank)
+	},
+	Autocomplete: summonerAutocomplete,
+}
+
+// profileCommand looks up the ranks and top champions of any Riot ID without tracking it.
+var profileCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "profile",
+		Description: "Show the profile of any summoner without tracking them",
+		Options:     lookupOptions,
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		respondWithLookup(s, i, lookup.Profile)
+	},
+	Autocomplete: summonerAutocomplete,
+}
+
+// masteryCommand shows the champions with the most mastery points of any Riot ID.
+var masteryCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "mastery",
+		Description: "Show the top champion masteries of any summoner",
+		Options: append(append([]*discordgo.ApplicationCommandOption{}, lookupOptions...), &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "count",
+			Description: "Number of champions to show (default: 5)",
+			MinValue:    &minMasteryCount,
+			MaxValue:    maxMasteryCount,
+		}),
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		count := int(intOption(optionsByName(i.ApplicationCommandData().Options), "count", defaultMasteryCount))
+		respondWithLookup(s, i, func(name, tag, region, guildID string) (*discordgo.MessageEmbed, []*discordgo.File, error) {
+			return mastery.Top(name, tag, region, guildID, count)
+		})
+	},
+	Autocomplete: summonerAutocomplete,
+}
+
+// Number of champions /mastery shows
+var (
+	minMasteryCount     = 1.0
+	maxMasteryCount     = 10.0
+	defaultMasteryCount = int64(5)
+)
+
+// liveCommand shows the current game of any Riot ID.
+var liveCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "live",
+		Description: "Show the current game of any summoner",
+		Options:     lookupOptions,
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		if !deferResponse(s, i) {
+			return
+		}
+
+		options := optionsByName(i.ApplicationCommandData().Options)
+		var message *discordgo.MessageEmbed
+		var files []*discordgo.File
+		id, err := riotIDOption(options)
+		if err == nil {
+			message, files, err = live.LiveGame(id.Name, id.Tag, stringOption(options, "region", ""), i.GuildID)
+		}
+		if err != nil {
+			errormessage := fmt.Sprintf("Failed to show live game: %v", err)
+			s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Content: &errormessage,
+			})
+			return
+		}
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Embeds: &[]*discordgo.MessageEmbed{message},
+			Files:  files,
+		})
+	},
+	Autocomplete: summonerAutocomplete,
+}
+
+// clashCommand shows the upcoming Clash tournaments and the team of any Riot ID.
+var clashCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "clash",
+		Description: "Show the upcoming Clash tournaments and the team of a summoner",
+		Options: []*discordgo.ApplicationCommandOption{
+			lookupOptions[0],
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "name",
+				Description:  "Ingame Name or full Riot ID (Name#TAG) to show the Clash team of",
+				Autocomplete: true,
+			},
+			lookupOptions[2],
+			lookupOptions[3],
+		},
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		if !deferResponse(s, i) {
+			return
+		}
+
+		options := optionsByName(i.ApplicationCommandData().Options)
+		var name, tag string
+		var err error
+		if stringOption(options, "name", "") != "" {
+			var id riotid.RiotID
+			id, err = riotIDOption(options)
+			name, tag = id.Name, id.Tag
+		}
+		var message *discordgo.MessageEmbed
+		var files []*discordgo.File
+		if err == nil {
+			message, files, err = clash.Overview(name, tag, stringOption(options, "region", ""), i.GuildID)
+		}
+		if err != nil {
+			errormessage := fmt.Sprintf("Failed to show Clash: %v", err)
+			s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Content: &errormessage,
+			})
+			return
+		}
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Embeds: &[]*discordgo.MessageEmbed{message},
+			Files:  files,
+		})
+	},
+	Autocomplete: summonerAutocomplete,
+}
+
+var lookupOptions = []*discordgo.ApplicationCommandOption{
+	{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "region",
+		Description: "League Region",
+		Required:    true,
+		Choices:     platformChoices(),
+	},
+	{
+		Type:         discordgo.ApplicationCommandOptionString,
+		Name:         "name",
+		Description:  "Ingame Name or full Riot ID (Name#TAG)",
+		Required:     true,
+		Autocomplete: true,
+	},
+	{
+		Type:         discordgo.ApplicationCommandOptionString,
+		Name:         "tag",
+		Description:  "Riot Tag (optional if the name is Name#TAG)",
+		Autocomplete: true,
+	},
+	{
+		Type:        discordgo.ApplicationCommandOptionBoolean,
+		Name:        "public",
+		Description: "Show the result to everyone in the channel (default: only you)",
+	},
+}
+
+// respondWithLookup defers the response and edits in the lookup result
+func respondWithLookup(s *discordgo.Session, i *discordgo.InteractionCreate, lookupFunc func(name, tag, region, guildID string) (*discordgo.MessageEmbed, []*discordgo.File, error)) {
+	if !deferResponse(s, i) {
+		return
+	}
+
+	options := optionsByName(i.ApplicationCommandData().Options)
+	var message *discordgo.MessageEmbed
+	var files []*discordgo.File
+	id, err := riotIDOption(options)
+	if err == nil {
+		message, files, err = lookupFunc(id.Name, id.Tag, stringOption(options, "region", ""), i.GuildID)
+	}
+	if err != nil {
+		errormessage := fmt.Sprintf("Failed to look up summoner: %v", err)
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content: &errormessage,
+		})
+		return
+	}
+	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{message},
+		Files:  files,
+	})
+}
@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"sort"
+
+	"discord-bot/internal/app/constants"
+	"discord-bot/internal/app/features/listing"
+	"discord-bot/internal/logger"
+	"discord-bot/types/riotid"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+var administratorPermission int64 = discordgo.PermissionAdministrator
+
+func platformChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, key := range constants.GetPlatformKeys() {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  key,
+			Value: key,
+		})
+	}
+	sort.Slice(choices, func(i, j int) bool {
+		return choices[i].Name < choices[j].Name
+	})
+	return choices
+}
+
+// deferResponse acknowledges a lookup command, since the Riot API may take a while.
+// The response is only visible to the invoking user unless the "public" option is set.
+func deferResponse(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	options := optionsByName(i.ApplicationCommandData().Options)
+	var flags discordgo.MessageFlags
+	if option, ok := options["public"]; !ok || !option.BoolValue() {
+		flags = discordgo.MessageFlagsEphemeral
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: flags},
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to defer interaction response", zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// summonerAutocomplete suggests summoners tracked in the current channel for the name and tag options
+func summonerAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	options := optionsByName(data.Options)
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, option := range data.Options {
+		if !option.Focused {
+			continue
+		}
+		switch option.Name {
+		case "name":
+			choices = listing.SuggestNames(i.ChannelID, option.StringValue())
+		case "tag":
+			choices = listing.SuggestTags(i.ChannelID, stringOption(options, "name", ""), option.StringValue())
+		}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to respond to autocomplete", zap.Error(err))
+	}
+}
+
+// interactionUserID returns the ID of the user who invoked an interaction, in guilds as well as in DMs
+func interactionUserID(i *discordgo.InteractionCreate) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
+
+// respondEphemeral responds to an interaction with a message only the invoking user can see
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, data *discordgo.InteractionResponseData) {
+	data.Flags = discordgo.MessageFlagsEphemeral
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+}
+
+// optionsByName maps interaction options by their name for commands with optional options
+func optionsByName(options []*discordgo.ApplicationCommandInteractionDataOption) map[string]*discordgo.ApplicationCommandInteractionDataOption {
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, option := range options {
+		optionMap[option.Name] = option
+	}
+	return optionMap
+}
+
+// stringOption returns the string value of an option or the fallback if it was not provided
+func stringOption(options map[string]*discordgo.ApplicationCommandInteractionDataOption, name, fallback string) string {
+	if option, ok := options[name]; ok {
+		return option.StringValue()
+	}
+	return fallback
+}
+
+// riotIDOption parses the "name" and "tag" options, the tag may be left out if the name is a full "Name#TAG"
+func riotIDOption(options map[string]*discordgo.ApplicationCommandInteractionDataOption) (riotid.RiotID, error) {
+	return riotid.New(stringOption(options, "name", ""), stringOption(options, "tag", ""))
+}
+
+func intOption(options map[string]*discordgo.ApplicationCommandInteractionDataOption, name string, fallback int64) int64 {
+	if option, ok := options[name]; ok {
+		return option.IntValue()
+	}
+	return fallback
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"fmt"
+
+	"discord-bot/internal/app/features/permissions"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// permissionsCommand configures which roles may use the management commands.
+var permissionsCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:                     "permissions",
+		Description:              "Configure which roles may use the management commands",
+		DefaultMemberPermissions: &administratorPermission,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "allow",
+				Description: "Allow a role to use the management commands",
+				Options:     []*discordgo.ApplicationCommandOption{permissionRoleOption},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "disallow",
+				Description: "Remove a role from the allowlist",
+				Options:     []*discordgo.ApplicationCommandOption{permissionRoleOption},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "show",
+				Description: "Show the roles allowed to use the management commands",
+			},
+		},
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		subcommand := i.ApplicationCommandData().Options[0]
+		options := optionsByName(subcommand.Options)
+
+		var content string
+		switch subcommand.Name {
+		case "allow":
+			role := options["role"].RoleValue(nil, "")
+			content = fmt.Sprintf("<@&%s> may now use the management commands", role.ID)
+			if err := permissions.AllowRole(i.GuildID, role.ID); err != nil {
+				content = fmt.Sprintf("Failed to allow role: %v", err)
+			}
+		case "disallow":
+			role := options["role"].RoleValue(nil, "")
+			content = fmt.Sprintf("<@&%s> has been removed from the allowlist", role.ID)
+			if err := permissions.DisallowRole(i.GuildID, role.ID); err != nil {
+				content = fmt.Sprintf("Failed to disallow role: %v", err)
+			}
+		case "show":
+			message, err := permissions.ShowRoles(i.GuildID)
+			if err != nil {
+				content = fmt.Sprintf("Failed to show permissions: %v", err)
+				break
+			}
+			respondEphemeral(s, i, &discordgo.InteractionResponseData{Embeds: []*discordgo.MessageEmbed{message}})
+			return
+		}
+		respondEphemeral(s, i, &discordgo.InteractionResponseData{Content: content})
+	},
+}
+
+var permissionRoleOption = &discordgo.ApplicationCommandOption{
+	Type:        discordgo.ApplicationCommandOptionRole,
+	Name:        "role",
+	Description: "Role",
+	Required:    true,
+}
@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"discord-bot/internal/logger"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// pingCommand responds with "Pong!".
+var pingCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "ping",
+		Description: "Responds with Pong!",
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		logger.Logger.Debug("Ping command received")
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "Pong!",
+			},
+		})
+	},
+}
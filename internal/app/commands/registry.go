@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"discord-bot/internal/app/features/permissions"
+	"discord-bot/internal/logger"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// Command bundles a slash command definition with everything that handles its interactions
+type Command struct {
+	Definition *discordgo.ApplicationCommand
+	Handler    func(s *discordgo.Session, i *discordgo.InteractionCreate)
+	// Autocomplete suggests values for options with Autocomplete enabled, optional
+	Autocomplete func(s *discordgo.Session, i *discordgo.InteractionCreate)
+	// Components handle message components sent with the command's responses, keyed by the custom ID prefix before the first ":"
+	Components map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate)
+}
+
+// all lists every command of the bot in the order they are registered
+var all = []*Command{
+	addCommand,
+	pingCommand,
+	deleteCommand,
+	followCommand,
+	listCommand,
+	rankCommand,
+	profileCommand,
+	masteryCommand,
+	liveCommand,
+	clashCommand,
+	digestCommand,
+	settingsCommand,
+	linkCommand,
+	unlinkCommand,
+	meCommand,
+	mentionsCommand,
+	verifyCommand,
+	rolesCommand,
+	permissionsCommand,
+	exportCommand,
+	importCommand,
+}
+
+var (
+	byName      = make(map[string]*Command)
+	byComponent = make(map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate))
+)
+
+func init() {
+	for _, cmd := range all {
+		byName[cmd.Definition.Name] = cmd
+		for prefix, handler := range cmd.Components {
+			byComponent[prefix] = handler
+		}
+	}
+}
+
+// Definitions returns the definitions of all commands
+func Definitions() []*discordgo.ApplicationCommand {
+	definitions := make([]*discordgo.ApplicationCommand, 0, len(all))
+	for _, cmd := range all {
+		definitions = append(definitions, cmd.Definition)
+	}
+	return definitions
+}
+
+// Global reports whether commands are registered globally instead of per guild, set COMMANDS_GLOBAL=true to enable it.
+// Global commands need up to an hour to show up in every guild but cost a single registration.
+func Global() bool {
+	return os.Getenv("COMMANDS_GLOBAL") == "true"
+}
+
+// HandleInteraction dispatches commands, autocomplete requests and message components to their handlers
+func HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		cmd, ok := byName[i.ApplicationCommandData().Name]
+		if !ok {
+			logger.Logger.Warn("Unknown command", zap.String("command", i.ApplicationCommandData().Name))
+			return
+		}
+		if rejection := permissions.Authorize(i); rejection != "" {
+			respondEphemeral(s, i, &discordgo.InteractionResponseData{Content: rejection})
+			return
+		}
+		cmd.Handler(s, i)
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		if cmd, ok := byName[i.ApplicationCommandData().Name]; ok && cmd.Autocomplete != nil {
+			cmd.Autocomplete(s, i)
+		}
+	case discordgo.InteractionMessageComponent:
+		prefix, _, _ := strings.Cut(i.MessageComponentData().CustomID, ":")
+		if handler, ok := byComponent[prefix]; ok {
+			handler(s, i)
+		}
+	}
+}
+
+// Sync registers the commands for a guild, or globally for an empty guild ID.
+// The registered commands are only overwritten if they differ from the definitions.
+func Sync(s *discordgo.Session, guildID string) error {
+	return syncDefinitions(s, guildID, Definitions())
+}
+
+// Clear removes all commands registered for a guild, used when commands are registered globally
+func Clear(s *discordgo.Session, guildID string) error {
+	return syncDefinitions(s, guildID, []*discordgo.ApplicationCommand{})
+}
+
+func syncDefinitions(s *discordgo.Session, guildID string, definitions []*discordgo.ApplicationCommand) error {
+	existing, err := s.ApplicationCommands(s.State.User.ID, guildID)
+	if err != nil {
+		return fmt.Errorf("failed to get registered commands: %v", err)
+	}
+
+	if commandsEqual(existing, definitions) {
+		logger.Logger.Debug("Commands are up to date", zap.String("guildID", guildID))
+		return nil
+	}
+
+	logger.Logger.Info("Registering commands", zap.String("guildID", guildID), zap.Int("registered", len(existing)), zap.Int("defined", len(definitions)))
+	_, err = s.ApplicationCommandBulkOverwrite(s.State.User.ID, guildID, definitions)
+	if err != nil {
+		return fmt.Errorf("failed to register commands: %v", err)
+	}
+	return nil
+}
+
+// commandsEqual compares registered commands with definitions, ignoring the order and the fields Discord fills in
+func commandsEqual(registered, defined []*discordgo.ApplicationCommand) bool {
+	if len(registered) != len(defined) {
+		return false
+	}
+
+	byName := make(map[string]string, len(registered))
+	for _, cmd := range registered {
+		byName[cmd.Name] = commandKey(cmd)
+	}
+	for _, cmd := range defined {
+		if key, ok := byName[cmd.Name]; !ok || key != commandKey(cmd) {
+			return false
+		}
+	}
+	return true
+}
+
+// commandKey renders the fields of a command that are part of its definition
+func commandKey(cmd *discordgo.ApplicationCommand) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s|%s|", cmd.Name, cmd.Description)
+	if cmd.DefaultMemberPermissions != nil {
+		fmt.Fprintf(&b, "%d", *cmd.DefaultMemberPermissions)
+	}
+	writeOptions(&b, cmd.Options)
+	return b.String()
+}
+
+func writeOptions(b *strings.Builder, options []*discordgo.ApplicationCommandOption) {
+	for _, o := range options {
+		fmt.Fprintf(b, "(%d|%s|%s|%t|%t", o.Type, o.Name, o.Description, o.Required, o.Autocomplete)
+		for _, c := range o.Choices {
+			fmt.Fprintf(b, "[%s=%v]", c.Name, c.Value)
+		}
+		if o.MinValue != nil {
+			fmt.Fprintf(b, "min%v", *o.MinValue)
+		}
+		fmt.Fprintf(b, "max%v", o.MaxValue)
+		if o.MinLength != nil {
+			fmt.Fprintf(b, "minlen%d", *o.MinLength)
+		}
+		fmt.Fprintf(b, "maxlen%d", o.MaxLength)
+		writeOptions(b, o.Options)
+		b.WriteString(")")
+	}
+}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestCommandsAreComplete(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, cmd := range all {
+		name := cmd.Definition.Name
+		if seen[name] {
+			t.Errorf("Command %s is registered twice", name)
+		}
+		seen[name] = true
+		if cmd.Handler == nil {
+			t.Errorf("Command %s has no handler", name)
+		}
+		if hasAutocomplete(cmd.Definition.Options) && cmd.Autocomplete == nil {
+			t.Errorf("Command %s has autocomplete options but no autocomplete handler", name)
+		}
+	}
+}
+
+func hasAutocomplete(options []*discordgo.ApplicationCommandOption) bool {
+	for _, o := range options {
+		if o.Autocomplete || hasAutocomplete(o.Options) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCommandsEqual(t *testing.T) {
+	permission := int64(discordgo.PermissionManageChannels)
+	defined := []*discordgo.ApplicationCommand{
+		{Name: "ping", Description: "Responds with Pong!"},
+		{
+			Name:                     "settings",
+			Description:              "Configure",
+			DefaultMemberPermissions: &permission,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "min-lp",
+					Description: "Minimum LP",
+					Options: []*discordgo.ApplicationCommandOption{
+						{Type: discordgo.ApplicationCommandOptionInteger, Name: "lp", Description: "LP", Required: true,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{{Name: "ten", Value: 10}}},
+					},
+				},
+			},
+		},
+	}
+
+	// Discord returns the commands with IDs, in any order and with numbers decoded as float64
+	registered := []*discordgo.ApplicationCommand{
+		{
+			ID:                       "2",
+			ApplicationID:            "app",
+			Name:                     "settings",
+			Description:              "Configure",
+			DefaultMemberPermissions: &permission,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "min-lp",
+					Description: "Minimum LP",
+					Options: []*discordgo.ApplicationCommandOption{
+						{Type: discordgo.ApplicationCommandOptionInteger, Name: "lp", Description: "LP", Required: true,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{{Name: "ten", Value: float64(10)}}},
+					},
+				},
+			},
+		},
+		{ID: "1", ApplicationID: "app", Name: "ping", Description: "Responds with Pong!"},
+	}
+
+	if !commandsEqual(registered, defined) {
+		t.Errorf("Expected registered commands to match their definitions")
+	}
+
+	registered[1].Description = "Changed"
+	if commandsEqual(registered, defined) {
+		t.Errorf("Expected a changed description to be detected")
+	}
+	registered[1].Description = "Responds with Pong!"
+
+	registered[0].Options[0].Options[0].Required = false
+	if commandsEqual(registered, defined) {
+		t.Errorf("Expected a changed nested option to be detected")
+	}
+
+	if commandsEqual(registered[:1], defined) {
+		t.Errorf("Expected a missing command to be detected")
+	}
+}
@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"fmt"
+
+	"discord-bot/internal/app/features/roles"
+	roleTypes "discord-bot/types/roles"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// rolesCommand configures Discord roles that mirror the ranked tier of verified accounts.
+var rolesCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "roles",
+		Description: "Configure roles that mirror the ranked tier of verified accounts",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "set",
+				Description: "Map a tier to a role",
+				Options: []*discordgo.ApplicationCommandOption{
+					roleQueueOption,
+					roleTierOption,
+					{
+						Type:        discordgo.ApplicationCommandOptionRole,
+						Name:        "role",
+						Description: "Role members of this tier get",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "remove",
+				Description: "Remove the role of a tier",
+				Options:     []*discordgo.ApplicationCommandOption{roleQueueOption, roleTierOption},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "show",
+				Description: "Show the configured rank roles",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "preview",
+				Description: "Show which roles a sync would change without applying them",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "sync",
+				Description: "Update the roles of members with verified accounts tracked in this server now",
+			},
+		},
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		subcommand := i.ApplicationCommandData().Options[0]
+		options := optionsByName(subcommand.Options)
+		queueType := stringOption(options, "queue", roleTypes.QueueSolo)
+		tier := stringOption(options, "tier", "")
+
+		switch subcommand.Name {
+		case "set":
+			role := options["role"].RoleValue(nil, "")
+			content := fmt.Sprintf("%s in %s now gives <@&%s>", tier, queueType, role.ID)
+			if err := roles.SetRole(i.GuildID, queueType, tier, role.ID); err != nil {
+				content = fmt.Sprintf("Failed to set rank role: %v", err)
+			}
+			respondEphemeral(s, i, &discordgo.InteractionResponseData{Content: content})
+		case "remove":
+			content := fmt.Sprintf("The role of %s in %s has been removed", tier, queueType)
+			if err := roles.RemoveRole(i.GuildID, queueType, tier); err != nil {
+				content = fmt.Sprintf("Failed to remove rank role: %v", err)
+			}
+			respondEphemeral(s, i, &discordgo.InteractionResponseData{Content: content})
+		case "show":
+			message, err := roles.ShowRoles(i.GuildID)
+			if err != nil {
+				respondEphemeral(s, i, &discordgo.InteractionResponseData{Content: fmt.Sprintf("Failed to show rank roles: %v", err)})
+				return
+			}
+			respondEphemeral(s, i, &discordgo.InteractionResponseData{Embeds: []*discordgo.MessageEmbed{message}})
+		case "preview", "sync":
+			// Syncing fetches every member with a verified account, which can take a while
+			if !deferResponse(s, i) {
+				return
+			}
+			dryRun := subcommand.Name == "preview"
+			changes, err := roles.SyncGuild(i.GuildID, dryRun)
+			if err != nil {
+				errormessage := fmt.Sprintf("Failed to %s rank roles: %v", subcommand.Name, err)
+				s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+					Content: &errormessage,
+				})
+				return
+			}
+			s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Embeds: &[]*discordgo.MessageEmbed{roles.ChangesEmbed(changes, dryRun)},
+			})
+		}
+	},
+}
+
+var roleQueueOption = &discordgo.ApplicationCommandOption{
+	Type:        discordgo.ApplicationCommandOptionString,
+	Name:        "queue",
+	Description: "Queue whose tier the role mirrors",
+	Required:    true,
+	Choices: []*discordgo.ApplicationCommandOptionChoice{
+		{Name: "solo/duo", Value: roleTypes.QueueSolo},
+		{Name: "flex", Value: roleTypes.QueueFlex},
+	},
+}
+
+var roleTierOption = &discordgo.ApplicationCommandOption{
+	Type:        discordgo.ApplicationCommandOptionString,
+	Name:        "tier",
+	Description: "Ranked tier",
+	Required:    true,
+	Choices:     tierChoices(),
+}
+
+// platformChoices returns the sorted League platforms as command option choices
+func tierChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, tier := range roleTypes.Tiers {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: tier, Value: tier})
+	}
+	return choices
+}
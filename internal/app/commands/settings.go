@@ -0,0 +1,308 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"discord-bot/internal/app/features/settings"
+	"discord-bot/internal/logger"
+	"discord-bot/types/profilelink"
+	"discord-bot/types/queue"
+	settingsTypes "discord-bot/types/settings"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// settingsCommand configures which notifications the channel receives.
+var settingsCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "settings",
+		Description: "Configure the notifications of this channel",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "show",
+				Description: "Show the settings of this channel",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "notifications",
+				Description: "Enable or disable match start, rank update, mastery, Clash and Riot status notifications",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "start",
+						Description: "Notify when a match starts",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "end",
+						Description: "Notify about rank changes after a match",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "mastery",
+						Description: "Notify about champion mastery levels and point milestones",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "clash",
+						Description: "Notify about Clash registrations, tournament days and Clash games",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "status",
+						Description: "Notify about Riot incidents and maintenances on the regions of tracked summoners",
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "queue",
+				Description: "Only notify about games of a queue",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "queue",
+						Description: "Queue to notify about",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "all", Value: settingsTypes.QueueAll},
+							{Name: "solo/duo", Value: settingsTypes.QueueSolo},
+							{Name: "flex", Value: settingsTypes.QueueFlex},
+						},
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "other-queues",
+				Description: "Also notify about games of a non-ranked queue like ARAM or Arena",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "queue",
+						Description: "Non-ranked queue",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "normal (draft, blind, quickplay)", Value: queue.CategoryNormal},
+							{Name: "ARAM", Value: queue.CategoryARAM},
+							{Name: "Arena", Value: queue.CategoryArena},
+							{Name: "Swiftplay", Value: queue.CategorySwiftplay},
+							{Name: "URF", Value: queue.CategoryURF},
+							{Name: "custom", Value: queue.CategoryCustom},
+							{Name: "other", Value: queue.CategoryOther},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "enabled",
+						Description: "Notify about games of this queue",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "min-lp",
+				Description: "Hide rank updates with a smaller LP change",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "lp",
+						Description: "Minimum LP change (0 shows every update)",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "images",
+				Description: "Attach match images to rank updates",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "enabled",
+						Description: "Attach match images",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "language",
+				Description: "Language of the notifications",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "language",
+						Description: "Notification language",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "English", Value: "en"},
+							{Name: "Deutsch", Value: "de"},
+						},
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "quiet-hours",
+				Description: "Suppress notifications during some hours, leave empty to disable",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "start",
+						Description: "Hour quiet hours start (0-23)",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "end",
+						Description: "Hour quiet hours end (0-23)",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "timezone",
+						Description: "IANA timezone, e.g. Europe/Berlin (default: UTC)",
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "profile-links",
+				Description: "Choose the site profile links point to, applies to the whole server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "site",
+						Description: "Profile site",
+						Required:    true,
+						Choices:     profileSiteChoices(),
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "theme",
+				Description: "Choose the image of rank updates, the scoreboard or a build image theme, applies to the whole server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "name",
+						Description:  "Theme name",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+		},
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		subcommand := i.ApplicationCommandData().Options[0]
+		options := optionsByName(subcommand.Options)
+
+		var message *discordgo.MessageEmbed
+		var err error
+		switch subcommand.Name {
+		case "show":
+			message, err = settings.Show(i.ChannelID, i.GuildID)
+		case "notifications":
+			message, err = settings.Update(i.ChannelID, i.GuildID, func(c *settingsTypes.ChannelSettings) error {
+				if option, ok := options["start"]; ok {
+					c.NotifyStart = option.BoolValue()
+				}
+				if option, ok := options["end"]; ok {
+					c.NotifyEnd = option.BoolValue()
+				}
+				if option, ok := options["mastery"]; ok {
+					c.NotifyMastery = option.BoolValue()
+				}
+				if option, ok := options["clash"]; ok {
+					c.NotifyClash = option.BoolValue()
+				}
+				if option, ok := options["status"]; ok {
+					c.NotifyStatus = option.BoolValue()
+				}
+				return nil
+			})
+		case "queue":
+			message, err = settings.Update(i.ChannelID, i.GuildID, settings.SetQueueFilter(stringOption(options, "queue", settingsTypes.QueueAll)))
+		case "other-queues":
+			message, err = settings.Update(i.ChannelID, i.GuildID, settings.SetOptInQueue(stringOption(options, "queue", ""), options["enabled"].BoolValue()))
+		case "min-lp":
+			message, err = settings.Update(i.ChannelID, i.GuildID, settings.SetMinLPChange(int(intOption(options, "lp", 0))))
+		case "images":
+			message, err = settings.Update(i.ChannelID, i.GuildID, func(c *settingsTypes.ChannelSettings) error {
+				c.ImagesEnabled = options["enabled"].BoolValue()
+				return nil
+			})
+		case "language":
+			message, err = settings.Update(i.ChannelID, i.GuildID, settings.SetLanguage(stringOption(options, "language", "en")))
+		case "quiet-hours":
+			start := intOption(options, "start", -1)
+			end := intOption(options, "end", -1)
+			if start < 0 || end < 0 {
+				start, end = -1, -1
+			}
+			message, err = settings.Update(i.ChannelID, i.GuildID, settings.SetQuietHours(int(start), int(end), stringOption(options, "timezone", "UTC")))
+		case "profile-links":
+			message, err = settings.SetProfileSite(i.ChannelID, i.GuildID, stringOption(options, "site", profilelink.Default))
+		case "theme":
+			message, err = settings.SetImageTheme(i.ChannelID, i.GuildID, stringOption(options, "name", settingsTypes.ScoreboardTheme))
+		}
+
+		response := &discordgo.InteractionResponseData{}
+		if err != nil {
+			response.Content = fmt.Sprintf("Failed to update settings: %v", err)
+			response.Flags = discordgo.MessageFlagsEphemeral
+		} else {
+			response.Embeds = []*discordgo.MessageEmbed{message}
+		}
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: response,
+		})
+	},
+	Autocomplete: themeAutocomplete,
+}
+
+func profileSiteChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, site := range profilelink.Sites {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  site.Name,
+			Value: site.ID,
+		})
+	}
+	return choices
+}
+
+// themeAutocomplete suggests the loaded image themes, custom themes are only known at runtime
+func themeAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var typed string
+	for _, subcommand := range i.ApplicationCommandData().Options {
+		for _, option := range subcommand.Options {
+			if option.Focused {
+				typed = strings.ToLower(option.StringValue())
+			}
+		}
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, theme := range settings.ImageThemes() {
+		if strings.Contains(theme, typed) && len(choices) < 25 {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: theme, Value: theme})
+		}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to respond to autocomplete", zap.Error(err))
+	}
+}
@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"fmt"
+
+	"discord-bot/internal/app/features/listing"
+	"discord-bot/internal/app/features/offboarding"
+	"discord-bot/internal/app/features/onboarding"
+	"discord-bot/internal/logger"
+	"discord-bot/types/subscription"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// addCommand adds a new summoner with the options "name" (Ingame Name or Name#TAG) and "tag" (Your Riot Tag).
+var addCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "add",
+		Description: "Add a new Summoner",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "region",
+				Description: "Your League Region",
+				Required:    true,
+				Choices:     platformChoices(),
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "name",
+				Description: "Ingame Name or full Riot ID (Name#TAG)",
+				Required:    true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "tag",
+				Description: "Riot Tag (optional if the name is Name#TAG)",
+			},
+			gamesOption(false),
+		},
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		options := optionsByName(i.ApplicationCommandData().Options)
+		region := stringOption(options, "region", "")
+		games := stringOption(options, "games", subscription.GamesLoL)
+		id, err := riotIDOption(options)
+		if err != nil {
+			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: fmt.Sprintf("Failed to onboard summoner: %v", err),
+				},
+			})
+			return
+		}
+		go func() {
+			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: "Adding " + id.String() + "... Waiting for RIOT API. Depending on the server load, this may take a while.",
+				},
+			})
+		}()
+		message, err := onboarding.OnboardSummoner(id.Name, id.Tag, region, i.ChannelID, i.GuildID, games)
+		if err != nil {
+			errormessage := fmt.Sprintf("Failed to onboard summoner: %v", err)
+			s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Content: &errormessage,
+			})
+			return
+		}
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content: new(string),
+			Embeds:  &[]*discordgo.MessageEmbed{message},
+		})
+	},
+}
+
+// deleteCommand deletes a summoner with the options "name" (Ingame Name or Name#TAG) and "tag" (Your Riot Tag).
+var deleteCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "delete",
+		Description: "Delete a summoner",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "name",
+				Description:  "Ingame Name or full Riot ID (Name#TAG)",
+				Required:     true,
+				Autocomplete: true,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "tag",
+				Description:  "Riot Tag (optional if the name is Name#TAG)",
+				Autocomplete: true,
+			},
+		},
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		options := optionsByName(i.ApplicationCommandData().Options)
+		id, err := riotIDOption(options)
+		if err == nil {
+			logger.Logger.Info("Deleting summoner", zap.String("summoner", id.String()))
+			err = offboarding.DeleteSummoner(id.Name, id.Tag, i.ChannelID)
+		}
+		if err != nil {
+			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: fmt.Sprintf("Failed to delete summoner: %v", err),
+				},
+			})
+			return
+		}
+
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: fmt.Sprintf("Summoner %v has been deleted", id),
+			},
+		})
+	},
+	Autocomplete: summonerAutocomplete,
+}
+
+// followCommand changes which games the channel follows for a tracked summoner with the options "name", "tag" and "games".
+var followCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "follow",
+		Description: "Choose which games of a tracked summoner are posted",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "name",
+				Description:  "Ingame Name or full Riot ID (Name#TAG)",
+				Required:     true,
+				Autocomplete: true,
+			},
+			gamesOption(true),
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "tag",
+				Description:  "Riot Tag (optional if the name is Name#TAG)",
+				Autocomplete: true,
+			},
+		},
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		options := optionsByName(i.ApplicationCommandData().Options)
+		games := stringOption(options, "games", subscription.GamesLoL)
+		id, err := riotIDOption(options)
+		if err == nil {
+			_, err = onboarding.SetGames(id.Name, id.Tag, i.ChannelID, games)
+		}
+		if err != nil {
+			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: fmt.Sprintf("Failed to change followed games: %v", err),
+				},
+			})
+			return
+		}
+
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: fmt.Sprintf("This channel now follows %v in %v", id, onboarding.GamesName(games)),
+			},
+		})
+	},
+	Autocomplete: summonerAutocomplete,
+}
+
+// gamesOption lets a subscription choose between League of Legends, Teamfight Tactics or both
+func gamesOption(required bool) *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "games",
+		Description: "Games to post (default: League of Legends)",
+		Required:    required,
+		Choices: []*discordgo.ApplicationCommandOptionChoice{
+			{Name: "League of Legends", Value: subscription.GamesLoL},
+			{Name: "Teamfight Tactics", Value: subscription.GamesTFT},
+			{Name: "Both", Value: subscription.GamesBoth},
+		},
+	}
+}
+
+// listCommand lists the summoners tracked in the channel or guild.
+var listCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "list",
+		Description: "List the tracked summoners",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "scope",
+				Description: "List this channel or the whole server (default: channel)",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "channel", Value: listing.ScopeChannel},
+					{Name: "server", Value: listing.ScopeGuild},
+				},
+			},
+		},
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		options := optionsByName(i.ApplicationCommandData().Options)
+		message, components, err := listing.ListPage(stringOption(options, "scope", listing.ScopeChannel), i.ChannelID, i.GuildID, 0)
+		if err != nil {
+			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: fmt.Sprintf("Failed to list summoners: %v", err),
+				},
+			})
+			return
+		}
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{message},
+				Components: components,
+			},
+		})
+	},
+	Components: map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate){
+		listing.ComponentPrefix: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			scope, page, err := listing.ParseComponentID(i.MessageComponentData().CustomID)
+			if err != nil {
+				logger.Logger.Warn("Invalid list component", zap.Error(err))
+				return
+			}
+			message, components, err := listing.ListPage(scope, i.ChannelID, i.GuildID, page)
+			if err != nil {
+				logger.Logger.Error("Failed to list summoners", zap.Error(err))
+				return
+			}
+			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseUpdateMessage,
+				Data: &discordgo.InteractionResponseData{
+					Embeds:     []*discordgo.MessageEmbed{message},
+					Components: components,
+				},
+			})
+		},
+	},
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"discord-bot/internal/app/features/transfer"
+	"discord-bot/internal/logger"
+	transferTypes "discord-bot/types/transfer"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// interactionTokenLifetime is how long the reply of an interaction can be edited
+const interactionTokenLifetime = 15 * time.Minute
+
+// exportCommand attaches a file with the subscriptions of the channel or guild.
+var exportCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "export",
+		Description: "Export the tracked summoners to a file",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "scope",
+				Description: "Export this channel or the whole server (default: channel)",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "channel", Value: transfer.ScopeChannel},
+					{Name: "server", Value: transfer.ScopeGuild},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "format",
+				Description: "File format (default: json)",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "json", Value: transferTypes.FormatJSON},
+					{Name: "csv", Value: transferTypes.FormatCSV},
+				},
+			},
+		},
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		options := optionsByName(i.ApplicationCommandData().Options)
+		file, count, err := transfer.Export(stringOption(options, "scope", transfer.ScopeChannel), stringOption(options, "format", transferTypes.FormatJSON), i.ChannelID, i.GuildID)
+		if err != nil {
+			respondEphemeral(s, i, &discordgo.InteractionResponseData{Content: fmt.Sprintf("Failed to export summoners: %v", err)})
+			return
+		}
+		respondEphemeral(s, i, &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("Exported %d subscriptions. Use /import with this file to restore them.", count),
+			Files:   []*discordgo.File{file},
+		})
+	},
+}
+
+// importCommand tracks the summoners of an export file, resolving them in the background.
+var importCommand = &Command{
+	Definition: &discordgo.ApplicationCommand{
+		Name:        "import",
+		Description: "Import tracked summoners from an /export file",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionAttachment,
+				Name:        "file",
+				Description: fmt.Sprintf("JSON or CSV file with up to %d summoners", transfer.MaxImportEntries),
+				Required:    true,
+			},
+		},
+	},
+	Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		data := i.ApplicationCommandData()
+		attachmentID, _ := optionsByName(data.Options)["file"].Value.(string)
+		attachment, ok := data.Resolved.Attachments[attachmentID]
+		if !ok {
+			respondEphemeral(s, i, &discordgo.InteractionResponseData{Content: "Failed to import summoners: the file is missing"})
+			return
+		}
+
+		started := time.Now()
+		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to defer interaction response", zap.Error(err))
+			return
+		}
+
+		entries, err := transfer.Download(attachment.URL, attachment.Size)
+		if err != nil {
+			errormessage := fmt.Sprintf("Failed to import summoners: %v", err)
+			s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Content: &errormessage,
+			})
+			return
+		}
+
+		guildChannels := make(map[string]bool)
+		channels, err := s.GuildChannels(i.GuildID)
+		if err != nil {
+			logger.Logger.Warn("Failed to load guild channels, importing into the current channel", zap.String("guildID", i.GuildID), zap.Error(err))
+		}
+		for _, channel := range channels {
+			guildChannels[channel.ID] = true
+		}
+
+		// Resolving hundreds of summoners takes longer than Discord waits for a handler
+		go transfer.Import(entries, i.ChannelID, i.GuildID, guildChannels, func(progress *transfer.Progress) {
+			message := transfer.ProgressEmbed(progress)
+			if time.Since(started) < interactionTokenLifetime {
+				_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+					Embeds: &[]*discordgo.MessageEmbed{message},
+				})
+				if err == nil {
+					return
+				}
+				logger.Logger.Warn("Failed to edit import progress", zap.Error(err))
+			}
+			// The reply can no longer be edited, so only the final result is posted as a new message
+			if progress.Done == progress.Total {
+				if _, err := s.ChannelMessageSendEmbed(i.ChannelID, message); err != nil {
+					logger.Logger.Error("Failed to send import result", zap.Error(err))
+				}
+			}
+		})
+	},
+}
@@ -1,17 +1,33 @@
 package checkforsummonerupdate
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"discord-bot/internal/app/features/clash"
+	"discord-bot/internal/app/features/identity"
+	"discord-bot/internal/app/features/mastery"
+	"discord-bot/internal/app/features/roles"
+	settingsFeature "discord-bot/internal/app/features/settings"
+	"discord-bot/internal/app/features/tft"
 	apiHelper "discord-bot/internal/app/helper/api"
-	"discord-bot/internal/app/helper/cdragon"
+	"discord-bot/internal/app/helper/assetprovider"
+	assethelper "discord-bot/internal/app/helper/assets"
 	databaseHelper "discord-bot/internal/app/helper/database"
 	"discord-bot/internal/app/utility/gametoimage"
+	"discord-bot/internal/app/utility/i18n"
 	"discord-bot/internal/logger"
+	"discord-bot/types/account"
 	"discord-bot/types/embed"
+	"discord-bot/types/history"
+	"discord-bot/types/match"
+	"discord-bot/types/queue"
 	"discord-bot/types/rank"
+	"discord-bot/types/settings"
+	"discord-bot/types/subscription"
 	"discord-bot/types/summoner"
 
 	"github.com/bwmarrin/discordgo"
@@ -20,13 +36,83 @@ import (
 
 var discordSession *discordgo.Session
 
+// maintenanceBackoff is the pause after skipping a summoner whose platform is under maintenance, so the loop does not spin without API calls
+const maintenanceBackoff = 5 * time.Second
+
 // Initialize sets the Discord session and channel ID for sending messages
 func Initialize(session *discordgo.Session) {
 	discordSession = session
 }
 
+// shouldNotify checks the channel settings shared by all notification types, non-ranked queues other than Clash have to be opted in
+func shouldNotify(channelSettings *settings.ChannelSettings, enabled bool, q queue.Queue) bool {
+	allowed := channelSettings.AllowsQueue(q.Name())
+	if q.Category() == queue.CategoryClash {
+		allowed = channelSettings.AllowsClash()
+	} else if !q.IsRanked() {
+		allowed = channelSettings.AllowsNonRankedQueue(q.Category())
+	}
+	return enabled && allowed && !channelSettings.IsQuiet(time.Now())
+}
+
+// renderScoreboard renders the scoreboard of a finished match with the tracked participants highlighted, nil if rendering failed
+func renderScoreboard(finishedMatch *match.Match, tracked map[string]bool) []byte {
+	scoreboard, err := gametoimage.ScoreboardToImage(finishedMatch, tracked)
+	if err != nil {
+		logger.Logger.Error("Failed to generate scoreboard image", zap.Error(err))
+		return nil
+	}
+	return scoreboard.Bytes()
+}
+
+// renderBuild renders the build image of a participant in a theme, nil if rendering failed
+func renderBuild(participant match.Participant, gameVersion, theme string) []byte {
+	build, err := gametoimage.GameToImage(participant, gameVersion, theme)
+	if err != nil {
+		logger.Logger.Error("Failed to generate build image", zap.String("theme", theme), zap.Error(err))
+		return nil
+	}
+	return build.Bytes()
+}
+
+// renderMatchStart renders the loading-screen image of an ongoing match with the tracked participants highlighted, nil if rendering failed
+func renderMatchStart(ongoingMatch *match.Match, tracked map[string]bool) []byte {
+	loadingScreen, err := gametoimage.LiveGameToImage(ongoingMatch, tracked)
+	if err != nil {
+		logger.Logger.Error("Failed to generate match start image", zap.Error(err))
+		return nil
+	}
+	return loadingScreen.Bytes()
+}
+
+// imageTheme returns the image theme chosen by a guild, the scoreboard if the guild settings cannot be loaded
+func imageTheme(guildID string) string {
+	guildSettings, err := databaseHelper.GetGuildSettings(guildID)
+	if err != nil {
+		logger.Logger.Warn("Failed to load guild settings, using the scoreboard", zap.String("guildID", guildID), zap.Error(err))
+		return settings.ScoreboardTheme
+	}
+	return guildSettings.ImageTheme
+}
+
+// mentionsFor returns the mentions of all users who linked a summoner and opted in, empty if there are none
+func mentionsFor(puuid string) string {
+	users, err := databaseHelper.GetMentionUsersForSummoner(puuid)
+	if err != nil {
+		logger.Logger.Error("Failed to get mention users for summoner", zap.String("puuid", puuid), zap.Error(err))
+		return ""
+	}
+
+	var mentions []string
+	for _, userID := range users {
+		mentions = append(mentions, account.Mention(userID))
+	}
+	return strings.Join(mentions, " ")
+}
+
 func checkAndSendRankUpdate(summoner summoner.Summoner) error {
 	var pretttyRank, rankType string = "", ""
+	var rankedQueue queue.Queue
 
 	newSoloRank, newFlexRank, err := apiHelper.GetSummonerRank(summoner.ID, summoner.Region)
 	if err != nil {
@@ -37,9 +123,11 @@ func checkAndSendRankUpdate(summoner summoner.Summoner) error {
 	if newSoloRank != summoner.SoloRank {
 		pretttyRank = "Solo/Duo"
 		rankType = "Solo"
+		rankedQueue = assethelper.GetQueue(queue.RankedSoloID)
 	} else if newFlexRank != summoner.FlexRank {
 		pretttyRank = "Flex"
 		rankType = "Flex"
+		rankedQueue = assethelper.GetQueue(queue.RankedFlexID)
 	} else {
 		return nil
 	}
@@ -74,11 +162,27 @@ func checkAndSendRankUpdate(summoner summoner.Summoner) error {
 		return fmt.Errorf("last match is nil")
 	}
 
+	champions := assethelper.GetCatalog(lastMatch.GameVersion)
+
+	// Participants mapped to any channel get a rank update and are highlighted on the scoreboard
+	tracked := make(map[string]bool)
 	for i := 0; i < len(lastMatch.Teams); i++ {
 		for _, participant := range lastMatch.Teams[i].Participants {
-			// If Participant is not mapped to any channel, skip
 			participantIsMapped, err := databaseHelper.IsSummonerMappedToAnyChannel(participant.Summoner.PUUID)
-			if !participantIsMapped || err != nil {
+			if participantIsMapped && err == nil {
+				tracked[participant.Summoner.PUUID] = true
+			}
+		}
+	}
+
+	// The scoreboard is rendered once per match and only if a channel wants it
+	var scoreboardImage []byte
+	scoreboardRendered := false
+
+	for i := 0; i < len(lastMatch.Teams); i++ {
+		for _, participant := range lastMatch.Teams[i].Participants {
+			// If Participant is not mapped to any channel, skip
+			if !tracked[participant.Summoner.PUUID] {
 				continue
 			}
 
@@ -96,16 +200,31 @@ func checkAndSendRankUpdate(summoner summoner.Summoner) error {
 				}
 			}
 
-			var currentRank, rankChange rank.Rank
+			var currentRank, previousRank, rankChange rank.Rank
 			rankChangeString := ""
 
 			switch rankType {
 			case "Solo":
 				currentRank = newparticipantSoloRank
-				rankChange = rank.Rank(rank.RankDifference(newparticipantSoloRank, participant.Summoner.SoloRank))
+				previousRank = participant.Summoner.SoloRank
 			case "Flex":
 				currentRank = newparticipantFlexRank
-				rankChange = rank.Rank(rank.RankDifference(newparticipantFlexRank, participant.Summoner.FlexRank))
+				previousRank = participant.Summoner.FlexRank
+			}
+			rankChange = rank.Rank(rank.RankDifference(currentRank, previousRank))
+
+			// Keep the rank history for digests
+			err = databaseHelper.SaveRankChange(history.RankChange{
+				SummonerPUUID: participant.Summoner.PUUID,
+				GameID:        lastmatchid,
+				QueueType:     rankType,
+				ChampionID:    participant.ChampionID,
+				OldRank:       previousRank,
+				NewRank:       currentRank,
+				Recorded:      time.Now().UTC(),
+			})
+			if err != nil {
+				logger.Logger.Error("Failed to save rank change", zap.Error(err))
 			}
 
 			if rankChange < 0 {
@@ -122,47 +241,77 @@ func checkAndSendRankUpdate(summoner summoner.Summoner) error {
 			rankTier = strings.ToLower(rankTier)
 			logger.Logger.Info("Rank tier", zap.String("rankTier", rankTier))
 
-			// Get the ranked picture URL
-			rankTierURL := cdragon.GetRankedPictureURL(rankTier)
-
-			knownChannels, err := databaseHelper.GetChannelsForSummoner(participant.Summoner.PUUID)
+			knownChannels, err := databaseHelper.GetChannelsForSummonerByGame(participant.Summoner.PUUID, subscription.GamesLoL)
 			if err != nil {
 				logger.Logger.Error("Failed to get channel by summoner PUUID", zap.Error(err))
 				continue
 			}
-			lastgameimage, err := gametoimage.GameToImage(participant)
-			if err != nil {
-				logger.Logger.Error("Failed to generate game image", zap.Error(err))
-				continue
-			}
-			encodedSummonerName := strings.ReplaceAll(participant.Summoner.Name, " ", "%20")
-			embedmessage := embed.NewEmbed().
-				SetAuthor(fmt.Sprintf("%v", participant.Summoner.GetNameTag()), cdragon.GetProfileIconURL(participant.Summoner.ProfileIconID), fmt.Sprintf("https://www.op.gg/summoners/euw/%v-%v", encodedSummonerName, participant.Summoner.TagLine), fmt.Sprintf("https://www.op.gg/summoners/euw/%v-%v", participant.Summoner.Name, participant.Summoner.TagLine)).
-				SetTitle(fmt.Sprintf("%v-Rank Update | %v LP", pretttyRank, rankChangeString)).
-				AddField("Solo/Duo-Rank", newparticipantSoloRank.ToString()).
-				AddField("Flex-Rank", newparticipantFlexRank.ToString()).
-				SetThumbnail(cdragon.GetChampionSquareURL(participant.ChampionID)).
-				SetImage("attachment://lastgameimage.png").
-				SetFooter(currentRank.ToString(), rankTierURL, fmt.Sprintf("https://www.op.gg/summoners/euw/%v-%v", encodedSummonerName, participant.Summoner.TagLine)).
-				SetColor(color).InlineAllFields().MessageEmbed
-
-			messageSend := &discordgo.MessageSend{
-				Embeds: []*discordgo.MessageEmbed{embedmessage},
-				Files: []*discordgo.File{
-					{
-						Name:   "lastgameimage.png",
-						Reader: lastgameimage,
-					},
-				},
-			}
+
+			mentions := mentionsFor(participant.Summoner.PUUID)
+			buildImages := make(map[string][]byte)
 			for _, knownChannel := range knownChannels {
+				channelSettings, err := databaseHelper.GetChannelSettings(knownChannel)
+				if err != nil {
+					logger.Logger.Error("Failed to get channel settings", zap.String("channel", knownChannel), zap.Error(err))
+					continue
+				}
+				if !shouldNotify(channelSettings, channelSettings.NotifyEnd, rankedQueue) || !channelSettings.AllowsLPChange(int(rankChange)) {
+					logger.Logger.Info("Skipping rank update notification due to channel settings", zap.String("channel", knownChannel))
+					continue
+				}
+
+				language := channelSettings.Language
+				profileURL := settingsFeature.ProfileURL(channelSettings.GuildID, &participant.Summoner)
+				attachments := assetprovider.NewAttachments()
+				embedmessage := embed.NewEmbed().
+					SetAuthor(participant.Summoner.GetNameTag(), attachments.URL(assetprovider.ProfileIcon(participant.Summoner.ProfileIconID)), profileURL).
+					SetTitle(i18n.T(language, i18n.RankUpdateTitle, pretttyRank, rankChangeString)).
+					AddField(i18n.T(language, i18n.SoloRank), newparticipantSoloRank.ToString()).
+					AddField(i18n.T(language, i18n.FlexRank), newparticipantFlexRank.ToString()).
+					AddField(i18n.T(language, i18n.Champion), champions.ChampionName(participant.ChampionID)).
+					SetThumbnail(attachments.URL(assetprovider.ChampionSquare(participant.ChampionID))).
+					SetFooter(currentRank.ToString(), attachments.URL(assetprovider.RankCrest(rankTier))).
+					SetColor(color).InlineAllFields()
+
+				messageSend := &discordgo.MessageSend{
+					Content:         mentions,
+					AllowedMentions: &discordgo.MessageAllowedMentions{Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers}},
+				}
+				if channelSettings.ImagesEnabled {
+					theme := imageTheme(channelSettings.GuildID)
+					imageName, image := "scoreboard.png", []byte(nil)
+					if theme == settings.ScoreboardTheme {
+						if !scoreboardRendered {
+							scoreboardImage = renderScoreboard(lastMatch, tracked)
+							scoreboardRendered = true
+						}
+						image = scoreboardImage
+					} else {
+						// Build images show only this participant, so they are rendered once per theme
+						if _, ok := buildImages[theme]; !ok {
+							buildImages[theme] = renderBuild(participant, lastMatch.GameVersion, theme)
+						}
+						imageName, image = "build.png", buildImages[theme]
+					}
+					if image != nil {
+						embedmessage.SetImage("attachment://" + imageName)
+						messageSend.Files = []*discordgo.File{
+							{
+								Name:   imageName,
+								Reader: bytes.NewReader(image),
+							},
+						}
+					}
+				}
+				messageSend.Embeds = []*discordgo.MessageEmbed{embedmessage.MessageEmbed}
+				messageSend.Files = append(messageSend.Files, attachments.Files()...)
+
 				_, err = discordSession.ChannelMessageSendComplex(knownChannel, messageSend)
 				if err != nil {
 					logger.Logger.Error("Failed to send embed message to Discord channel", zap.Error(err))
 
 				}
 			}
-			lastgameimage.Close()
 			participant.Summoner.SoloRank = newparticipantSoloRank
 			participant.Summoner.FlexRank = newparticipantFlexRank
 			participant.Summoner.Updated = time.Now()
@@ -171,6 +320,9 @@ func checkAndSendRankUpdate(summoner summoner.Summoner) error {
 				logger.Logger.Error("Failed to save summoner to DB", zap.Error(err))
 				return err
 			}
+
+			// Keep the rank roles of linked Discord users in sync with the new rank
+			roles.SyncSummoner(participant.Summoner.PUUID)
 		}
 	}
 	// Save the match to the database
@@ -208,31 +360,38 @@ func checkForOngoingGames(checksummoner *summoner.Summoner) {
 		return
 	}
 
-	if ongoingMatch.GameType == "UNRANKED" {
-		logger.Logger.Info("Ongoing match is unranked for summoner", zap.String("nameTag", checksummoner.GetNameTag()))
-		return
-	}
+	champions := assethelper.GetCatalog("")
 
-	// Iterate over each team in the ongoing match
-	for teamid, team := range ongoingMatch.Teams {
-		// Iterate over each participant in the team
+	// Participants mapped to any channel get a notification and are highlighted on the match start image
+	tracked := make(map[string]bool)
+	for _, team := range ongoingMatch.Teams {
 		for _, participant := range team.Participants {
-			// Check if the summoner is registered
 			summonerMapped, err := databaseHelper.IsSummonerMappedToAnyChannel(participant.Summoner.PUUID)
 			if err != nil {
 				logger.Logger.Error("Failed to check if summoner is registered", zap.Error(err))
 				continue
 			}
 			if summonerMapped {
+				tracked[participant.Summoner.PUUID] = true
+			}
+		}
+	}
+
+	// The match start image is rendered once per match and only if a channel wants it
+	var matchStartImage []byte
+	matchStartRendered := false
+
+	// Iterate over each team in the ongoing match
+	for teamid, team := range ongoingMatch.Teams {
+		// Iterate over each participant in the team
+		for _, participant := range team.Participants {
+			if tracked[participant.Summoner.PUUID] {
 				logger.Logger.Info("Summoner is mapped to a channel", zap.String("nameTag", participant.Summoner.GetNameTag()))
 
-				var rank rank.Rank
-				if ongoingMatch.GameType == "Solo/Duo" {
-					rank = participant.Summoner.SoloRank
-				} else if ongoingMatch.GameType == "Flex" {
+				// Non-ranked queues show the Solo/Duo rank
+				rank := participant.Summoner.SoloRank
+				if ongoingMatch.Queue.Category() == queue.CategoryFlex {
 					rank = participant.Summoner.FlexRank
-				} else if ongoingMatch.GameType == "UNRANKED" {
-					continue
 				}
 
 				enemyteamid := 1
@@ -243,33 +402,74 @@ func checkForOngoingGames(checksummoner *summoner.Summoner) {
 				rankTier := strings.Split(rank.ToString(), " ")[0]
 				rankTier = strings.ToLower(rankTier)
 
-				// Get the ranked picture URL
-				rankTierURL := cdragon.GetRankedPictureURL(rankTier)
-
-				knownChannels, err := databaseHelper.GetChannelsForSummoner(participant.Summoner.PUUID)
+				knownChannels, err := databaseHelper.GetChannelsForSummonerByGame(participant.Summoner.PUUID, subscription.GamesLoL)
 				if err != nil {
 					logger.Logger.Error("Failed to get channel by summoner PUUID", zap.Error(err))
 					continue
 				}
 
-				encodedSummonerName := strings.ReplaceAll(participant.Summoner.Name, " ", "%20")
+				mentions := mentionsFor(participant.Summoner.PUUID)
 
-				// Send a message to the Discord channel
-				embedmessage := embed.NewEmbed().
-					SetAuthor(fmt.Sprintf("%v", participant.Summoner.GetNameTag()), cdragon.GetProfileIconURL(participant.Summoner.ProfileIconID), fmt.Sprintf("https://www.op.gg/summoners/euw/%v-%v", encodedSummonerName, participant.Summoner.TagLine), fmt.Sprintf("https://www.op.gg/summoners/euw/%v-%v", encodedSummonerName, participant.Summoner.TagLine)).
-					SetTitle(fmt.Sprintf("A %v-Match has started!", ongoingMatch.GameType)).
-					AddField("Your Team Average Rank", ongoingMatch.Teams[teamid].AverageRank().ToString()).
-					AddField("Enemy Team Average Rank", ongoingMatch.Teams[enemyteamid].AverageRank().ToString()).
-					SetThumbnail(cdragon.GetChampionSquareURL(participant.ChampionID)).
-					SetFooter(rank.ToString(), rankTierURL, fmt.Sprintf("https://www.op.gg/summoners/euw/%v-%v", encodedSummonerName, participant.Summoner.TagLine)).
-					InlineAllFields().MessageEmbed
+				// Clash games show the team the summoner plays for, it is resolved once a channel wants the message
+				var clashTeam string
+				clashTeamResolved := false
 
-				messageSend := &discordgo.MessageSend{
-					Embeds: []*discordgo.MessageEmbed{embedmessage},
-				}
 				for _, knownChannel := range knownChannels {
+					channelSettings, err := databaseHelper.GetChannelSettings(knownChannel)
+					if err != nil {
+						logger.Logger.Error("Failed to get channel settings", zap.String("channel", knownChannel), zap.Error(err))
+						continue
+					}
+					if !shouldNotify(channelSettings, channelSettings.NotifyStart, ongoingMatch.Queue) {
+						logger.Logger.Info("Skipping ongoing match notification due to channel settings", zap.String("channel", knownChannel))
+						continue
+					}
+
+					if !clashTeamResolved && ongoingMatch.Queue.Category() == queue.CategoryClash {
+						clashTeam = clash.TeamName(participant.Summoner.PUUID, checksummoner.Region)
+						clashTeamResolved = true
+					}
+
+					// Send a message to the Discord channel
+					language := channelSettings.Language
+					profileURL := settingsFeature.ProfileURL(channelSettings.GuildID, &participant.Summoner)
+					attachments := assetprovider.NewAttachments()
+					embedmessage := embed.NewEmbed().
+						SetAuthor(participant.Summoner.GetNameTag(), attachments.URL(assetprovider.ProfileIcon(participant.Summoner.ProfileIconID)), profileURL).
+						SetTitle(i18n.T(language, i18n.MatchStartedTitle, ongoingMatch.Queue.Name())).
+						AddField(i18n.T(language, i18n.YourTeamAverage), ongoingMatch.Teams[teamid].AverageRank().ToString()).
+						AddField(i18n.T(language, i18n.EnemyTeamAverage), ongoingMatch.Teams[enemyteamid].AverageRank().ToString()).
+						AddField(i18n.T(language, i18n.Champion), champions.ChampionName(participant.ChampionID)).
+						SetThumbnail(attachments.URL(assetprovider.ChampionSquare(participant.ChampionID))).
+						SetFooter(rank.ToString(), attachments.URL(assetprovider.RankCrest(rankTier)))
+					if clashTeam != "" {
+						embedmessage.AddField(i18n.T(language, i18n.ClashTeam), clashTeam)
+					}
+					embedmessage.InlineAllFields()
+
+					messageSend := &discordgo.MessageSend{
+						Content:         mentions,
+						AllowedMentions: &discordgo.MessageAllowedMentions{Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers}},
+					}
+					if channelSettings.ImagesEnabled {
+						if !matchStartRendered {
+							matchStartImage = renderMatchStart(ongoingMatch, tracked)
+							matchStartRendered = true
+						}
+						if matchStartImage != nil {
+							embedmessage.SetImage("attachment://matchstart.png")
+							messageSend.Files = []*discordgo.File{
+								{
+									Name:   "matchstart.png",
+									Reader: bytes.NewReader(matchStartImage),
+								},
+							}
+						}
+					}
+					messageSend.Embeds = []*discordgo.MessageEmbed{embedmessage.MessageEmbed}
+					messageSend.Files = append(messageSend.Files, attachments.Files()...)
 					logger.Logger.Info("Sending ongoing match notification to channel", zap.String("channel", knownChannel))
-					_, err := discordSession.ChannelMessageSendComplex(knownChannel, messageSend)
+					_, err = discordSession.ChannelMessageSendComplex(knownChannel, messageSend)
 					if err != nil {
 						logger.Logger.Error("Failed to send embed message to Discord channel", zap.Error(err))
 					}
@@ -304,11 +504,48 @@ func CheckForUpdates() {
 		logger.Logger.Info("Time since last update", zap.Duration("duration", time.Since(oldestsummoner.Updated)))
 		logger.Logger.Info("Summoner details", zap.Any("summoner", oldestsummoner))
 
+		// Riot answers with errors during a maintenance, so the summoner is checked again after it ended
+		if apiHelper.UnderMaintenance(oldestsummoner.Region) {
+			logger.Logger.Info("Skipping summoner while its platform is under maintenance", zap.String("nameTag", oldestsummoner.GetNameTag()), zap.String("platform", oldestsummoner.Region))
+			databaseHelper.UpdateSummonerTimestamp(oldestsummoner.PUUID)
+			time.Sleep(maintenanceBackoff)
+			continue
+		}
+
+		// Follow renames and region transfers before calling the platform APIs
+		oldestsummoner, err = identity.Refresh(oldestsummoner, false)
+		if err != nil {
+			logger.Logger.Warn("Failed to refresh summoner identity", zap.Error(err))
+		}
+
 		// Compare summoners and process only if something changed
-		checkAndSendRankUpdate(*oldestsummoner)
+		err = checkAndSendRankUpdate(*oldestsummoner)
+		if errors.Is(err, apiHelper.ErrNotFound) {
+			// The summoner ID is unknown on the platform, e.g. after a region transfer
+			oldestsummoner, err = identity.Refresh(oldestsummoner, true)
+			if err != nil {
+				logger.Logger.Warn("Failed to refresh summoner identity", zap.Error(err))
+			}
+		}
 
+		checkForFinishedGame(oldestsummoner)
 		checkForOngoingGames(oldestsummoner)
 
+		err = mastery.Refresh(oldestsummoner)
+		if err != nil {
+			logger.Logger.Warn("Failed to refresh champion mastery", zap.Error(err))
+		}
+
+		err = tft.Refresh(oldestsummoner)
+		if err != nil {
+			logger.Logger.Warn("Failed to refresh TFT games", zap.Error(err))
+		}
+
+		err = clash.Refresh(oldestsummoner)
+		if err != nil {
+			logger.Logger.Warn("Failed to refresh Clash registration", zap.Error(err))
+		}
+
 		databaseHelper.UpdateSummonerTimestamp(oldestsummoner.PUUID)
 	}
 }
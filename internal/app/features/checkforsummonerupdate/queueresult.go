@@ -0,0 +1,173 @@
+package checkforsummonerupdate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"discord-bot/internal/app/features/clash"
+	settingsFeature "discord-bot/internal/app/features/settings"
+	apiHelper "discord-bot/internal/app/helper/api"
+	"discord-bot/internal/app/helper/assetprovider"
+	assethelper "discord-bot/internal/app/helper/assets"
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/app/utility/i18n"
+	"discord-bot/internal/logger"
+	"discord-bot/types/embed"
+	"discord-bot/types/match"
+	"discord-bot/types/queue"
+	"discord-bot/types/subscription"
+	"discord-bot/types/summoner"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// maxOngoingGameAge is how long a running game is looked up before it is given up, e.g. custom games never get a result
+const maxOngoingGameAge = 6 * time.Hour
+
+// checkForFinishedGame announces the result of a finished non-ranked game of a summoner.
+// Ranked games are announced by the rank update, and only games whose start was seen are announced,
+// so the first check of a summoner does not post an old game.
+func checkForFinishedGame(checksummoner *summoner.Summoner) {
+	if expired, err := databaseHelper.DeleteStaleOngoingNonRankedGames(checksummoner.PUUID, maxOngoingGameAge); err != nil {
+		logger.Logger.Error("Failed to expire stale ongoing games", zap.Error(err))
+	} else if expired > 0 {
+		logger.Logger.Info("Expired ongoing games without a result", zap.String("puuid", checksummoner.PUUID), zap.Int("games", expired))
+	}
+
+	ongoingGameIDs, err := databaseHelper.GetOngoingNonRankedGameIDs(checksummoner.PUUID)
+	if err != nil {
+		logger.Logger.Error("Failed to get ongoing games", zap.Error(err))
+		return
+	}
+
+	// Each stored game is looked up directly, so a newer game played in between does not hide it
+	for _, gameID := range ongoingGameIDs {
+		matchID := strings.ToUpper(checksummoner.Region) + "_" + gameID
+		finishedMatch, err := apiHelper.GetMatchByID(matchID)
+		if errors.Is(err, apiHelper.ErrNotFound) {
+			// The game is still running
+			continue
+		}
+		if err != nil {
+			logger.Logger.Error("Failed to fetch finished match", zap.String("matchID", matchID), zap.Error(err))
+			continue
+		}
+		announceFinishedGame(checksummoner, gameID, finishedMatch)
+	}
+}
+
+// announceFinishedGame posts the result of a finished game to the channels of its tracked participants
+// and stores it in place of the ongoing game
+func announceFinishedGame(checksummoner *summoner.Summoner, gameID string, finishedMatch *match.Match) {
+	tracked := make(map[string]bool)
+	for _, team := range finishedMatch.Teams {
+		for _, participant := range team.Participants {
+			if mapped, err := databaseHelper.IsSummonerMappedToAnyChannel(participant.Summoner.PUUID); err == nil && mapped {
+				tracked[participant.Summoner.PUUID] = true
+			}
+		}
+	}
+
+	// The scoreboard is rendered once per match and only if a channel wants it
+	var scoreboardImage []byte
+	scoreboardRendered := false
+	for _, team := range finishedMatch.Teams {
+		for _, participant := range team.Participants {
+			if !tracked[participant.Summoner.PUUID] {
+				continue
+			}
+			knownChannels, err := databaseHelper.GetChannelsForSummonerByGame(participant.Summoner.PUUID, subscription.GamesLoL)
+			if err != nil {
+				logger.Logger.Error("Failed to get channel by summoner PUUID", zap.Error(err))
+				continue
+			}
+			// Clash results show the team the summoner played for, it is resolved once a channel wants the message
+			var clashTeam string
+			clashTeamResolved := false
+			for _, knownChannel := range knownChannels {
+				channelSettings, err := databaseHelper.GetChannelSettings(knownChannel)
+				if err != nil {
+					logger.Logger.Error("Failed to get channel settings", zap.String("channel", knownChannel), zap.Error(err))
+					continue
+				}
+				if !shouldNotify(channelSettings, channelSettings.NotifyEnd, finishedMatch.Queue) {
+					continue
+				}
+				if !clashTeamResolved && finishedMatch.Queue.Category() == queue.CategoryClash {
+					clashTeam = clash.TeamName(participant.Summoner.PUUID, checksummoner.Region)
+					clashTeamResolved = true
+				}
+
+				embedmessage, attachments := queueResultEmbed(finishedMatch, participant, team.Win, clashTeam, channelSettings.Language, channelSettings.GuildID)
+				messageSend := &discordgo.MessageSend{
+					Content:         mentionsFor(participant.Summoner.PUUID),
+					AllowedMentions: &discordgo.MessageAllowedMentions{Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers}},
+				}
+				// The scoreboard has two teams of five, which does not fit Arena
+				if channelSettings.ImagesEnabled && finishedMatch.Queue.Category() != queue.CategoryArena {
+					if !scoreboardRendered {
+						scoreboardImage = renderScoreboard(finishedMatch, tracked)
+						scoreboardRendered = true
+					}
+					if scoreboardImage != nil {
+						embedmessage.SetImage("attachment://scoreboard.png")
+						messageSend.Files = []*discordgo.File{{Name: "scoreboard.png", Reader: bytes.NewReader(scoreboardImage)}}
+					}
+				}
+				messageSend.Embeds = []*discordgo.MessageEmbed{embedmessage.MessageEmbed}
+				messageSend.Files = append(messageSend.Files, attachments.Files()...)
+
+				_, err = discordSession.ChannelMessageSendComplex(knownChannel, messageSend)
+				if err != nil {
+					logger.Logger.Error("Failed to send match result to Discord channel", zap.String("channel", knownChannel), zap.Error(err))
+				}
+			}
+		}
+	}
+
+	if err := databaseHelper.UpdateOngoingToFinishedGame(gameID, finishedMatch); err != nil {
+		logger.Logger.Error("Failed to update ongoing game to finished", zap.Error(err))
+	}
+}
+
+// queueResultEmbed builds the result of a non-ranked game: Arena shows the placement, other queues victory or defeat.
+// clashTeam is shown for Clash games and may be empty.
+func queueResultEmbed(finishedMatch *match.Match, participant match.Participant, win bool, clashTeam, language, guildID string) (*embed.Embed, *assetprovider.Attachments) {
+	champions := assethelper.GetCatalog(finishedMatch.GameVersion)
+	stats := participant.Stats
+
+	var title string
+	if finishedMatch.Queue.Category() == queue.CategoryArena {
+		title = i18n.T(language, i18n.ArenaResultTitle, stats.Placement)
+		win = stats.Placement > 0 && stats.Placement <= 4
+	} else {
+		result := i18n.T(language, i18n.Defeat)
+		if win {
+			result = i18n.T(language, i18n.Victory)
+		}
+		title = i18n.T(language, i18n.QueueResultTitle, finishedMatch.Queue.Name(), result)
+	}
+	color := 0x00ff00
+	if !win {
+		color = 0xff0000
+	}
+
+	attachments := assetprovider.NewAttachments()
+	e := embed.NewEmbed().
+		SetAuthor(participant.Summoner.GetNameTag(), attachments.URL(assetprovider.ProfileIcon(participant.Summoner.ProfileIconID)), settingsFeature.ProfileURL(guildID, &participant.Summoner)).
+		SetTitle(title).
+		AddField(i18n.T(language, i18n.Champion), champions.ChampionName(participant.ChampionID)).
+		AddField(i18n.T(language, i18n.KDA), fmt.Sprintf("%d/%d/%d (%.2f)", stats.Kills, stats.Deaths, stats.Assists, stats.KDA())).
+		SetThumbnail(attachments.URL(assetprovider.ChampionSquare(participant.ChampionID))).
+		SetFooter(fmt.Sprintf("%s | %02d:%02d", finishedMatch.Queue.Name(), int(finishedMatch.Duration.Minutes()), int(finishedMatch.Duration.Seconds())%60)).
+		SetColor(color)
+	if clashTeam != "" {
+		e.AddField(i18n.T(language, i18n.ClashTeam), clashTeam)
+	}
+	e.InlineAllFields()
+	return e, attachments
+}
@@ -0,0 +1,341 @@
+package clash
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"discord-bot/internal/app/features/settings"
+	apiHelper "discord-bot/internal/app/helper/api"
+	"discord-bot/internal/app/helper/assetprovider"
+	assethelper "discord-bot/internal/app/helper/assets"
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/app/utility/i18n"
+	"discord-bot/internal/logger"
+	"discord-bot/types/clash"
+	"discord-bot/types/embed"
+	"discord-bot/types/subscription"
+	"discord-bot/types/summoner"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// checkInterval is the time between two Clash registration checks of a tracked summoner
+const checkInterval = 30 * time.Minute
+
+// tournamentCacheTTL is how long the tournaments of a platform are reused, they only change when Riot schedules a new one
+const tournamentCacheTTL = time.Hour
+
+// reminderWindow is how long before a tournament day starts the registered teams are reminded
+const reminderWindow = 24 * time.Hour
+
+// Color of Clash announcements
+const clashColor = 0xc89b3c
+
+var discordSession *discordgo.Session
+
+type cachedTournaments struct {
+	fetched     time.Time
+	tournaments []clash.Tournament
+}
+
+var (
+	tournamentCache   = make(map[string]cachedTournaments)
+	tournamentCacheMu sync.Mutex
+)
+
+// Initialize sets the Discord session used to announce Clash registrations and tournament days
+func Initialize(session *discordgo.Session) {
+	discordSession = session
+}
+
+// tournaments returns the Clash tournaments of a platform, fetched at most once per tournamentCacheTTL
+func tournaments(region string) ([]clash.Tournament, error) {
+	tournamentCacheMu.Lock()
+	defer tournamentCacheMu.Unlock()
+
+	if cached, ok := tournamentCache[region]; ok && time.Since(cached.fetched) < tournamentCacheTTL {
+		return cached.tournaments, nil
+	}
+	fetched, err := apiHelper.GetClashTournaments(region)
+	if err != nil {
+		return nil, err
+	}
+	tournamentCache[region] = cachedTournaments{fetched: time.Now(), tournaments: fetched}
+	return fetched, nil
+}
+
+// Refresh checks the Clash registration of a summoner if the last check is older than checkInterval.
+// A new team is announced once per channel, and the team is reminded once per tournament day when it starts within reminderWindow.
+// Nothing is fetched for a summoner while no tournament is upcoming on their platform.
+func Refresh(s *summoner.Summoner) error {
+	all, err := tournaments(s.Region)
+	if err != nil {
+		return fmt.Errorf("failed to fetch Clash tournaments: %v", err)
+	}
+	if len(clash.Upcoming(all, time.Now())) == 0 {
+		return nil
+	}
+
+	due, err := databaseHelper.IsClashCheckDue(s.PUUID, checkInterval)
+	if err != nil || !due {
+		return err
+	}
+	if err := databaseHelper.SetClashChecked(s.PUUID); err != nil {
+		return err
+	}
+
+	players, err := apiHelper.GetClashPlayers(s.PUUID, s.Region)
+	if err != nil {
+		return err
+	}
+	for _, player := range players {
+		if player.TeamID == "" {
+			continue
+		}
+		team, err := apiHelper.GetClashTeam(player.TeamID, s.Region)
+		if err != nil {
+			logger.Logger.Warn("Failed to fetch Clash team", zap.String("teamID", player.TeamID), zap.Error(err))
+			continue
+		}
+		tournament := clash.Find(all, team.TournamentID)
+		if tournament == nil || tournament.NextPhase(time.Now()) == nil {
+			continue
+		}
+		announce(s, player, team, tournament)
+	}
+	return nil
+}
+
+// announce posts the registration of a team and the tournament day reminder to every channel following the LoL games of the summoner.
+// Each announcement is remembered per channel, so a team with several tracked members is only announced once.
+func announce(s *summoner.Summoner, player clash.Player, team *clash.Team, tournament *clash.Tournament) {
+	if discordSession == nil {
+		return
+	}
+
+	channels, err := databaseHelper.GetChannelsForSummonerByGame(s.PUUID, subscription.GamesLoL)
+	if err != nil {
+		logger.Logger.Error("Failed to get channels for summoner", zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+	phase := tournament.NextPhase(now)
+	reminderDue := phase.StartTime.Sub(now) <= reminderWindow
+	for _, channelID := range channels {
+		channelSettings, err := databaseHelper.GetChannelSettings(channelID)
+		if err != nil {
+			logger.Logger.Error("Failed to get channel settings", zap.String("channel", channelID), zap.Error(err))
+			continue
+		}
+		if !channelSettings.AllowsClash() || channelSettings.IsQuiet(now) {
+			continue
+		}
+
+		// Both keys are marked, so a team registered less than a day before the tournament only gets the reminder
+		registered, err := databaseHelper.MarkClashAnnounced(channelID, "registered:"+team.ID)
+		if err != nil {
+			logger.Logger.Error("Failed to remember Clash announcement", zap.String("channel", channelID), zap.Error(err))
+			continue
+		}
+		reminded := false
+		if reminderDue {
+			reminded, err = databaseHelper.MarkClashAnnounced(channelID, fmt.Sprintf("day:%s:%d", team.ID, phase.ID))
+			if err != nil {
+				logger.Logger.Error("Failed to remember Clash announcement", zap.String("channel", channelID), zap.Error(err))
+				continue
+			}
+		}
+
+		language := channelSettings.Language
+		var title string
+		switch {
+		case reminded:
+			title = i18n.T(language, i18n.ClashDayTitle, tournament.Name())
+		case registered:
+			title = i18n.T(language, i18n.ClashRegistered, tournament.Name())
+		default:
+			continue
+		}
+
+		attachments := assetprovider.NewAttachments()
+		e := embed.NewEmbed().
+			SetAuthor(s.GetNameTag(), attachments.URL(assetprovider.ProfileIcon(s.ProfileIconID)), settings.ProfileURL(channelSettings.GuildID, s)).
+			SetTitle(title).
+			AddField(i18n.T(language, i18n.ClashTeam), TeamLabel(team)).
+			AddField(i18n.T(language, i18n.ClashStart), discordTimestamp(phase.StartTime)).
+			SetFooter(clash.TierName(team.Tier)).
+			SetColor(clashColor)
+		if position := clash.PositionName(player.Position); position != "" {
+			e.AddField(i18n.T(language, i18n.ClashPosition), position)
+		}
+		e.InlineAllFields()
+
+		_, err = discordSession.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+			Embeds: []*discordgo.MessageEmbed{e.MessageEmbed},
+			Files:  attachments.Files(),
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to send Clash announcement to Discord channel", zap.String("channel", channelID), zap.Error(err))
+		}
+	}
+}
+
+// TeamName returns the label of the Clash team a summoner is registered with, empty if they are not registered.
+// Clash match notifications use it to show which team is playing.
+func TeamName(puuid, region string) string {
+	players, err := apiHelper.GetClashPlayers(puuid, region)
+	if err != nil {
+		logger.Logger.Warn("Failed to fetch Clash players", zap.Error(err))
+		return ""
+	}
+	for _, player := range players {
+		if player.TeamID == "" {
+			continue
+		}
+		team, err := apiHelper.GetClashTeam(player.TeamID, region)
+		if err != nil {
+			logger.Logger.Warn("Failed to fetch Clash team", zap.String("teamID", player.TeamID), zap.Error(err))
+			return ""
+		}
+		return TeamLabel(team)
+	}
+	return ""
+}
+
+// TeamLabel formats a team like "Name [ABBR]"
+func TeamLabel(team *clash.Team) string {
+	if team.Abbreviation == "" {
+		return team.Name
+	}
+	return fmt.Sprintf("%s [%s]", team.Name, team.Abbreviation)
+}
+
+// Overview lists the upcoming Clash tournaments of a platform. If a Riot ID is given, the team it is registered with
+// is shown with the ranks and most played champions of its members.
+func Overview(name, tagLine, region, guildID string) (*discordgo.MessageEmbed, []*discordgo.File, error) {
+	logger.Logger.Info("Looking up Clash", zap.String("name", name), zap.String("tagLine", tagLine), zap.String("region", region))
+
+	all, err := tournaments(region)
+	if err != nil {
+		logger.Logger.Error("Failed to fetch Clash tournaments", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to fetch Clash tournaments: %v", err)
+	}
+
+	attachments := assetprovider.NewAttachments()
+	e := embed.NewEmbed().
+		SetTitle("Clash").
+		SetDescription(tournamentLines(clash.Upcoming(all, time.Now()))).
+		SetColor(clashColor)
+
+	if name == "" {
+		return e.MessageEmbed, attachments.Files(), nil
+	}
+
+	s, err := apiHelper.GetSummonerByTag(name, tagLine, region)
+	if err != nil {
+		logger.Logger.Error("Failed to fetch summoner data", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to fetch summoner data: %v", err)
+	}
+	e.SetAuthor(s.GetNameTag(), attachments.URL(assetprovider.ProfileIcon(s.ProfileIconID)), settings.ProfileURL(guildID, s))
+
+	players, err := apiHelper.GetClashPlayers(s.PUUID, region)
+	if err != nil {
+		logger.Logger.Error("Failed to fetch Clash players", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to fetch Clash registration: %v", err)
+	}
+
+	registered := false
+	for _, player := range players {
+		if player.TeamID == "" {
+			continue
+		}
+		team, err := apiHelper.GetClashTeam(player.TeamID, region)
+		if err != nil {
+			logger.Logger.Error("Failed to fetch Clash team", zap.String("teamID", player.TeamID), zap.Error(err))
+			return nil, nil, fmt.Errorf("failed to fetch Clash team: %v", err)
+		}
+		registered = true
+
+		title := TeamLabel(team)
+		if tournament := clash.Find(all, team.TournamentID); tournament != nil {
+			title = fmt.Sprintf("%s | %s", title, tournament.Name())
+		}
+		e.AddField(title, memberLines(team, region)).
+			SetFooter(clash.TierName(team.Tier))
+	}
+	if !registered {
+		e.AddField("Team", fmt.Sprintf("%s is not registered for Clash", s.GetNameTag()))
+	}
+	return e.MessageEmbed, attachments.Files(), nil
+}
+
+// tournamentLines formats the next day of every tournament with Discord timestamps, shown in the local time of the reader
+func tournamentLines(upcoming []clash.Tournament) string {
+	if len(upcoming) == 0 {
+		return "No upcoming Clash tournaments"
+	}
+	now := time.Now()
+	var lines []string
+	for _, tournament := range upcoming {
+		phase := tournament.NextPhase(now)
+		registration := "registration open"
+		if phase.RegistrationTime.After(now) {
+			registration = "registration opens " + discordRelativeTimestamp(phase.RegistrationTime)
+		}
+		lines = append(lines, fmt.Sprintf("**%s**: %s (%s)", tournament.Name(), discordTimestamp(phase.StartTime), registration))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// memberLines formats one line per team member with position, Riot ID, Solo/Duo rank and most played champions
+func memberLines(team *clash.Team, region string) string {
+	champions := assethelper.GetCatalog("")
+	var lines []string
+	for _, player := range team.Players {
+		member, err := apiHelper.GetSummonerByPUUID(player.PUUID, region)
+		if err != nil || member == nil {
+			logger.Logger.Warn("Failed to fetch Clash team member", zap.String("PUUID", player.PUUID), zap.Error(err))
+			continue
+		}
+
+		line := member.GetNameTag()
+		if player.PUUID == team.Captain {
+			line += " (C)"
+		}
+		if position := clash.PositionName(player.Position); position != "" {
+			line = position + ": " + line
+		}
+		line += " | " + member.SoloRank.ToString()
+
+		masteries, err := apiHelper.GetTopChampionMasteries(player.PUUID, region, 3)
+		if err != nil {
+			logger.Logger.Warn("Failed to fetch champion masteries", zap.String("PUUID", player.PUUID), zap.Error(err))
+		}
+		var championNames []string
+		for _, m := range masteries {
+			championNames = append(championNames, champions.ChampionName(m.ChampionID))
+		}
+		if len(championNames) > 0 {
+			line += " | " + strings.Join(championNames, ", ")
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return "No members yet"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// discordTimestamp formats t as a Discord timestamp with date and time
+func discordTimestamp(t time.Time) string {
+	return fmt.Sprintf("<t:%d:F>", t.Unix())
+}
+
+// discordRelativeTimestamp formats t as a Discord timestamp like "in 2 days"
+func discordRelativeTimestamp(t time.Time) string {
+	return fmt.Sprintf("<t:%d:R>", t.Unix())
+}
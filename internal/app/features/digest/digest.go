@@ -0,0 +1,359 @@
+package digest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	assethelper "discord-bot/internal/app/helper/assets"
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/app/utility/cron"
+	"discord-bot/internal/logger"
+	"discord-bot/types/catalog"
+	"discord-bot/types/digest"
+	"discord-bot/types/embed"
+	"discord-bot/types/history"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+var discordSession *discordgo.Session
+
+// Weekdays maps the day choices of the digest command to cron day-of-week values
+var Weekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// Initialize sets the Discord session used to post digests
+func Initialize(session *discordgo.Session) {
+	discordSession = session
+}
+
+// RunScheduler checks every minute for digests that are due and posts them
+func RunScheduler() {
+	for {
+		runDueDigests(time.Now())
+		time.Sleep(time.Minute)
+	}
+}
+
+func runDueDigests(now time.Time) {
+	schedules, err := databaseHelper.GetDigestSchedules()
+	if err != nil {
+		logger.Logger.Error("Failed to load digest schedules", zap.Error(err))
+		return
+	}
+
+	for _, schedule := range schedules {
+		loc, err := time.LoadLocation(schedule.Timezone)
+		if err != nil {
+			logger.Logger.Error("Invalid digest timezone", zap.String("timezone", schedule.Timezone), zap.Error(err))
+			continue
+		}
+		cronSchedule, err := cron.Parse(schedule.CronExpression)
+		if err != nil {
+			logger.Logger.Error("Invalid digest schedule", zap.String("cron", schedule.CronExpression), zap.Error(err))
+			continue
+		}
+
+		last := schedule.Created
+		if schedule.LastRun != nil {
+			last = *schedule.LastRun
+		}
+		next := cronSchedule.Next(last.In(loc))
+		if next.IsZero() || next.After(now) {
+			continue
+		}
+
+		logger.Logger.Info("Posting digest", zap.String("channelID", schedule.ChannelID), zap.String("period", schedule.Period))
+		message, err := BuildDigest(schedule.ChannelID, schedule.Period, schedule.Content, now.In(loc))
+		if err != nil {
+			logger.Logger.Error("Failed to build digest", zap.String("channelID", schedule.ChannelID), zap.Error(err))
+		} else {
+			_, err = discordSession.ChannelMessageSendEmbed(schedule.ChannelID, message)
+			if err != nil {
+				logger.Logger.Error("Failed to send digest to Discord channel", zap.String("channelID", schedule.ChannelID), zap.Error(err))
+			}
+		}
+
+		// Mark the run even on failure so a broken channel does not get retried every minute
+		err = databaseHelper.UpdateDigestLastRun(schedule.ChannelID, schedule.Period, now.UTC())
+		if err != nil {
+			logger.Logger.Error("Failed to update digest last run", zap.Error(err))
+		}
+	}
+}
+
+// CronFromDayTime builds a cron expression from a weekday (or "daily") and a "HH:MM" time
+func CronFromDayTime(day, clock string) (string, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(clock, "%d:%d", &hour, &minute); err != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return "", fmt.Errorf("invalid time %q, expected HH:MM", clock)
+	}
+
+	dow := "*"
+	if day != "daily" {
+		dow = ""
+		for i, weekday := range Weekdays {
+			if weekday == day {
+				dow = fmt.Sprintf("%d", i)
+			}
+		}
+		if dow == "" {
+			return "", fmt.Errorf("invalid day %q", day)
+		}
+	}
+
+	return fmt.Sprintf("%d %d * * %s", minute, hour, dow), nil
+}
+
+// SetSchedule validates and stores a digest schedule for a channel
+func SetSchedule(channelID, guildID, period, cronExpression, timezone, content string) (*discordgo.MessageEmbed, error) {
+	if period != digest.PeriodWeekly && period != digest.PeriodSeason {
+		return nil, fmt.Errorf("invalid period %q", period)
+	}
+	cronSchedule, err := cron.Parse(cronExpression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule: %v", err)
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", timezone)
+	}
+	sections := digest.ParseContent(content)
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("no valid content sections in %q, choose from: %s", content, strings.Join(digest.AllSections, ", "))
+	}
+
+	schedule := digest.Schedule{
+		ChannelID:      channelID,
+		GuildID:        guildID,
+		Period:         period,
+		CronExpression: cronExpression,
+		Timezone:       timezone,
+		Content:        sections,
+	}
+	err = databaseHelper.SaveDigestSchedule(schedule)
+	if err != nil {
+		logger.Logger.Error("Failed to save digest schedule", zap.Error(err))
+		return nil, fmt.Errorf("failed to save digest schedule: %v", err)
+	}
+
+	next := cronSchedule.Next(time.Now().In(loc))
+	embedMessage := embed.NewEmbed().
+		SetTitle("Digest scheduled").
+		SetDescription(fmt.Sprintf("A %s digest will be posted to this channel.", period)).
+		AddField("Schedule", fmt.Sprintf("`%s` (%s)", cronExpression, timezone)).
+		AddField("Next digest", fmt.Sprintf("<t:%d:F>", next.Unix())).
+		AddField("Content", schedule.ContentString()).
+		InlineAllFields().MessageEmbed
+
+	return embedMessage, nil
+}
+
+// RemoveSchedule deletes the digest schedule of a channel for a period
+func RemoveSchedule(channelID, period string) error {
+	err := databaseHelper.DeleteDigestSchedule(channelID, period)
+	if err != nil {
+		logger.Logger.Error("Failed to delete digest schedule", zap.String("channelID", channelID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ShowSchedules returns an embed listing the digest schedules of a channel
+func ShowSchedules(channelID string) (*discordgo.MessageEmbed, error) {
+	schedules, err := databaseHelper.GetDigestSchedules(channelID)
+	if err != nil {
+		logger.Logger.Error("Failed to load digest schedules", zap.Error(err))
+		return nil, fmt.Errorf("failed to load digest schedules: %v", err)
+	}
+
+	e := embed.NewEmbed().SetTitle("Digest schedules")
+	if len(schedules) == 0 {
+		e.SetDescription("No digests are scheduled for this channel.")
+	}
+	for _, schedule := range schedules {
+		e.AddField(schedule.Period, fmt.Sprintf("`%s` (%s)\n%s", schedule.CronExpression, schedule.Timezone, schedule.ContentString()))
+	}
+	return e.MessageEmbed, nil
+}
+
+// PeriodStart returns the beginning of the period a digest posted at now summarizes
+func PeriodStart(period string, now time.Time) time.Time {
+	if period == digest.PeriodSeason {
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+	}
+	return now.AddDate(0, 0, -7)
+}
+
+type playerSummary struct {
+	nameTag         string
+	games           int
+	wins            int
+	netLP           int
+	worstLossStreak int
+	currentStreak   int
+	promotions      []string
+	championsPlayed map[int]int
+}
+
+// BuildDigest summarizes the rank history of a channel's summoners for the period ending at now
+func BuildDigest(channelID, period string, sections []string, now time.Time) (*discordgo.MessageEmbed, error) {
+	from := PeriodStart(period, now)
+	changes, err := databaseHelper.GetRankChangesForChannel(channelID, from.UTC(), now.UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := digest.Schedule{Content: sections}
+	title := "Weekly Digest"
+	if period == digest.PeriodSeason {
+		title = fmt.Sprintf("Season %d Digest", now.Year())
+	}
+	e := embed.NewEmbed().
+		SetTitle(title).
+		SetFooter(fmt.Sprintf("%s - %s", from.Format("02 Jan 2006"), now.Format("02 Jan 2006"))).
+		SetColor(0x5865f2)
+
+	if len(changes) == 0 {
+		e.SetDescription("No ranked games were played in this period.")
+		return e.MessageEmbed, nil
+	}
+
+	players := summarize(changes)
+
+	if schedule.HasSection(digest.SectionGames) {
+		var lines []string
+		for _, p := range players {
+			lines = append(lines, fmt.Sprintf("**%s**: %d games (%dW %dL)", p.nameTag, p.games, p.wins, p.games-p.wins))
+		}
+		e.AddField("Games played", strings.Join(lines, "\n"))
+	}
+
+	if schedule.HasSection(digest.SectionLP) {
+		var lines []string
+		for _, p := range players {
+			lines = append(lines, fmt.Sprintf("**%s**: %+d LP", p.nameTag, p.netLP))
+		}
+		e.AddField("Net LP", strings.Join(lines, "\n"))
+	}
+
+	if schedule.HasSection(digest.SectionClimber) {
+		best := players[0]
+		for _, p := range players[1:] {
+			if p.netLP > best.netLP {
+				best = p
+			}
+		}
+		e.AddField("Biggest climber", fmt.Sprintf("**%s** with %+d LP", best.nameTag, best.netLP))
+	}
+
+	if schedule.HasSection(digest.SectionStreak) {
+		worst := players[0]
+		for _, p := range players[1:] {
+			if p.worstLossStreak > worst.worstLossStreak {
+				worst = p
+			}
+		}
+		if worst.worstLossStreak > 0 {
+			e.AddField("Worst loss streak", fmt.Sprintf("**%s** lost %d in a row", worst.nameTag, worst.worstLossStreak))
+		}
+	}
+
+	if schedule.HasSection(digest.SectionChampions) {
+		counts := make(map[int]int)
+		for _, p := range players {
+			for championID, count := range p.championsPlayed {
+				counts[championID] += count
+			}
+		}
+		e.AddField("Most played champions", formatChampionCounts(assethelper.GetCatalog(""), counts, 5))
+	}
+
+	if schedule.HasSection(digest.SectionPromotions) {
+		var lines []string
+		for _, p := range players {
+			for _, promotion := range p.promotions {
+				lines = append(lines, fmt.Sprintf("**%s** reached %s", p.nameTag, promotion))
+			}
+		}
+		if len(lines) == 0 {
+			lines = append(lines, "No promotions this time.")
+		}
+		e.AddField("Promotions", strings.Join(lines, "\n"))
+	}
+
+	return e.Truncate().MessageEmbed, nil
+}
+
+// summarize groups rank changes by summoner, sorted by games played
+func summarize(changes []history.RankChange) []*playerSummary {
+	byPUUID := make(map[string]*playerSummary)
+	var players []*playerSummary
+
+	for _, change := range changes {
+		p, ok := byPUUID[change.SummonerPUUID]
+		if !ok {
+			p = &playerSummary{nameTag: change.NameTag, championsPlayed: make(map[int]int)}
+			byPUUID[change.SummonerPUUID] = p
+			players = append(players, p)
+		}
+
+		p.games++
+		p.netLP += change.LPChange()
+		if change.ChampionID != 0 {
+			p.championsPlayed[change.ChampionID]++
+		}
+		if change.IsWin() {
+			p.wins++
+			p.currentStreak = 0
+		} else {
+			p.currentStreak++
+			if p.currentStreak > p.worstLossStreak {
+				p.worstLossStreak = p.currentStreak
+			}
+		}
+		if change.IsPromotion() {
+			p.promotions = append(p.promotions, fmt.Sprintf("%s (%s)", change.NewRank.ToString(), change.QueueType))
+		}
+	}
+
+	sort.SliceStable(players, func(i, j int) bool {
+		return players[i].games > players[j].games
+	})
+	return players
+}
+
+func formatChampionCounts(champions *catalog.Catalog, counts map[int]int, limit int) string {
+	type championCount struct {
+		championID int
+		count      int
+	}
+	var sorted []championCount
+	for championID, count := range counts {
+		sorted = append(sorted, championCount{championID, count})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].count == sorted[j].count {
+			return sorted[i].championID < sorted[j].championID
+		}
+		return sorted[i].count > sorted[j].count
+	})
+
+	var lines []string
+	for i, c := range sorted {
+		if i >= limit {
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s: %d games", champions.ChampionName(c.championID), c.count))
+	}
+	if len(lines) == 0 {
+		return "-"
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,163 @@
+package identity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"discord-bot/internal/app/features/settings"
+	apiHelper "discord-bot/internal/app/helper/api"
+	"discord-bot/internal/app/helper/assetprovider"
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/app/utility/i18n"
+	"discord-bot/internal/logger"
+	"discord-bot/types/embed"
+	"discord-bot/types/history"
+	"discord-bot/types/summoner"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// refreshInterval is the time between two account-v1 checks of the Riot ID and platform of a summoner
+const refreshInterval = 24 * time.Hour
+
+var discordSession *discordgo.Session
+
+// Initialize sets the Discord session used to announce renames and region transfers
+func Initialize(session *discordgo.Session) {
+	discordSession = session
+}
+
+// Refresh re-resolves the Riot ID and platform of a summoner by its PUUID if they were not checked recently or force is set.
+// A summoner that is not found on its platform anymore is looked up on its active shard.
+// It returns the up to date summoner, which is the given one if nothing changed.
+func Refresh(current *summoner.Summoner, force bool) (*summoner.Summoner, error) {
+	if !force {
+		due, err := databaseHelper.IsIdentityCheckDue(current.PUUID, refreshInterval)
+		if err != nil {
+			return current, err
+		}
+		if !due {
+			return current, nil
+		}
+	}
+
+	logger.Logger.Info("Refreshing summoner identity", zap.String("nameTag", current.GetNameTag()), zap.String("region", current.Region))
+
+	name, tagLine, err := apiHelper.GetNameTagByPUUID(current.PUUID)
+	if err != nil {
+		return current, fmt.Errorf("failed to fetch Riot ID: %v", err)
+	}
+
+	fresh, err := apiHelper.GetSummonerByPUUID(current.PUUID, current.Region, &name, &tagLine)
+	if errors.Is(err, apiHelper.ErrNotFound) {
+		// The summoner does not exist on its platform anymore, so the account was transferred
+		platform, shardErr := apiHelper.GetActiveShard(current.PUUID)
+		if shardErr != nil {
+			return current, fmt.Errorf("failed to resolve active shard: %v", shardErr)
+		}
+		logger.Logger.Info("Summoner not found on its platform, using active shard", zap.String("nameTag", current.GetNameTag()), zap.String("from", current.Region), zap.String("to", platform))
+		fresh, err = apiHelper.GetSummonerByPUUID(current.PUUID, platform, &name, &tagLine)
+	}
+	if err != nil {
+		return current, fmt.Errorf("failed to fetch summoner: %v", err)
+	}
+
+	// Ranks and timestamps stay untouched, the rank check compares them with the API
+	updated := *current
+	updated.Name = fresh.Name
+	updated.TagLine = fresh.TagLine
+	updated.Region = fresh.Region
+	updated.ID = fresh.ID
+	updated.AccountID = fresh.AccountID
+	updated.ProfileIconID = fresh.ProfileIconID
+
+	if err := Apply(current, &updated); err != nil {
+		return current, err
+	}
+	if err := databaseHelper.UpdateIdentityChecked(current.PUUID); err != nil {
+		logger.Logger.Warn("Failed to update identity check time", zap.Error(err))
+	}
+	return &updated, nil
+}
+
+// Apply stores a newer version of a summoner and records and announces a changed Riot ID or platform
+func Apply(previous, updated *summoner.Summoner) error {
+	renamed := previous.Name != updated.Name || previous.TagLine != updated.TagLine
+	transferred := previous.Region != updated.Region
+	if !renamed && !transferred && previous.ID == updated.ID && previous.ProfileIconID == updated.ProfileIconID {
+		return nil
+	}
+
+	if renamed || transferred {
+		err := databaseHelper.SaveNameChange(history.NameChange{
+			SummonerPUUID: previous.PUUID,
+			Name:          previous.Name,
+			TagLine:       previous.TagLine,
+			Region:        previous.Region,
+			Changed:       time.Now(),
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to save name change", zap.Error(err))
+		}
+	}
+
+	err := databaseHelper.SaveSummonerToDB(*updated)
+	if err != nil {
+		logger.Logger.Error("Failed to save refreshed summoner", zap.Error(err))
+		return err
+	}
+
+	if renamed || transferred {
+		logger.Logger.Info("Summoner identity changed", zap.String("from", previous.GetNameTag()), zap.String("to", updated.GetNameTag()), zap.String("fromRegion", previous.Region), zap.String("toRegion", updated.Region))
+		announce(previous, updated, renamed, transferred)
+	}
+	return nil
+}
+
+// announce posts a rename or region transfer to every channel tracking the summoner
+func announce(previous, updated *summoner.Summoner, renamed, transferred bool) {
+	if discordSession == nil {
+		return
+	}
+
+	channels, err := databaseHelper.GetChannelsForSummoner(updated.PUUID)
+	if err != nil {
+		logger.Logger.Error("Failed to get channels for summoner", zap.Error(err))
+		return
+	}
+
+	for _, channelID := range channels {
+		channelSettings, err := databaseHelper.GetChannelSettings(channelID)
+		if err != nil {
+			logger.Logger.Error("Failed to get channel settings", zap.String("channel", channelID), zap.Error(err))
+			continue
+		}
+
+		language := channelSettings.Language
+		attachments := assetprovider.NewAttachments()
+		e := embed.NewEmbed().
+			SetThumbnail(attachments.URL(assetprovider.ProfileIcon(updated.ProfileIconID))).
+			SetURL(settings.ProfileURL(channelSettings.GuildID, updated))
+		if renamed {
+			e.SetTitle(i18n.T(language, i18n.NameChanged, previous.GetNameTag(), updated.GetNameTag()))
+		}
+		if transferred {
+			text := i18n.T(language, i18n.RegionTransferred, updated.GetNameTag(), previous.Region, updated.Region)
+			if renamed {
+				e.SetDescription(text)
+			} else {
+				e.SetTitle(text)
+			}
+		}
+
+		_, err = discordSession.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+			Embeds: []*discordgo.MessageEmbed{e.MessageEmbed},
+			Files:  attachments.Files(),
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to send identity change to Discord channel", zap.String("channel", channelID), zap.Error(err))
+		}
+	}
+}
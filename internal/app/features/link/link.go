@@ -0,0 +1,108 @@
+package link
+
+import (
+	"fmt"
+	"strings"
+
+	"discord-bot/internal/app/features/onboarding"
+	"discord-bot/internal/app/helper/assetprovider"
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/logger"
+	"discord-bot/types/account"
+	"discord-bot/types/embed"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// LinkAccount links a Riot account to the Discord user who invoked the command
+func LinkAccount(userID, name, tagLine, region string) (*discordgo.MessageEmbed, error) {
+	logger.Logger.Info("Linking account", zap.String("userID", userID), zap.String("name", name), zap.String("tagLine", tagLine), zap.String("region", region))
+
+	summoner, err := onboarding.FindOrFetchSummoner(name, tagLine, region)
+	if err != nil {
+		return nil, err
+	}
+
+	err = databaseHelper.LinkAccount(userID, summoner.PUUID)
+	if err != nil {
+		logger.Logger.Warn("Failed to link account", zap.String("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("failed to link %s: %v", summoner.GetNameTag(), err)
+	}
+
+	embedMessage := embed.NewEmbed().
+		SetTitle("Account Linked").
+		SetDescription(fmt.Sprintf("%s is now linked to %s", summoner.GetNameTag(), account.Mention(userID))).
+		SetThumbnail(assetprovider.URL(assetprovider.ProfileIcon(summoner.ProfileIconID))).
+		MessageEmbed
+
+	return embedMessage, nil
+}
+
+// UnlinkAccount removes a linked Riot account from the Discord user who invoked the command
+func UnlinkAccount(userID, name, tagLine string) error {
+	summoner, err := databaseHelper.GetDBSummonerByName(name, tagLine)
+	if err != nil {
+		return fmt.Errorf("failed to fetch summoner data: %v", err)
+	}
+
+	err = databaseHelper.UnlinkAccount(userID, summoner.PUUID)
+	if err != nil {
+		logger.Logger.Warn("Failed to unlink account", zap.String("userID", userID), zap.Error(err))
+		return err
+	}
+
+	logger.Logger.Info("Unlinked account", zap.String("userID", userID), zap.String("summoner", summoner.GetNameTag()))
+	return nil
+}
+
+// SetMentions stores whether a Discord user wants to be mentioned in the notifications of their accounts
+func SetMentions(userID string, enabled bool) error {
+	err := databaseHelper.SetMentionOptIn(userID, enabled)
+	if err != nil {
+		logger.Logger.Error("Failed to set mention opt-in", zap.String("userID", userID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Me returns an embed with all Riot accounts linked to a Discord user
+func Me(userID string) (*discordgo.MessageEmbed, error) {
+	accounts, err := databaseHelper.GetLinkedAccounts(userID)
+	if err != nil {
+		logger.Logger.Error("Failed to get linked accounts", zap.String("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("failed to get linked accounts: %v", err)
+	}
+	user, err := databaseHelper.GetDiscordUser(userID)
+	if err != nil {
+		logger.Logger.Error("Failed to get discord user", zap.String("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("failed to get discord user: %v", err)
+	}
+
+	mentions := "off"
+	if user != nil && user.MentionOptIn {
+		mentions = "on"
+	}
+
+	e := embed.NewEmbed().
+		SetTitle("Your Linked Accounts").
+		SetFooter(fmt.Sprintf("Mentions in notifications: %s", mentions))
+
+	if len(accounts) == 0 {
+		e.SetDescription("You have not linked any accounts yet. Use /link or /verify to link one.")
+		return e.MessageEmbed, nil
+	}
+
+	var lines []string
+	for _, a := range accounts {
+		verified := "unverified"
+		if a.Verified {
+			verified = "verified"
+		}
+		lines = append(lines, fmt.Sprintf("**%s** (%s, %s) - Solo: %s, Flex: %s", a.Summoner.GetNameTag(), a.Summoner.Region, verified, a.Summoner.SoloRank.ToString(), a.Summoner.FlexRank.ToString()))
+	}
+	e.SetDescription(strings.Join(lines, "\n")).
+		SetThumbnail(assetprovider.URL(assetprovider.ProfileIcon(accounts[0].Summoner.ProfileIconID)))
+
+	return e.Truncate().MessageEmbed, nil
+}
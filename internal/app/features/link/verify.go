@@ -0,0 +1,111 @@
+package link
+
+import (
+	"fmt"
+	"time"
+
+	"discord-bot/internal/app/features/onboarding"
+	apiHelper "discord-bot/internal/app/helper/api"
+	"discord-bot/internal/app/helper/assetprovider"
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/logger"
+	"discord-bot/types/account"
+	"discord-bot/types/embed"
+	"discord-bot/types/summoner"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// Timing of the profile icon challenge, the timeout stays below the 15 minute lifetime of an interaction token
+const (
+	VerificationTimeout      = 10 * time.Minute
+	verificationPollInterval = 20 * time.Second
+)
+
+// Challenge is a pending ownership verification of a Riot account
+type Challenge struct {
+	UserID   string
+	Summoner *summoner.Summoner
+	IconID   int
+	Expires  time.Time
+}
+
+// StartVerification links the account to the user if needed and picks the starter icon the user has to set
+func StartVerification(userID, name, tagLine, region string) (*Challenge, *discordgo.MessageEmbed, error) {
+	logger.Logger.Info("Starting account verification", zap.String("userID", userID), zap.String("name", name), zap.String("tagLine", tagLine), zap.String("region", region))
+
+	summoner, err := onboarding.FindOrFetchSummoner(name, tagLine, region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	linked, err := databaseHelper.IsAccountLinked(userID, summoner.PUUID)
+	if err != nil {
+		logger.Logger.Error("Failed to check account link", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to check account link: %v", err)
+	}
+	if !linked {
+		err = databaseHelper.LinkAccount(userID, summoner.PUUID)
+		if err != nil {
+			logger.Logger.Error("Failed to link account", zap.Error(err))
+			return nil, nil, fmt.Errorf("failed to link %s: %v", summoner.GetNameTag(), err)
+		}
+	}
+
+	currentIcon, err := apiHelper.GetSummonerProfileIconIDByPUUID(summoner.PUUID, summoner.Region)
+	if err != nil {
+		logger.Logger.Error("Failed to fetch profile icon", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to fetch profile icon: %v", err)
+	}
+
+	challenge := &Challenge{
+		UserID:   userID,
+		Summoner: summoner,
+		IconID:   account.RandomStarterIcon(currentIcon),
+		Expires:  time.Now().Add(VerificationTimeout),
+	}
+
+	embedMessage := embed.NewEmbed().
+		SetTitle("Verify Account Ownership").
+		SetDescription(fmt.Sprintf("To prove that you own %s, change its profile icon in the League client to the icon shown here. The check runs automatically until <t:%d:t>.", summoner.GetNameTag(), challenge.Expires.Unix())).
+		AddField("Icon ID", fmt.Sprintf("%d", challenge.IconID)).
+		SetThumbnail(assetprovider.URL(assetprovider.ProfileIcon(challenge.IconID))).
+		MessageEmbed
+
+	return challenge, embedMessage, nil
+}
+
+// AwaitVerification polls the profile icon until it matches the challenge or the challenge expires and marks the link verified
+func AwaitVerification(challenge *Challenge) (*discordgo.MessageEmbed, error) {
+	for {
+		iconID, err := apiHelper.GetSummonerProfileIconIDByPUUID(challenge.Summoner.PUUID, challenge.Summoner.Region)
+		if err != nil {
+			logger.Logger.Warn("Failed to fetch profile icon during verification", zap.Error(err))
+		} else if iconID == challenge.IconID {
+			break
+		}
+
+		if time.Now().Add(verificationPollInterval).After(challenge.Expires) {
+			logger.Logger.Info("Account verification timed out", zap.String("userID", challenge.UserID), zap.String("summoner", challenge.Summoner.GetNameTag()))
+			return nil, fmt.Errorf("the profile icon of %s was not changed in time, run /verify again to get a new challenge", challenge.Summoner.GetNameTag())
+		}
+		time.Sleep(verificationPollInterval)
+	}
+
+	err := databaseHelper.SetAccountVerified(challenge.UserID, challenge.Summoner.PUUID)
+	if err != nil {
+		logger.Logger.Error("Failed to mark account as verified", zap.Error(err))
+		return nil, fmt.Errorf("failed to mark account as verified: %v", err)
+	}
+
+	logger.Logger.Info("Account verified", zap.String("userID", challenge.UserID), zap.String("summoner", challenge.Summoner.GetNameTag()))
+	embedMessage := embed.NewEmbed().
+		SetTitle("Account Verified").
+		SetDescription(fmt.Sprintf("%s is now verified for %s. You can change your profile icon back.", challenge.Summoner.GetNameTag(), account.Mention(challenge.UserID))).
+		SetThumbnail(assetprovider.URL(assetprovider.ProfileIcon(challenge.IconID))).
+		SetColor(0x2ecc71).
+		MessageEmbed
+
+	return embedMessage, nil
+}
@@ -0,0 +1,171 @@
+package listing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/logger"
+	"discord-bot/types/embed"
+	"discord-bot/types/summoner"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// Scopes of the list command
+const (
+	ScopeChannel = "channel"
+	ScopeGuild   = "guild"
+)
+
+// ComponentPrefix prefixes the custom IDs of the pagination buttons
+const ComponentPrefix = "list"
+
+// pageSize is the number of summoners shown per page
+const pageSize = 10
+
+// maxSuggestions is the maximum number of autocomplete choices Discord accepts
+const maxSuggestions = 25
+
+// ListPage builds one page of the summoners tracked in a channel or guild together with its pagination buttons
+func ListPage(scope, channelID, guildID string, page int) (*discordgo.MessageEmbed, []discordgo.MessageComponent, error) {
+	var tracked []summoner.TrackedSummoner
+	var err error
+	if scope == ScopeGuild {
+		tracked, err = databaseHelper.GetTrackedSummonersForGuild(guildID)
+	} else {
+		scope = ScopeChannel
+		tracked, err = databaseHelper.GetTrackedSummonersForChannel(channelID)
+	}
+	if err != nil {
+		logger.Logger.Error("Failed to list tracked summoners", zap.String("scope", scope), zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to list tracked summoners: %v", err)
+	}
+
+	pages := (len(tracked) + pageSize - 1) / pageSize
+	if pages == 0 {
+		pages = 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	if page >= pages {
+		page = pages - 1
+	}
+
+	e := embed.NewEmbed().
+		SetTitle(fmt.Sprintf("Tracked Summoners (%s)", scope)).
+		SetFooter(fmt.Sprintf("Page %d/%d | %d summoners", page+1, pages, len(tracked)))
+
+	if len(tracked) == 0 {
+		e.SetDescription("No summoners are tracked here yet. Use /add to track one.")
+		return e.MessageEmbed, nil, nil
+	}
+
+	var lines []string
+	end := (page + 1) * pageSize
+	if end > len(tracked) {
+		end = len(tracked)
+	}
+	for _, t := range tracked[page*pageSize : end] {
+		lines = append(lines, formatTrackedSummoner(t, scope))
+	}
+	e.SetDescription(strings.Join(lines, "\n"))
+
+	if pages == 1 {
+		return e.MessageEmbed, nil, nil
+	}
+
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Previous",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("%s:%s:%d", ComponentPrefix, scope, page-1),
+					Disabled: page == 0,
+				},
+				discordgo.Button{
+					Label:    "Next",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("%s:%s:%d", ComponentPrefix, scope, page+1),
+					Disabled: page >= pages-1,
+				},
+			},
+		},
+	}
+
+	return e.MessageEmbed, components, nil
+}
+
+// ParseComponentID extracts scope and page from the custom ID of a pagination button
+func ParseComponentID(customID string) (string, int, error) {
+	parts := strings.Split(customID, ":")
+	if len(parts) != 3 || parts[0] != ComponentPrefix {
+		return "", 0, fmt.Errorf("invalid list component ID %q", customID)
+	}
+	page, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid page in list component ID %q", customID)
+	}
+	return parts[1], page, nil
+}
+
+func formatTrackedSummoner(t summoner.TrackedSummoner, scope string) string {
+	line := fmt.Sprintf("**%s** (%s) - Solo: %s", t.Summoner.GetNameTag(), t.Summoner.Region, t.Summoner.SoloRank.ToString())
+	if t.LastGame != nil {
+		line += fmt.Sprintf(" - last game <t:%d:R>", t.LastGame.Unix())
+	} else {
+		line += " - no game recorded yet"
+	}
+	if scope == ScopeGuild {
+		line += fmt.Sprintf(" in <#%s>", t.ChannelID)
+	}
+	return line
+}
+
+// SuggestNames returns autocomplete choices for summoner names tracked in a channel
+func SuggestNames(channelID, namePrefix string) []*discordgo.ApplicationCommandOptionChoice {
+	summoners, err := databaseHelper.SearchSummonersForChannel(channelID, namePrefix, "", maxSuggestions)
+	if err != nil {
+		logger.Logger.Error("Failed to search summoners for autocomplete", zap.Error(err))
+		return nil
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	seen := make(map[string]bool)
+	for _, s := range summoners {
+		if seen[strings.ToLower(s.Name)] {
+			continue
+		}
+		seen[strings.ToLower(s.Name)] = true
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  s.GetNameTag(),
+			Value: s.Name,
+		})
+	}
+	return choices
+}
+
+// SuggestTags returns autocomplete choices for the tags of summoners tracked in a channel with the given name
+func SuggestTags(channelID, name, tagPrefix string) []*discordgo.ApplicationCommandOptionChoice {
+	summoners, err := databaseHelper.SearchSummonersForChannel(channelID, name, tagPrefix, maxSuggestions)
+	if err != nil {
+		logger.Logger.Error("Failed to search summoners for autocomplete", zap.Error(err))
+		return nil
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, s := range summoners {
+		if name != "" && !strings.EqualFold(s.Name, name) {
+			continue
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  s.GetNameTag(),
+			Value: s.TagLine,
+		})
+	}
+	return choices
+}
@@ -0,0 +1,91 @@
+package live
+
+import (
+	"fmt"
+	"strings"
+
+	"discord-bot/internal/app/features/settings"
+	apiHelper "discord-bot/internal/app/helper/api"
+	"discord-bot/internal/app/helper/assetprovider"
+	assethelper "discord-bot/internal/app/helper/assets"
+	"discord-bot/internal/app/utility/gametoimage"
+	"discord-bot/internal/logger"
+	"discord-bot/types/catalog"
+	"discord-bot/types/embed"
+	"discord-bot/types/match"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// ImageName is the attachment name of the rendered live game image
+const ImageName = "livegame.png"
+
+var teamNames = [2]string{"Blue Team", "Red Team"}
+
+// LiveGame looks up the running game of any Riot ID and renders it as a loading-screen style image.
+// The image and icons that have to be attached are returned as files.
+func LiveGame(name, tagLine, region, guildID string) (*discordgo.MessageEmbed, []*discordgo.File, error) {
+	logger.Logger.Info("Looking up live game", zap.String("name", name), zap.String("tagLine", tagLine), zap.String("region", region))
+
+	summoner, err := apiHelper.GetSummonerByTag(name, tagLine, region)
+	if err != nil {
+		logger.Logger.Error("Failed to fetch summoner data", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to fetch summoner data: %v", err)
+	}
+
+	liveMatch, err := apiHelper.GetActiveGameByPUUID(summoner.PUUID, region)
+	if err != nil {
+		logger.Logger.Error("Failed to fetch live game", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to fetch live game: %v", err)
+	}
+	if liveMatch == nil {
+		return nil, nil, fmt.Errorf("%s is not in a game right now", summoner.GetNameTag())
+	}
+
+	image, err := gametoimage.LiveGameToImage(liveMatch, map[string]bool{summoner.PUUID: true})
+	if err != nil {
+		logger.Logger.Error("Failed to render live game image", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to render live game: %v", err)
+	}
+
+	attachments := assetprovider.NewAttachments()
+	e := embed.NewEmbed().
+		SetAuthor(summoner.GetNameTag(), attachments.URL(assetprovider.ProfileIcon(summoner.ProfileIconID)), settings.ProfileURL(guildID, summoner)).
+		SetTitle(fmt.Sprintf("Live Game | %v", liveMatch.Queue.Name())).
+		SetDescription(describe(liveMatch)).
+		SetImage("attachment://" + ImageName).
+		SetColor(0x0ac8b9)
+
+	champions := assethelper.GetCatalog("")
+	for teamIndex, team := range liveMatch.Teams {
+		var lines []string
+		for _, participant := range team.Participants {
+			lines = append(lines, formatParticipant(champions, participant))
+		}
+		e.AddField(fmt.Sprintf("%s (Ø %s)", teamNames[teamIndex], team.AverageRank().ToString()), strings.Join(lines, "\n"))
+	}
+
+	files := append([]*discordgo.File{{Name: ImageName, Reader: image}}, attachments.Files()...)
+	return e.Truncate().MessageEmbed, files, nil
+}
+
+func describe(liveMatch *match.Match) string {
+	description := fmt.Sprintf("Mode: %s", liveMatch.GameMode)
+	if elapsed := liveMatch.Elapsed(); elapsed > 0 {
+		description += fmt.Sprintf("\nRunning for %02d:%02d", int(elapsed.Minutes()), int(elapsed.Seconds())%60)
+	}
+	return description
+}
+
+func formatParticipant(champions *catalog.Catalog, participant match.Participant) string {
+	rankText := "UNRANKED"
+	if participant.SoloEntry != nil {
+		rankText = fmt.Sprintf("%s, %.0f%% WR", participant.SoloEntry.Rank().ToString(), participant.SoloEntry.WinRate())
+	}
+	champion := champions.ChampionName(participant.ChampionID)
+	if len(participant.Perks.PerkIDs) > 0 {
+		champion += ", " + champions.RuneName(participant.Perks.PerkIDs[0])
+	}
+	return fmt.Sprintf("**%s** (%s) - %s", participant.Summoner.GetNameTag(), champion, rankText)
+}
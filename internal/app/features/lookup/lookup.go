@@ -0,0 +1,109 @@
+package lookup
+
+import (
+	"fmt"
+
+	masteryFeature "discord-bot/internal/app/features/mastery"
+	"discord-bot/internal/app/features/settings"
+	apiHelper "discord-bot/internal/app/helper/api"
+	"discord-bot/internal/app/helper/assetprovider"
+	assethelper "discord-bot/internal/app/helper/assets"
+	"discord-bot/internal/logger"
+	"discord-bot/types/embed"
+	"discord-bot/types/league"
+	"discord-bot/types/mastery"
+	"discord-bot/types/summoner"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// topMasteryCount is the number of champions shown on a profile
+const topMasteryCount = 3
+
+// Rank looks up the current ranks of any Riot ID without tracking it.
+// The profile link points to the site chosen by the guild, icons that have to be attached are returned as files.
+func Rank(name, tagLine, region, guildID string) (*discordgo.MessageEmbed, []*discordgo.File, error) {
+	logger.Logger.Info("Looking up rank", zap.String("name", name), zap.String("tagLine", tagLine), zap.String("region", region))
+
+	summoner, entries, err := fetchSummoner(name, tagLine, region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachments := assetprovider.NewAttachments()
+	e := newSummonerEmbed(summoner, guildID, attachments).
+		SetTitle("Ranked Overview")
+	addQueueField(e, "Solo/Duo-Rank", entries, league.QueueSolo)
+	addQueueField(e, "Flex-Rank", entries, league.QueueFlex)
+
+	return e.InlineAllFields().MessageEmbed, attachments.Files(), nil
+}
+
+// Profile looks up the ranks and top champion masteries of any Riot ID without tracking it
+func Profile(name, tagLine, region, guildID string) (*discordgo.MessageEmbed, []*discordgo.File, error) {
+	logger.Logger.Info("Looking up profile", zap.String("name", name), zap.String("tagLine", tagLine), zap.String("region", region))
+
+	summoner, entries, err := fetchSummoner(name, tagLine, region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	masteries, err := apiHelper.GetChampionMasteries(summoner.PUUID, region)
+	if err != nil {
+		logger.Logger.Error("Failed to fetch champion masteries", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to fetch champion masteries: %v", err)
+	}
+
+	attachments := assetprovider.NewAttachments()
+	e := newSummonerEmbed(summoner, guildID, attachments).
+		SetTitle("Profile").
+		SetDescription(fmt.Sprintf("Level %d", summoner.Level))
+	addQueueField(e, "Solo/Duo-Rank", entries, league.QueueSolo)
+	addQueueField(e, "Flex-Rank", entries, league.QueueFlex)
+	e.InlineAllFields()
+
+	top := mastery.Top(masteries, topMasteryCount)
+	if len(top) == 0 {
+		e.AddField("Top Champions", "No champion mastery yet")
+	} else {
+		e.AddField("Top Champions", masteryFeature.Lines(assethelper.GetCatalog(""), top)).
+			AddField("Mastery", fmt.Sprintf("Total level %d on %d champions", mastery.TotalLevel(masteries), len(masteries))).
+			SetThumbnail(attachments.URL(assetprovider.ChampionSquare(top[0].ChampionID)))
+	}
+
+	return e.MessageEmbed, attachments.Files(), nil
+}
+
+func fetchSummoner(name, tagLine, region string) (*summoner.Summoner, []league.Entry, error) {
+	summoner, err := apiHelper.GetSummonerByTag(name, tagLine, region)
+	if err != nil {
+		logger.Logger.Error("Failed to fetch summoner data", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to fetch summoner data: %v", err)
+	}
+
+	entries, err := apiHelper.GetLeagueEntries(summoner.ID, region)
+	if err != nil {
+		logger.Logger.Error("Failed to fetch league entries", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to fetch league entries: %v", err)
+	}
+
+	return summoner, entries, nil
+}
+
+func newSummonerEmbed(summoner *summoner.Summoner, guildID string, attachments *assetprovider.Attachments) *embed.Embed {
+	iconURL := attachments.URL(assetprovider.ProfileIcon(summoner.ProfileIconID))
+	return embed.NewEmbed().
+		SetAuthor(summoner.GetNameTag(), iconURL, settings.ProfileURL(guildID, summoner)).
+		SetThumbnail(iconURL)
+}
+
+func addQueueField(e *embed.Embed, name string, entries []league.Entry, queueType string) {
+	entry := league.FindEntry(entries, queueType)
+	if entry == nil {
+		e.AddField(name, "UNRANKED")
+		return
+	}
+
+	e.AddField(name, fmt.Sprintf("%s\n%dW %dL (%.0f%%)", entry.Rank().ToString(), entry.Wins, entry.Losses, entry.WinRate()))
+}
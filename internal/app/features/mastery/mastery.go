@@ -0,0 +1,194 @@
+package mastery
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"discord-bot/internal/app/features/settings"
+	apiHelper "discord-bot/internal/app/helper/api"
+	"discord-bot/internal/app/helper/assetprovider"
+	assethelper "discord-bot/internal/app/helper/assets"
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/app/utility/gametoimage"
+	"discord-bot/internal/app/utility/i18n"
+	"discord-bot/internal/logger"
+	"discord-bot/types/catalog"
+	"discord-bot/types/embed"
+	"discord-bot/types/mastery"
+	"discord-bot/types/subscription"
+	"discord-bot/types/summoner"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// snapshotInterval is the time between two mastery snapshots of a tracked summoner
+const snapshotInterval = 6 * time.Hour
+
+// ImageName is the name of the attached mastery image
+const ImageName = "mastery.png"
+
+var discordSession *discordgo.Session
+
+// Initialize sets the Discord session used to announce mastery milestones
+func Initialize(session *discordgo.Session) {
+	discordSession = session
+}
+
+// Refresh takes a mastery snapshot of a summoner if the last one is older than snapshotInterval
+// and announces the levels and point milestones reached since then.
+// The first snapshot of a summoner is only stored, so tracking a new summoner does not flood its channels.
+func Refresh(s *summoner.Summoner) error {
+	due, err := databaseHelper.IsMasteryCheckDue(s.PUUID, snapshotInterval)
+	if err != nil || !due {
+		return err
+	}
+
+	previous, err := databaseHelper.GetChampionMasteries(s.PUUID)
+	if err != nil {
+		return err
+	}
+	current, err := apiHelper.GetChampionMasteries(s.PUUID, s.Region)
+	if err != nil {
+		return fmt.Errorf("failed to fetch champion masteries: %v", err)
+	}
+	if err := databaseHelper.SaveChampionMasteries(s.PUUID, current); err != nil {
+		return err
+	}
+
+	if len(previous) == 0 {
+		logger.Logger.Info("Stored first mastery snapshot", zap.String("nameTag", s.GetNameTag()), zap.Int("champions", len(current)))
+		return nil
+	}
+	for _, milestone := range mastery.Milestones(previous, current) {
+		logger.Logger.Info("Mastery milestone reached", zap.String("nameTag", s.GetNameTag()), zap.Any("milestone", milestone))
+		announce(s, milestone, pointsOf(current, milestone.ChampionID))
+	}
+	return nil
+}
+
+// announce posts a mastery milestone to every channel following the LoL games of the summoner that wants mastery notifications
+func announce(s *summoner.Summoner, milestone mastery.Milestone, points int) {
+	if discordSession == nil {
+		return
+	}
+
+	channels, err := databaseHelper.GetChannelsForSummonerByGame(s.PUUID, subscription.GamesLoL)
+	if err != nil {
+		logger.Logger.Error("Failed to get channels for summoner", zap.Error(err))
+		return
+	}
+
+	champions := assethelper.GetCatalog("")
+	championName := champions.ChampionName(milestone.ChampionID)
+	for _, channelID := range channels {
+		channelSettings, err := databaseHelper.GetChannelSettings(channelID)
+		if err != nil {
+			logger.Logger.Error("Failed to get channel settings", zap.String("channel", channelID), zap.Error(err))
+			continue
+		}
+		if !channelSettings.NotifyMastery || channelSettings.IsQuiet(time.Now()) {
+			continue
+		}
+
+		language := channelSettings.Language
+		title := i18n.T(language, i18n.MasteryPoints, s.GetNameTag(), formatPoints(milestone.Points), championName)
+		if milestone.Level > 0 {
+			title = i18n.T(language, i18n.MasteryLevel, s.GetNameTag(), milestone.Level, championName)
+		}
+
+		attachments := assetprovider.NewAttachments()
+		e := embed.NewEmbed().
+			SetAuthor(s.GetNameTag(), attachments.URL(assetprovider.ProfileIcon(s.ProfileIconID)), settings.ProfileURL(channelSettings.GuildID, s)).
+			SetTitle(title).
+			AddField(i18n.T(language, i18n.MasteryPointsSum), formatPoints(points)).
+			SetThumbnail(attachments.URL(assetprovider.ChampionSquare(milestone.ChampionID))).
+			SetColor(0x9b59b6)
+
+		_, err = discordSession.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+			Embeds: []*discordgo.MessageEmbed{e.MessageEmbed},
+			Files:  attachments.Files(),
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to send mastery milestone to Discord channel", zap.String("channel", channelID), zap.Error(err))
+		}
+	}
+}
+
+// Top looks up the champions with the most mastery points of any Riot ID and renders them as an image.
+// The image and icons that have to be attached are returned as files.
+func Top(name, tagLine, region, guildID string, count int) (*discordgo.MessageEmbed, []*discordgo.File, error) {
+	logger.Logger.Info("Looking up champion mastery", zap.String("name", name), zap.String("tagLine", tagLine), zap.String("region", region))
+
+	s, err := apiHelper.GetSummonerByTag(name, tagLine, region)
+	if err != nil {
+		logger.Logger.Error("Failed to fetch summoner data", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to fetch summoner data: %v", err)
+	}
+
+	masteries, err := apiHelper.GetChampionMasteries(s.PUUID, region)
+	if err != nil {
+		logger.Logger.Error("Failed to fetch champion masteries", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to fetch champion masteries: %v", err)
+	}
+
+	attachments := assetprovider.NewAttachments()
+	e := embed.NewEmbed().
+		SetAuthor(s.GetNameTag(), attachments.URL(assetprovider.ProfileIcon(s.ProfileIconID)), settings.ProfileURL(guildID, s)).
+		SetTitle("Champion Mastery").
+		SetColor(0x9b59b6)
+
+	top := mastery.Top(masteries, count)
+	if len(top) == 0 {
+		e.SetDescription("No champion mastery yet")
+		return e.MessageEmbed, attachments.Files(), nil
+	}
+
+	e.SetDescription(Lines(assethelper.GetCatalog(""), top)).
+		SetFooter(fmt.Sprintf("%d champions played | total mastery level %d", len(masteries), mastery.TotalLevel(masteries)))
+
+	image, err := gametoimage.MasteryToImage(top)
+	if err != nil {
+		// The list is complete without the image, so a failed render only drops it
+		logger.Logger.Error("Failed to render mastery image", zap.Error(err))
+		e.SetThumbnail(attachments.URL(assetprovider.ChampionSquare(top[0].ChampionID)))
+		return e.MessageEmbed, attachments.Files(), nil
+	}
+	e.SetImage("attachment://" + ImageName)
+	files := append(attachments.Files(), &discordgo.File{Name: ImageName, ContentType: "image/png", Reader: bytes.NewReader(image.Bytes())})
+
+	return e.MessageEmbed, files, nil
+}
+
+// Lines formats one line per champion mastery, used by /mastery and /profile
+func Lines(champions *catalog.Catalog, masteries []mastery.ChampionMastery) string {
+	var lines []string
+	for _, m := range masteries {
+		lines = append(lines, fmt.Sprintf("%s: Level %d (%s points)", champions.ChampionName(m.ChampionID), m.ChampionLevel, formatPoints(m.ChampionPoints)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func pointsOf(masteries []mastery.ChampionMastery, championID int) int {
+	for _, m := range masteries {
+		if m.ChampionID == championID {
+			return m.ChampionPoints
+		}
+	}
+	return 0
+}
+
+// formatPoints formats mastery points with thousands separators like 1,234,567
+func formatPoints(points int) string {
+	digits := fmt.Sprintf("%d", points)
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+	return b.String()
+}
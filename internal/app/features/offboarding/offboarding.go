@@ -3,29 +3,22 @@ package offboarding
 import (
 	databaseHelper "discord-bot/internal/app/helper/database"
 	"discord-bot/internal/logger"
-	"fmt"
-	"strings"
+	"discord-bot/types/riotid"
 
 	"go.uber.org/zap"
 )
 
 // DeleteSummoner deletes a summoner by name.
 func DeleteSummoner(name, tag, channelID string) error {
-
-	// Sanity check for name and tagLine to ensure they are URL-safe
-	if strings.ContainsAny(name, "!@#$%^&*()+=[]{}|\\;:'\",<>/?") || strings.ContainsAny(tag, " !@#$%^&*()+=[]{}|\\;:'\",<>/?") {
-		logger.Logger.Warn("Invalid characters in name or tagLine", zap.String("name", name), zap.String("tagLine", tag))
-		return fmt.Errorf("name or tagLine contains invalid characters")
-	}
-
-	// Sanity check for SQL injection
-	if strings.ContainsAny(name, "'\";--") || strings.ContainsAny(tag, "'\";--") {
-		logger.Logger.Warn("SQL injection characters in name or tagLine", zap.String("name", name), zap.String("tagLine", tag))
-		return fmt.Errorf("name or tagLine contains SQL injection characters")
+	id, err := riotid.New(name, tag)
+	if err != nil {
+		logger.Logger.Warn("Invalid Riot ID", zap.String("name", name), zap.String("tagLine", tag), zap.Error(err))
+		return err
 	}
+	name, tag = id.Name, id.Tag
 
-	summonerNameTag := name + "#" + tag
-	err := databaseHelper.DeleteChannelForSummonerByName(name, tag, channelID)
+	summonerNameTag := id.String()
+	err = databaseHelper.DeleteChannelForSummonerByName(name, tag, channelID)
 	if err != nil {
 		logger.Logger.Error("Failed to delete channel for summoner", zap.String("summonerNameTag", summonerNameTag), zap.String("channelID", channelID), zap.Error(err))
 		return err
@@ -36,7 +29,27 @@ func DeleteSummoner(name, tag, channelID string) error {
 }
 
 func DeleteChannel(channelID string) error {
-	err := databaseHelper.DeleteChannel(channelID)
+	err := databaseHelper.DeleteDigestSchedulesForChannel(channelID)
+	if err != nil {
+		logger.Logger.Error("Failed to delete digest schedules", zap.String("channelID", channelID), zap.Error(err))
+	}
+
+	err = databaseHelper.DeleteChannelSettings(channelID)
+	if err != nil {
+		logger.Logger.Error("Failed to delete channel settings", zap.String("channelID", channelID), zap.Error(err))
+	}
+
+	err = databaseHelper.DeleteClashAnnouncementsForChannel(channelID)
+	if err != nil {
+		logger.Logger.Error("Failed to delete Clash announcements", zap.String("channelID", channelID), zap.Error(err))
+	}
+
+	err = databaseHelper.DeleteStatusMessagesForChannel(channelID)
+	if err != nil {
+		logger.Logger.Error("Failed to delete status messages", zap.String("channelID", channelID), zap.Error(err))
+	}
+
+	err = databaseHelper.DeleteChannel(channelID)
 	if err != nil {
 		logger.Logger.Error("Failed to delete channel", zap.String("channelID", channelID), zap.Error(err))
 		return err
@@ -47,7 +60,32 @@ func DeleteChannel(channelID string) error {
 }
 
 func DeleteGuild(guildID string) error {
-	err := databaseHelper.DeleteGuild(guildID)
+	err := databaseHelper.DeleteDigestSchedulesForGuild(guildID)
+	if err != nil {
+		logger.Logger.Error("Failed to delete digest schedules", zap.String("guildID", guildID), zap.Error(err))
+	}
+
+	err = databaseHelper.DeleteChannelSettingsForGuild(guildID)
+	if err != nil {
+		logger.Logger.Error("Failed to delete channel settings", zap.String("guildID", guildID), zap.Error(err))
+	}
+
+	err = databaseHelper.DeleteGuildSettings(guildID)
+	if err != nil {
+		logger.Logger.Error("Failed to delete guild settings", zap.String("guildID", guildID), zap.Error(err))
+	}
+
+	err = databaseHelper.DeleteRankRolesForGuild(guildID)
+	if err != nil {
+		logger.Logger.Error("Failed to delete rank roles", zap.String("guildID", guildID), zap.Error(err))
+	}
+
+	err = databaseHelper.DeleteCommandRolesForGuild(guildID)
+	if err != nil {
+		logger.Logger.Error("Failed to delete command roles", zap.String("guildID", guildID), zap.Error(err))
+	}
+
+	err = databaseHelper.DeleteGuild(guildID)
 	if err != nil {
 		logger.Logger.Error("Failed to delete guild", zap.String("guildID", guildID), zap.Error(err))
 		return err
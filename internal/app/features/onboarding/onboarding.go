@@ -1,36 +1,70 @@
 package onboarding
 
 import (
+	"discord-bot/internal/app/features/identity"
 	apiHelper "discord-bot/internal/app/helper/api"
-	"discord-bot/internal/app/helper/cdragon"
+	"discord-bot/internal/app/helper/assetprovider"
 	databaseHelper "discord-bot/internal/app/helper/database"
 	"discord-bot/internal/logger"
 	"discord-bot/types/embed"
+	"discord-bot/types/riotid"
+	"discord-bot/types/subscription"
 	"discord-bot/types/summoner"
+	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/bwmarrin/discordgo"
 	"go.uber.org/zap"
 )
 
-// OnboardSummoner fetches summoner data by tag and saves it to the database
-func OnboardSummoner(name, tagLine, region, channelID, guildID string) (*discordgo.MessageEmbed, error) {
+// OnboardSummoner fetches summoner data by tag and saves it to the database.
+// games selects which games the channel follows for the summoner, see the subscription package.
+func OnboardSummoner(name, tagLine, region, channelID, guildID, games string) (*discordgo.MessageEmbed, error) {
 	logger.Logger.Info("Onboarding summoner", zap.String("name", name), zap.String("tagLine", tagLine), zap.String("region", region), zap.String("channelID", channelID))
-	var summoner *summoner.Summoner
 
-	// Sanity check for name and tagLine to ensure they are URL-safe
-	if strings.ContainsAny(name, "!@#$%^&*()+=[]{}|\\;:'\",<>/?") || strings.ContainsAny(tagLine, " !@#$%^&*()+=[]{}|\\;:'\",<>/?") {
-		logger.Logger.Warn("Invalid characters in name or tagLine", zap.String("name", name), zap.String("tagLine", tagLine))
-		return nil, fmt.Errorf("name or tagLine contains invalid characters")
+	id, err := riotid.New(name, tagLine)
+	if err != nil {
+		logger.Logger.Warn("Invalid Riot ID", zap.String("name", name), zap.String("tagLine", tagLine), zap.Error(err))
+		return nil, err
+	}
+	name, tagLine = id.Name, id.Tag
+
+	summoner, err := FindOrFetchSummoner(name, tagLine, region)
+	if err != nil {
+		return nil, err
 	}
 
-	// Sanity check for SQL injection
-	if strings.ContainsAny(name, "'\";--") || strings.ContainsAny(tagLine, "'\";--") {
-		logger.Logger.Warn("SQL injection characters in name or tagLine", zap.String("name", name), zap.String("tagLine", tagLine))
-		return nil, fmt.Errorf("name or tagLine contains SQL injection characters")
+	err = databaseHelper.SaveChannelForSummoner(summoner.PUUID, channelID, guildID)
+	if errors.Is(err, databaseHelper.ErrAlreadyMapped) {
+		return nil, fmt.Errorf("summoner already exists in this channel: name=%s, tagLine=%s, region=%s, channelID=%s", name, tagLine, region, channelID)
+	}
+	if err != nil {
+		logger.Logger.Error("Failed to save channel for summoner", zap.String("summoner", id.String()), zap.Error(err))
+		return nil, err
+	}
+	if games != subscription.GamesLoL {
+		err = databaseHelper.SetSubscriptionGames(summoner.PUUID, channelID, games)
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	embedMessage := embed.NewEmbed().
+		SetTitle("Summoner Onboarded").
+		SetDescription(fmt.Sprintf("Summoner %v is now registered", summoner.GetNameTag())).
+		AddField("Solo-Rank", summoner.SoloRank.ToString()).
+		AddField("Flex-Rank", summoner.FlexRank.ToString()).
+		AddField("Games", GamesName(games)).
+		SetThumbnail(assetprovider.URL(assetprovider.ProfileIcon(summoner.ProfileIconID))).
+		InlineAllFields().MessageEmbed
+
+	return embedMessage, nil
+}
+
+// FindOrFetchSummoner returns a summoner from the database, fetching and saving it from the API if it is not known yet
+func FindOrFetchSummoner(name, tagLine, region string) (*summoner.Summoner, error) {
+	var summoner *summoner.Summoner
+
 	summonerExists, err := databaseHelper.SummonerExists(name, tagLine, region)
 	if err != nil {
 		logger.Logger.Error("Failed to check if summoner exists", zap.Error(err))
@@ -52,24 +86,55 @@ func OnboardSummoner(name, tagLine, region, channelID, guildID string) (*discord
 		}
 		logger.Logger.Info("Fetched summoner data", zap.Any("summoner", summoner))
 
-		err = databaseHelper.SaveSummonerToDB(*summoner)
-		if err != nil {
-			return nil, fmt.Errorf("failed to save summoner to database: %v", err)
+		if known, err := databaseHelper.GetSummonerByPUUIDFromDB(summoner.PUUID); err == nil {
+			// The account is already known under its previous Riot ID or platform
+			err = identity.Apply(known, summoner)
+			if err != nil {
+				return nil, fmt.Errorf("failed to save summoner to database: %v", err)
+			}
+		} else {
+			err = databaseHelper.SaveSummonerToDB(*summoner)
+			if err != nil {
+				return nil, fmt.Errorf("failed to save summoner to database: %v", err)
+			}
 		}
 	}
 
-	err = databaseHelper.SaveChannelForSummoner(summoner.PUUID, channelID, guildID)
+	return summoner, nil
+}
+
+// SetGames changes which games a channel follows for a tracked summoner
+func SetGames(name, tagLine, channelID, games string) (*summoner.Summoner, error) {
+	id, err := riotid.New(name, tagLine)
 	if err != nil {
-		return nil, fmt.Errorf("summoner already exists in this channel: name=%s, tagLine=%s, region=%s, channelID=%s", name, tagLine, region, channelID)
+		return nil, err
+	}
+	if !subscription.IsValid(games) {
+		return nil, fmt.Errorf("unknown games %q", games)
 	}
 
-	embedMessage := embed.NewEmbed().
-		SetTitle("Summoner Onboarded").
-		SetDescription(fmt.Sprintf("Summoner %v is now registered", summoner.GetNameTag())).
-		AddField("Solo-Rank", summoner.SoloRank.ToString()).
-		AddField("Flex-Rank", summoner.FlexRank.ToString()).
-		SetThumbnail(cdragon.GetProfileIconURL(summoner.ProfileIconID)).
-		InlineAllFields().MessageEmbed
+	summoner, err := databaseHelper.GetDBSummonerByName(id.Name, id.Tag)
+	if err != nil {
+		logger.Logger.Warn("Summoner is not known", zap.String("summoner", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("summoner %v is not tracked", id)
+	}
+	err = databaseHelper.SetSubscriptionGames(summoner.PUUID, channelID, games)
+	if err != nil {
+		return nil, err
+	}
 
-	return embedMessage, nil
+	logger.Logger.Info("Changed followed games", zap.String("summoner", id.String()), zap.String("channelID", channelID), zap.String("games", games))
+	return summoner, nil
+}
+
+// GamesName returns the readable name of the games a subscription follows
+func GamesName(games string) string {
+	switch games {
+	case subscription.GamesTFT:
+		return "Teamfight Tactics"
+	case subscription.GamesBoth:
+		return "League of Legends and Teamfight Tactics"
+	default:
+		return "League of Legends"
+	}
 }
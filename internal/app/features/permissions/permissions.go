@@ -0,0 +1,195 @@
+package permissions
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/app/utility/ratelimit"
+	"discord-bot/internal/logger"
+	"discord-bot/types/embed"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// ManagementCommands change what the bot tracks or posts and are subject to the role allowlist of a guild.
+// They are registered without default member permissions, since Discord would hide them from allowlisted roles
+// that lack the permission, so the permission they require without an allowlist is checked by Authorize.
+var ManagementCommands = map[string]int64{
+	"add":      discordgo.PermissionManageChannels,
+	"delete":   discordgo.PermissionManageChannels,
+	"follow":   discordgo.PermissionManageChannels,
+	"settings": discordgo.PermissionManageChannels,
+	"digest":   discordgo.PermissionManageChannels,
+	"roles":    discordgo.PermissionManageRoles,
+	"export":   discordgo.PermissionManageChannels,
+	"import":   discordgo.PermissionManageChannels,
+}
+
+var (
+	userLimiter  *ratelimit.Limiter
+	guildLimiter *ratelimit.Limiter
+)
+
+// Initialize creates the command rate limiters, configurable via COMMAND_RATE_LIMIT_USER and COMMAND_RATE_LIMIT_GUILD (commands per minute)
+func Initialize() {
+	userLimiter = newPerMinuteLimiter(getEnvAsInt("COMMAND_RATE_LIMIT_USER", 10))
+	guildLimiter = newPerMinuteLimiter(getEnvAsInt("COMMAND_RATE_LIMIT_GUILD", 60))
+
+	go func() {
+		for {
+			time.Sleep(10 * time.Minute)
+			now := time.Now()
+			userLimiter.Cleanup(now)
+			guildLimiter.Cleanup(now)
+		}
+	}()
+}
+
+func newPerMinuteLimiter(perMinute int) *ratelimit.Limiter {
+	if perMinute < 1 {
+		perMinute = 1
+	}
+	return ratelimit.NewLimiter(perMinute, time.Minute/time.Duration(perMinute))
+}
+
+func getEnvAsInt(name string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(name)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// Authorize checks the role allowlist and the rate limits for a command invocation.
+// It returns an empty string if the command may run, otherwise the message to show the user.
+func Authorize(i *discordgo.InteractionCreate) string {
+	commandName := i.ApplicationCommandData().Name
+
+	userID := ""
+	if i.Member != nil && i.Member.User != nil {
+		userID = i.Member.User.ID
+	} else if i.User != nil {
+		userID = i.User.ID
+	}
+
+	now := time.Now()
+	if userLimiter != nil {
+		if ok, wait := userLimiter.Allow(userID, now); !ok {
+			logger.Logger.Info("User command rate limit exceeded", zap.String("userID", userID), zap.String("command", commandName))
+			return fmt.Sprintf("You are using commands too quickly, please try again in %d seconds.", waitSeconds(wait))
+		}
+	}
+	if guildLimiter != nil && i.GuildID != "" {
+		if ok, wait := guildLimiter.Allow(i.GuildID, now); !ok {
+			logger.Logger.Info("Guild command rate limit exceeded", zap.String("guildID", i.GuildID), zap.String("command", commandName))
+			return fmt.Sprintf("This server is using commands too quickly, please try again in %d seconds.", waitSeconds(wait))
+		}
+	}
+
+	requiredPermission, ok := ManagementCommands[commandName]
+	if !ok {
+		return ""
+	}
+	if i.Member == nil {
+		return "This command can only be used in a server."
+	}
+	if i.Member.Permissions&discordgo.PermissionAdministrator != 0 {
+		return ""
+	}
+
+	allowedRoles, err := databaseHelper.GetCommandRoles(i.GuildID)
+	if err != nil {
+		logger.Logger.Error("Failed to load command roles", zap.String("guildID", i.GuildID), zap.Error(err))
+		return "Your permissions could not be checked, please try again later."
+	}
+	if len(allowedRoles) == 0 {
+		if i.Member.Permissions&requiredPermission != 0 {
+			return ""
+		}
+		logger.Logger.Info("Command rejected by missing permission", zap.String("userID", userID), zap.String("command", commandName))
+		return fmt.Sprintf("You need the %s permission to use this command.", permissionName(requiredPermission))
+	}
+	for _, roleID := range allowedRoles {
+		for _, memberRole := range i.Member.Roles {
+			if memberRole == roleID {
+				return ""
+			}
+		}
+	}
+
+	logger.Logger.Info("Command rejected by role allowlist", zap.String("userID", userID), zap.String("command", commandName))
+	return "You need one of the roles allowed to manage this bot to use this command. Ask an admin to check /permissions show."
+}
+
+// permissionName returns the name Discord shows for the permissions of ManagementCommands
+func permissionName(permission int64) string {
+	if permission == discordgo.PermissionManageRoles {
+		return "Manage Roles"
+	}
+	return "Manage Channels"
+}
+
+func waitSeconds(wait time.Duration) int {
+	seconds := int(wait.Seconds() + 0.999)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return seconds
+}
+
+// AllowRole adds a role to the management command allowlist of a guild
+func AllowRole(guildID, roleID string) error {
+	err := databaseHelper.AddCommandRole(guildID, roleID)
+	if err != nil {
+		logger.Logger.Error("Failed to add command role", zap.String("guildID", guildID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// DisallowRole removes a role from the management command allowlist of a guild
+func DisallowRole(guildID, roleID string) error {
+	err := databaseHelper.RemoveCommandRole(guildID, roleID)
+	if err != nil {
+		logger.Logger.Warn("Failed to remove command role", zap.String("guildID", guildID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ShowRoles returns an embed with the management command allowlist of a guild
+func ShowRoles(guildID string) (*discordgo.MessageEmbed, error) {
+	allowedRoles, err := databaseHelper.GetCommandRoles(guildID)
+	if err != nil {
+		logger.Logger.Error("Failed to load command roles", zap.String("guildID", guildID), zap.Error(err))
+		return nil, fmt.Errorf("failed to load command roles: %v", err)
+	}
+
+	var commandNames []string
+	for name := range ManagementCommands {
+		commandNames = append(commandNames, "/"+name)
+	}
+	sort.Strings(commandNames)
+
+	e := embed.NewEmbed().
+		SetTitle("Command permissions").
+		SetFooter("Administrators can always use every command")
+
+	if len(allowedRoles) == 0 {
+		e.SetDescription("No allowlist is configured, members with Manage Channels can use the management commands and members with Manage Roles can use /roles.")
+	} else {
+		var mentions []string
+		for _, roleID := range allowedRoles {
+			mentions = append(mentions, fmt.Sprintf("<@&%s>", roleID))
+		}
+		e.SetDescription("Only members with one of these roles can use management commands:\n" + strings.Join(mentions, "\n"))
+	}
+	e.AddField("Management commands", strings.Join(commandNames, ", "))
+
+	return e.MessageEmbed, nil
+}
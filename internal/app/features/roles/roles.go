@@ -0,0 +1,274 @@
+package roles
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/logger"
+	"discord-bot/types/account"
+	"discord-bot/types/embed"
+	"discord-bot/types/rank"
+	"discord-bot/types/roles"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+var discordSession *discordgo.Session
+
+// sweepInterval is the time between two full syncs of all guilds
+const sweepInterval = time.Hour
+
+// Change is a role update of a guild member, Err is set if applying it failed
+type Change struct {
+	UserID string
+	Add    []string
+	Remove []string
+	Err    error
+}
+
+// Initialize sets the Discord session used to update member roles
+func Initialize(session *discordgo.Session) {
+	discordSession = session
+}
+
+// RunSweep syncs the rank roles of every guild periodically
+func RunSweep() {
+	for {
+		time.Sleep(sweepInterval)
+
+		guilds, err := databaseHelper.GetGuildsWithRankRoles()
+		if err != nil {
+			logger.Logger.Error("Failed to load guilds with rank roles", zap.Error(err))
+			continue
+		}
+		for _, guildID := range guilds {
+			if _, err := SyncGuild(guildID, false); err != nil {
+				logger.Logger.Error("Failed to sync rank roles", zap.String("guildID", guildID), zap.Error(err))
+			}
+		}
+	}
+}
+
+// SyncSummoner updates the roles of every user who verified the summoner in the guilds with rank roles that track it
+func SyncSummoner(puuid string) {
+	users, err := databaseHelper.GetVerifiedUsersForSummoner(puuid)
+	if err != nil {
+		logger.Logger.Error("Failed to load verified users for summoner", zap.String("puuid", puuid), zap.Error(err))
+		return
+	}
+	if len(users) == 0 {
+		return
+	}
+
+	guilds, err := databaseHelper.GetGuildsWithRankRolesForSummoner(puuid)
+	if err != nil {
+		logger.Logger.Error("Failed to load guilds with rank roles", zap.String("puuid", puuid), zap.Error(err))
+		return
+	}
+	for _, guildID := range guilds {
+		mapping, err := databaseHelper.GetRankRoles(guildID)
+		if err != nil {
+			logger.Logger.Error("Failed to load rank roles", zap.String("guildID", guildID), zap.Error(err))
+			continue
+		}
+		for _, userID := range users {
+			change, err := syncMember(guildID, userID, mapping, false)
+			if err != nil {
+				logger.Logger.Error("Failed to sync rank roles of member", zap.String("guildID", guildID), zap.String("userID", userID), zap.Error(err))
+			} else if change != nil && change.Err != nil {
+				logger.Logger.Warn("Failed to apply rank roles", zap.String("guildID", guildID), zap.String("userID", userID), zap.Error(change.Err))
+			}
+		}
+	}
+}
+
+// SyncGuild updates the roles of all members with verified accounts tracked in the guild, dryRun only computes the changes.
+// Only these users are looked up, so a sync costs one member request per tracked user instead of one per verified user of any guild.
+func SyncGuild(guildID string, dryRun bool) ([]Change, error) {
+	mapping, err := databaseHelper.GetRankRoles(guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rank roles: %v", err)
+	}
+	if len(mapping) == 0 {
+		return nil, nil
+	}
+
+	users, err := databaseHelper.GetVerifiedUsersInGuild(guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load verified users: %v", err)
+	}
+
+	var changes []Change
+	for _, userID := range users {
+		change, err := syncMember(guildID, userID, mapping, dryRun)
+		if err != nil {
+			logger.Logger.Error("Failed to sync rank roles of member", zap.String("guildID", guildID), zap.String("userID", userID), zap.Error(err))
+			continue
+		}
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	logger.Logger.Info("Synced rank roles", zap.String("guildID", guildID), zap.Int("changes", len(changes)), zap.Bool("dryRun", dryRun))
+	return changes, nil
+}
+
+// syncMember computes and applies the role changes of one user, nil if the user is not a member or already in sync
+func syncMember(guildID, userID string, mapping []roles.RankRole, dryRun bool) (*Change, error) {
+	member, err := discordSession.GuildMember(guildID, userID)
+	if err != nil {
+		if isStatus(err, http.StatusNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get guild member: %v", err)
+	}
+
+	summoners, err := databaseHelper.GetVerifiedSummonersForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The best account of a user decides the tier per queue
+	var bestSolo, bestFlex rank.Rank
+	for _, s := range summoners {
+		if s.SoloRank > bestSolo {
+			bestSolo = s.SoloRank
+		}
+		if s.FlexRank > bestFlex {
+			bestFlex = s.FlexRank
+		}
+	}
+	tiers := map[string]string{
+		roles.QueueSolo: bestSolo.Tier(),
+		roles.QueueFlex: bestFlex.Tier(),
+	}
+
+	add, remove := roles.Diff(mapping, tiers, member.Roles)
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, nil
+	}
+
+	change := &Change{UserID: userID, Add: add, Remove: remove}
+	if dryRun {
+		return change, nil
+	}
+
+	for _, roleID := range add {
+		if err := discordSession.GuildMemberRoleAdd(guildID, userID, roleID); err != nil {
+			change.Err = describeRoleError(roleID, err)
+		}
+	}
+	for _, roleID := range remove {
+		if err := discordSession.GuildMemberRoleRemove(guildID, userID, roleID); err != nil {
+			change.Err = describeRoleError(roleID, err)
+		}
+	}
+	return change, nil
+}
+
+// describeRoleError explains role hierarchy errors, which need an admin to move the bot's role up
+func describeRoleError(roleID string, err error) error {
+	if isStatus(err, http.StatusForbidden) {
+		return fmt.Errorf("missing permission for role <@&%s>, the bot's role must be above it and have Manage Roles", roleID)
+	}
+	return fmt.Errorf("failed to update role <@&%s>: %v", roleID, err)
+}
+
+func isStatus(err error, status int) bool {
+	var restErr *discordgo.RESTError
+	return errors.As(err, &restErr) && restErr.Response != nil && restErr.Response.StatusCode == status
+}
+
+// SetRole maps a tier of a queue to a role of a guild
+func SetRole(guildID, queueType, tier, roleID string) error {
+	tier = strings.ToUpper(tier)
+	if !roles.IsTier(tier) {
+		return fmt.Errorf("invalid tier %q", tier)
+	}
+	if queueType != roles.QueueSolo && queueType != roles.QueueFlex {
+		return fmt.Errorf("invalid queue %q", queueType)
+	}
+
+	err := databaseHelper.SaveRankRole(roles.RankRole{GuildID: guildID, QueueType: queueType, Tier: tier, RoleID: roleID})
+	if err != nil {
+		logger.Logger.Error("Failed to save rank role", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// RemoveRole removes the role mapping of a tier of a queue
+func RemoveRole(guildID, queueType, tier string) error {
+	err := databaseHelper.DeleteRankRole(guildID, queueType, strings.ToUpper(tier))
+	if err != nil {
+		logger.Logger.Warn("Failed to delete rank role", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ShowRoles returns an embed with the role mappings of a guild
+func ShowRoles(guildID string) (*discordgo.MessageEmbed, error) {
+	mapping, err := databaseHelper.GetRankRoles(guildID)
+	if err != nil {
+		logger.Logger.Error("Failed to load rank roles", zap.Error(err))
+		return nil, fmt.Errorf("failed to load rank roles: %v", err)
+	}
+
+	e := embed.NewEmbed().SetTitle("Rank roles")
+	if len(mapping) == 0 {
+		e.SetDescription("No rank roles are configured. Use /roles set to map a tier to a role.")
+		return e.MessageEmbed, nil
+	}
+
+	byQueue := make(map[string][]string)
+	for _, tier := range roles.Tiers {
+		for _, m := range mapping {
+			if m.Tier == tier {
+				byQueue[m.QueueType] = append(byQueue[m.QueueType], fmt.Sprintf("%s: <@&%s>", tier, m.RoleID))
+			}
+		}
+	}
+	for _, queueType := range []string{roles.QueueSolo, roles.QueueFlex} {
+		if lines, ok := byQueue[queueType]; ok {
+			e.AddField(queueType, strings.Join(lines, "\n"))
+		}
+	}
+	return e.InlineAllFields().MessageEmbed, nil
+}
+
+// ChangesEmbed describes the role changes of a sync or its preview
+func ChangesEmbed(changes []Change, dryRun bool) *discordgo.MessageEmbed {
+	title := "Rank roles synced"
+	if dryRun {
+		title = "Rank role preview"
+	}
+	e := embed.NewEmbed().SetTitle(title)
+	if len(changes) == 0 {
+		e.SetDescription("All members with verified accounts tracked in this server already have the right roles.")
+		return e.MessageEmbed
+	}
+
+	var lines []string
+	for _, change := range changes {
+		line := account.Mention(change.UserID) + ":"
+		for _, roleID := range change.Add {
+			line += fmt.Sprintf(" +<@&%s>", roleID)
+		}
+		for _, roleID := range change.Remove {
+			line += fmt.Sprintf(" -<@&%s>", roleID)
+		}
+		if change.Err != nil {
+			line += fmt.Sprintf(" (%v)", change.Err)
+		}
+		lines = append(lines, line)
+	}
+	e.SetDescription(strings.Join(lines, "\n"))
+	return e.Truncate().MessageEmbed
+}
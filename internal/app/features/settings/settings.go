@@ -0,0 +1,233 @@
+package settings
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/app/utility/gametoimage"
+	"discord-bot/internal/app/utility/i18n"
+	"discord-bot/internal/logger"
+	"discord-bot/types/embed"
+	"discord-bot/types/profilelink"
+	"discord-bot/types/queue"
+	"discord-bot/types/settings"
+	"discord-bot/types/summoner"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// Show returns an embed with the current notification settings of a channel and the settings of its guild
+func Show(channelID, guildID string) (*discordgo.MessageEmbed, error) {
+	channelSettings, err := databaseHelper.GetChannelSettings(channelID)
+	if err != nil {
+		logger.Logger.Error("Failed to load channel settings", zap.String("channelID", channelID), zap.Error(err))
+		return nil, fmt.Errorf("failed to load channel settings: %v", err)
+	}
+	guildSettings, err := databaseHelper.GetGuildSettings(guildID)
+	if err != nil {
+		logger.Logger.Error("Failed to load guild settings", zap.String("guildID", guildID), zap.Error(err))
+		return nil, fmt.Errorf("failed to load guild settings: %v", err)
+	}
+	return settingsEmbed(channelSettings, guildSettings), nil
+}
+
+// Update loads the settings of a channel, applies change to them and stores the result
+func Update(channelID, guildID string, change func(*settings.ChannelSettings) error) (*discordgo.MessageEmbed, error) {
+	channelSettings, err := databaseHelper.GetChannelSettings(channelID)
+	if err != nil {
+		logger.Logger.Error("Failed to load channel settings", zap.String("channelID", channelID), zap.Error(err))
+		return nil, fmt.Errorf("failed to load channel settings: %v", err)
+	}
+	channelSettings.GuildID = guildID
+
+	err = change(channelSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	err = databaseHelper.SaveChannelSettings(channelSettings)
+	if err != nil {
+		logger.Logger.Error("Failed to save channel settings", zap.String("channelID", channelID), zap.Error(err))
+		return nil, fmt.Errorf("failed to save channel settings: %v", err)
+	}
+
+	logger.Logger.Info("Updated channel settings", zap.String("channelID", channelID))
+	return Show(channelID, guildID)
+}
+
+// SetProfileSite stores the site that profile links of a guild point to
+func SetProfileSite(channelID, guildID, site string) (*discordgo.MessageEmbed, error) {
+	if !profilelink.IsSupported(site) {
+		return nil, fmt.Errorf("unsupported profile site %q", site)
+	}
+
+	guildSettings, err := databaseHelper.GetGuildSettings(guildID)
+	if err != nil {
+		logger.Logger.Error("Failed to load guild settings", zap.String("guildID", guildID), zap.Error(err))
+		return nil, fmt.Errorf("failed to load guild settings: %v", err)
+	}
+	guildSettings.ProfileSite = site
+
+	err = databaseHelper.SaveGuildSettings(guildSettings)
+	if err != nil {
+		logger.Logger.Error("Failed to save guild settings", zap.String("guildID", guildID), zap.Error(err))
+		return nil, fmt.Errorf("failed to save guild settings: %v", err)
+	}
+
+	logger.Logger.Info("Updated profile site", zap.String("guildID", guildID), zap.String("site", site))
+	return Show(channelID, guildID)
+}
+
+// SetImageTheme stores the image theme of rank update notifications of a guild
+func SetImageTheme(channelID, guildID, theme string) (*discordgo.MessageEmbed, error) {
+	if theme != settings.ScoreboardTheme && !gametoimage.HasTheme(theme) {
+		return nil, fmt.Errorf("unknown theme %q, choose from: %s", theme, strings.Join(ImageThemes(), ", "))
+	}
+
+	guildSettings, err := databaseHelper.GetGuildSettings(guildID)
+	if err != nil {
+		logger.Logger.Error("Failed to load guild settings", zap.String("guildID", guildID), zap.Error(err))
+		return nil, fmt.Errorf("failed to load guild settings: %v", err)
+	}
+	guildSettings.ImageTheme = theme
+
+	err = databaseHelper.SaveGuildSettings(guildSettings)
+	if err != nil {
+		logger.Logger.Error("Failed to save guild settings", zap.String("guildID", guildID), zap.Error(err))
+		return nil, fmt.Errorf("failed to save guild settings: %v", err)
+	}
+
+	logger.Logger.Info("Updated image theme", zap.String("guildID", guildID), zap.String("theme", theme))
+	return Show(channelID, guildID)
+}
+
+// ImageThemes returns the names of all selectable image themes, the scoreboard first
+func ImageThemes() []string {
+	names := []string{settings.ScoreboardTheme}
+	for _, theme := range gametoimage.Themes() {
+		names = append(names, theme.Name)
+	}
+	return names
+}
+
+// ProfileURL returns the profile link of a summoner on the profile site chosen by a guild
+func ProfileURL(guildID string, s *summoner.Summoner) string {
+	guildSettings, err := databaseHelper.GetGuildSettings(guildID)
+	if err != nil {
+		logger.Logger.Warn("Failed to load guild settings, using the default profile site", zap.String("guildID", guildID), zap.Error(err))
+		guildSettings = settings.NewGuildSettings(guildID)
+	}
+	return guildSettings.ProfileURL(s.Region, s.Name, s.TagLine)
+}
+
+// SetQueueFilter returns a change restricting notifications to a queue
+func SetQueueFilter(queue string) func(*settings.ChannelSettings) error {
+	return func(s *settings.ChannelSettings) error {
+		if queue != settings.QueueAll && queue != settings.QueueSolo && queue != settings.QueueFlex {
+			return fmt.Errorf("invalid queue %q", queue)
+		}
+		s.QueueFilter = queue
+		return nil
+	}
+}
+
+// SetOptInQueue returns a change that enables or disables notifications for a non-ranked queue category
+func SetOptInQueue(category string, enabled bool) func(*settings.ChannelSettings) error {
+	return func(s *settings.ChannelSettings) error {
+		if !queue.IsCategory(category) || category == queue.CategorySolo || category == queue.CategoryFlex || category == queue.CategoryClash {
+			return fmt.Errorf("invalid queue %q, choose from: %s", category, strings.Join(queue.NonRankedCategories, ", "))
+		}
+		optInQueues := []string{}
+		for _, optIn := range s.OptInQueues {
+			if optIn != category {
+				optInQueues = append(optInQueues, optIn)
+			}
+		}
+		if enabled {
+			optInQueues = append(optInQueues, category)
+		}
+		s.OptInQueues = optInQueues
+		return nil
+	}
+}
+
+// SetMinLPChange returns a change that suppresses rank updates below an LP threshold
+func SetMinLPChange(minLPChange int) func(*settings.ChannelSettings) error {
+	return func(s *settings.ChannelSettings) error {
+		if minLPChange < 0 || minLPChange > 100 {
+			return fmt.Errorf("minimum LP change must be between 0 and 100")
+		}
+		s.MinLPChange = minLPChange
+		return nil
+	}
+}
+
+// SetLanguage returns a change of the notification language
+func SetLanguage(language string) func(*settings.ChannelSettings) error {
+	return func(s *settings.ChannelSettings) error {
+		if !i18n.IsSupported(language) {
+			return fmt.Errorf("unsupported language %q, choose from: %s", language, strings.Join(i18n.Languages(), ", "))
+		}
+		s.Language = language
+		return nil
+	}
+}
+
+// SetQuietHours returns a change of the quiet hours, a negative start disables them
+func SetQuietHours(start, end int, timezone string) func(*settings.ChannelSettings) error {
+	return func(s *settings.ChannelSettings) error {
+		if start < 0 {
+			s.QuietStart, s.QuietEnd = -1, -1
+			return nil
+		}
+		if start > 23 || end < 0 || end > 23 {
+			return fmt.Errorf("quiet hours must be between 0 and 23")
+		}
+		if timezone == "" {
+			timezone = "UTC"
+		}
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return fmt.Errorf("unknown timezone %q", timezone)
+		}
+		s.QuietStart, s.QuietEnd, s.Timezone = start, end, timezone
+		return nil
+	}
+}
+
+func settingsEmbed(s *settings.ChannelSettings, g *settings.GuildSettings) *discordgo.MessageEmbed {
+	optInQueues := "none"
+	if len(s.OptInQueues) > 0 {
+		optInQueues = strings.Join(s.OptInQueues, ", ")
+	}
+	quietHours := "disabled"
+	if s.HasQuietHours() {
+		quietHours = fmt.Sprintf("%02d:00 - %02d:00 (%s)", s.QuietStart, s.QuietEnd, s.Timezone)
+	}
+
+	return embed.NewEmbed().
+		SetTitle("Channel settings").
+		AddField("Match start notifications", onOff(s.NotifyStart)).
+		AddField("Rank update notifications", onOff(s.NotifyEnd)).
+		AddField("Mastery notifications", onOff(s.NotifyMastery)).
+		AddField("Clash notifications", onOff(s.NotifyClash)).
+		AddField("Riot status notifications", onOff(s.NotifyStatus)).
+		AddField("Queues", s.QueueFilter).
+		AddField("Other queues", optInQueues).
+		AddField("Minimum LP change", fmt.Sprintf("%d", s.MinLPChange)).
+		AddField("Match images", onOff(s.ImagesEnabled)).
+		AddField("Language", s.Language).
+		AddField("Quiet hours", quietHours).
+		AddField("Profile links (server-wide)", profilelink.Name(g.ProfileSite)).
+		AddField("Image theme (server-wide)", g.ImageTheme).
+		InlineAllFields().MessageEmbed
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,236 @@
+package status
+
+import (
+	"fmt"
+	"time"
+
+	apiHelper "discord-bot/internal/app/helper/api"
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/app/utility/i18n"
+	"discord-bot/internal/logger"
+	"discord-bot/types/embed"
+	"discord-bot/types/status"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// pollInterval is the time between two lol-status-v4 polls of every tracked platform
+const pollInterval = 5 * time.Minute
+
+// Colors of status announcements
+const (
+	colorCritical = 0xff0000
+	colorWarning  = 0xffa500
+	colorInfo     = 0x3498db
+	colorResolved = 0x00ff00
+)
+
+var discordSession *discordgo.Session
+
+// Initialize sets the Discord session used to announce Riot incidents and maintenances
+func Initialize(session *discordgo.Session) {
+	discordSession = session
+}
+
+// RunPoller polls the status of every platform with tracked summoners periodically.
+// The first poll runs right away, so a maintenance in progress is known before the first summoner checks fail.
+func RunPoller() {
+	for {
+		poll()
+		time.Sleep(pollInterval)
+	}
+}
+
+func poll() {
+	platforms, err := databaseHelper.GetTrackedPlatforms()
+	if err != nil {
+		logger.Logger.Error("Failed to load tracked platforms", zap.Error(err))
+		return
+	}
+	for _, platform := range platforms {
+		pollPlatform(platform)
+	}
+}
+
+// pollPlatform records whether the platform is under maintenance, announces new incidents and maintenances,
+// edits the announcements Riot posted updates for and marks the ones that disappeared from the feed as resolved
+func pollPlatform(platform string) {
+	platformStatus, err := apiHelper.GetPlatformStatus(platform)
+	if err != nil {
+		// The status endpoint itself may be down during an outage, the next poll retries
+		logger.Logger.Warn("Failed to fetch platform status", zap.String("platform", platform), zap.Error(err))
+		return
+	}
+
+	underMaintenance := platformStatus.UnderMaintenance()
+	if underMaintenance != apiHelper.UnderMaintenance(platform) {
+		logger.Logger.Info("Platform maintenance changed", zap.String("platform", platform), zap.Bool("underMaintenance", underMaintenance))
+	}
+	apiHelper.SetMaintenance(platform, underMaintenance)
+
+	channels, err := databaseHelper.GetStatusChannelsForPlatform(platform)
+	if err != nil {
+		logger.Logger.Error("Failed to load status channels", zap.String("platform", platform), zap.Error(err))
+		return
+	}
+	messages, err := databaseHelper.GetStatusMessages(platform)
+	if err != nil {
+		logger.Logger.Error("Failed to load status messages", zap.String("platform", platform), zap.Error(err))
+		return
+	}
+	posted := make(map[string]databaseHelper.StatusMessage)
+	for _, message := range messages {
+		posted[message.ChannelID+"|"+message.EntryKey] = message
+	}
+
+	active := make(map[string]bool)
+	for _, entry := range platformStatus.Entries() {
+		active[entry.Key()] = true
+		for _, channelID := range channels {
+			var existing *databaseHelper.StatusMessage
+			if message, ok := posted[channelID+"|"+entry.Key()]; ok {
+				existing = &message
+			}
+			announce(channelID, platform, platformStatus, entry, existing)
+		}
+	}
+
+	for _, message := range messages {
+		if !active[message.EntryKey] {
+			resolve(message)
+		}
+	}
+}
+
+// announce posts an incident or maintenance to a channel, or edits the posted announcement if Riot changed the entry
+func announce(channelID, platform string, platformStatus *status.PlatformStatus, entry status.Entry, existing *databaseHelper.StatusMessage) {
+	if discordSession == nil {
+		return
+	}
+	if existing != nil && existing.Version == entry.Version() {
+		return
+	}
+
+	channelSettings, err := databaseHelper.GetChannelSettings(channelID)
+	if err != nil {
+		logger.Logger.Error("Failed to get channel settings", zap.String("channel", channelID), zap.Error(err))
+		return
+	}
+	message := statusEmbed(platformStatus, entry, channelSettings.Language)
+
+	if existing != nil {
+		_, err = discordSession.ChannelMessageEditEmbed(channelID, existing.MessageID, message)
+		if err == nil {
+			existing.Version = entry.Version()
+			if err := databaseHelper.SaveStatusMessage(*existing); err != nil {
+				logger.Logger.Error("Failed to save status message", zap.Error(err))
+			}
+			return
+		}
+		// The announcement was deleted, so the update is posted as a new one
+		logger.Logger.Warn("Failed to edit status message", zap.String("channel", channelID), zap.Error(err))
+	}
+
+	// New announcements wait for the end of quiet hours, edits do not ping anyone
+	if channelSettings.IsQuiet(time.Now()) {
+		return
+	}
+	sent, err := discordSession.ChannelMessageSendEmbed(channelID, message)
+	if err != nil {
+		logger.Logger.Error("Failed to send status message to Discord channel", zap.String("channel", channelID), zap.Error(err))
+		return
+	}
+	err = databaseHelper.SaveStatusMessage(databaseHelper.StatusMessage{
+		ChannelID: channelID,
+		Platform:  platform,
+		EntryKey:  entry.Key(),
+		MessageID: sent.ID,
+		Version:   entry.Version(),
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to save status message", zap.Error(err))
+	}
+}
+
+// resolve marks an announcement as resolved once its entry disappeared from the feed and forgets it
+func resolve(message databaseHelper.StatusMessage) {
+	if discordSession == nil {
+		return
+	}
+
+	posted, err := discordSession.ChannelMessage(message.ChannelID, message.MessageID)
+	if err == nil && len(posted.Embeds) > 0 {
+		channelSettings, err := databaseHelper.GetChannelSettings(message.ChannelID)
+		language := i18n.DefaultLanguage
+		if err == nil {
+			language = channelSettings.Language
+		}
+
+		resolved := posted.Embeds[0]
+		resolved.Color = colorResolved
+		resolved.Fields = []*discordgo.MessageEmbedField{{Name: i18n.T(language, i18n.StatusState), Value: i18n.T(language, i18n.StatusResolved), Inline: true}}
+		if _, err := discordSession.ChannelMessageEditEmbed(message.ChannelID, message.MessageID, resolved); err != nil {
+			logger.Logger.Warn("Failed to mark status message as resolved", zap.String("channel", message.ChannelID), zap.Error(err))
+		}
+	}
+
+	if err := databaseHelper.DeleteStatusMessage(message.ChannelID, message.Platform, message.EntryKey); err != nil {
+		logger.Logger.Error("Failed to delete status message", zap.Error(err))
+	}
+}
+
+// statusEmbed builds the announcement of an incident or maintenance with its latest update in the channel language
+func statusEmbed(platformStatus *status.PlatformStatus, entry status.Entry, language string) *discordgo.MessageEmbed {
+	locale := status.Locale(language)
+
+	title := i18n.T(language, i18n.StatusIncident, platformStatus.Name)
+	if entry.Kind == status.KindMaintenance {
+		title = i18n.T(language, i18n.StatusMaintenance, platformStatus.Name)
+	}
+	description := fmt.Sprintf("**%s**", entry.Title(locale))
+	if update := entry.LatestUpdate(locale); update != "" {
+		description += "\n" + update
+	}
+
+	e := embed.NewEmbed().
+		SetTitle(title).
+		SetDescription(description).
+		SetColor(statusColor(entry)).
+		SetFooter(platformStatus.ID)
+	if state := stateName(entry, language); state != "" {
+		e.AddField(i18n.T(language, i18n.StatusState), state).InlineAllFields()
+	}
+	return e.Truncate().MessageEmbed
+}
+
+// stateName returns the translated state of a maintenance, incidents have no state
+func stateName(entry status.Entry, language string) string {
+	switch entry.MaintenanceStatus {
+	case status.MaintenanceScheduled:
+		return i18n.T(language, i18n.StatusScheduled)
+	case status.MaintenanceInProgress:
+		return i18n.T(language, i18n.StatusInProgress)
+	case status.MaintenanceComplete:
+		return i18n.T(language, i18n.StatusResolved)
+	default:
+		return ""
+	}
+}
+
+func statusColor(entry status.Entry) int {
+	if entry.MaintenanceStatus == status.MaintenanceComplete {
+		return colorResolved
+	}
+	switch entry.IncidentSeverity {
+	case status.SeverityCritical:
+		return colorCritical
+	case status.SeverityWarning:
+		return colorWarning
+	default:
+		if entry.Kind == status.KindMaintenance {
+			return colorWarning
+		}
+		return colorInfo
+	}
+}
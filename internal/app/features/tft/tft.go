@@ -0,0 +1,220 @@
+package tft
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"discord-bot/internal/app/features/settings"
+	apiHelper "discord-bot/internal/app/helper/api"
+	"discord-bot/internal/app/helper/assetprovider"
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/app/utility/gametoimage"
+	"discord-bot/internal/app/utility/i18n"
+	"discord-bot/internal/logger"
+	"discord-bot/types/embed"
+	"discord-bot/types/league"
+	"discord-bot/types/rank"
+	"discord-bot/types/subscription"
+	"discord-bot/types/summoner"
+	"discord-bot/types/tft"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// ImageName is the name of the attached board image
+const ImageName = "tftboard.png"
+
+var discordSession *discordgo.Session
+
+// Initialize sets the Discord session used to announce TFT games
+func Initialize(session *discordgo.Session) {
+	discordSession = session
+}
+
+// Refresh checks a summoner for a started or finished ranked TFT game and announces it to every channel following
+// their TFT games. Summoners no channel follows in TFT are skipped without any API call.
+// The first finished game seen for a summoner is only stored, so enabling TFT does not announce an old game.
+func Refresh(s *summoner.Summoner) (err error) {
+	channels, err := databaseHelper.GetChannelsForSummonerByGame(s.PUUID, subscription.GamesTFT)
+	if err != nil || len(channels) == 0 {
+		return err
+	}
+
+	state, err := databaseHelper.GetTFTState(s.PUUID)
+	if err != nil {
+		return err
+	}
+
+	activeGame, err := apiHelper.GetActiveTFTGame(s.PUUID, s.Region)
+	if err != nil {
+		return err
+	}
+
+	// The state is saved even if a later request fails, so an announced game start is never announced again
+	defer func() {
+		if saveErr := databaseHelper.SaveTFTState(s.PUUID, state); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}()
+
+	if activeGame != nil && activeGame.GameID != state.ActiveGameID {
+		state.ActiveGameID = activeGame.GameID
+		if activeGame.IsRanked() {
+			logger.Logger.Info("Ranked TFT game started", zap.String("nameTag", s.GetNameTag()), zap.Int64("gameID", activeGame.GameID))
+			announceStart(s, channels)
+		}
+	}
+
+	lastMatchID, err := apiHelper.GetLastTFTMatchID(s.PUUID)
+	if err != nil {
+		return err
+	}
+	if lastMatchID != "" && lastMatchID != state.LastMatchID {
+		firstMatch := state.LastMatchID == ""
+		if err := finishMatch(s, channels, lastMatchID, state, firstMatch); err != nil {
+			// A match that does not exist is skipped, other failures are retried on the next check
+			if errors.Is(err, apiHelper.ErrNotFound) {
+				state.LastMatchID = lastMatchID
+			}
+			return err
+		}
+		state.LastMatchID = lastMatchID
+	}
+
+	return nil
+}
+
+// finishMatch updates the TFT rank in state after a finished game and announces ranked games unless silent is set
+func finishMatch(s *summoner.Summoner, channels []string, matchID string, state *databaseHelper.TFTState, silent bool) error {
+	finishedMatch, err := apiHelper.GetTFTMatch(matchID)
+	if err != nil {
+		return err
+	}
+	if !finishedMatch.IsRanked() {
+		return nil
+	}
+
+	entries, err := apiHelper.GetTFTLeagueEntries(s.PUUID, s.Region)
+	if err != nil {
+		return err
+	}
+	newRank := rank.Rank(0)
+	if entry := league.FindEntry(entries, league.QueueTFT); entry != nil {
+		newRank = entry.Rank()
+	}
+	previousRank := state.Rank
+	state.Rank = newRank
+
+	participant := finishedMatch.Participant(s.PUUID)
+	if silent || participant == nil {
+		return nil
+	}
+	logger.Logger.Info("Ranked TFT game finished", zap.String("nameTag", s.GetNameTag()), zap.String("matchID", matchID), zap.Int("placement", participant.Placement))
+	announceResult(s, channels, finishedMatch, participant, newRank, rank.RankDifference(newRank, previousRank))
+	return nil
+}
+
+func announceStart(s *summoner.Summoner, channels []string) {
+	if discordSession == nil {
+		return
+	}
+
+	for _, channelID := range channels {
+		channelSettings, err := databaseHelper.GetChannelSettings(channelID)
+		if err != nil {
+			logger.Logger.Error("Failed to get channel settings", zap.String("channel", channelID), zap.Error(err))
+			continue
+		}
+		if !channelSettings.NotifyStart || channelSettings.IsQuiet(time.Now()) {
+			continue
+		}
+
+		attachments := assetprovider.NewAttachments()
+		e := embed.NewEmbed().
+			SetAuthor(s.GetNameTag(), attachments.URL(assetprovider.ProfileIcon(s.ProfileIconID)), settings.ProfileURL(channelSettings.GuildID, s)).
+			SetTitle(i18n.T(channelSettings.Language, i18n.TFTStartedTitle)).
+			SetColor(0x3498db)
+
+		_, err = discordSession.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+			Embeds: []*discordgo.MessageEmbed{e.MessageEmbed},
+			Files:  attachments.Files(),
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to send TFT game start to Discord channel", zap.String("channel", channelID), zap.Error(err))
+		}
+	}
+}
+
+func announceResult(s *summoner.Summoner, channels []string, finishedMatch *tft.Match, participant *tft.Participant, newRank rank.Rank, rankChange int) {
+	if discordSession == nil {
+		return
+	}
+
+	rankChangeString := fmt.Sprintf("+%d", rankChange)
+	if rankChange < 0 {
+		rankChangeString = fmt.Sprintf("%d", rankChange)
+	}
+	color := 0x00ff00 // Green color for a top four placement
+	if !participant.IsTopFour() {
+		color = 0xff0000
+	}
+	rankTier := strings.ToLower(newRank.Tier())
+
+	// The board is rendered once per game and only if a channel wants it
+	var boardImage []byte
+	boardRendered := false
+
+	for _, channelID := range channels {
+		channelSettings, err := databaseHelper.GetChannelSettings(channelID)
+		if err != nil {
+			logger.Logger.Error("Failed to get channel settings", zap.String("channel", channelID), zap.Error(err))
+			continue
+		}
+		if !channelSettings.NotifyEnd || channelSettings.IsQuiet(time.Now()) || !channelSettings.AllowsLPChange(rankChange) {
+			continue
+		}
+
+		language := channelSettings.Language
+		attachments := assetprovider.NewAttachments()
+		e := embed.NewEmbed().
+			SetAuthor(s.GetNameTag(), attachments.URL(assetprovider.ProfileIcon(s.ProfileIconID)), settings.ProfileURL(channelSettings.GuildID, s)).
+			SetTitle(i18n.T(language, i18n.TFTResultTitle, tft.Ordinal(participant.Placement), rankChangeString)).
+			AddField(i18n.T(language, i18n.TFTPlacement), tft.Ordinal(participant.Placement)).
+			AddField(i18n.T(language, i18n.TFTRank), newRank.ToString()).
+			SetFooter(newRank.ToString(), attachments.URL(assetprovider.RankCrest(rankTier))).
+			SetColor(color).InlineAllFields()
+
+		messageSend := &discordgo.MessageSend{}
+		if channelSettings.ImagesEnabled {
+			if !boardRendered {
+				boardImage = renderBoard(finishedMatch, participant)
+				boardRendered = true
+			}
+			if boardImage != nil {
+				e.SetImage("attachment://" + ImageName)
+				messageSend.Files = []*discordgo.File{{Name: ImageName, ContentType: "image/png", Reader: bytes.NewReader(boardImage)}}
+			}
+		}
+		messageSend.Embeds = []*discordgo.MessageEmbed{e.MessageEmbed}
+		messageSend.Files = append(messageSend.Files, attachments.Files()...)
+
+		_, err = discordSession.ChannelMessageSendComplex(channelID, messageSend)
+		if err != nil {
+			logger.Logger.Error("Failed to send TFT result to Discord channel", zap.String("channel", channelID), zap.Error(err))
+		}
+	}
+}
+
+// renderBoard renders the final board of a participant, nil if it fails so the result is sent without it
+func renderBoard(finishedMatch *tft.Match, participant *tft.Participant) []byte {
+	image, err := gametoimage.TFTBoardToImage(*participant, finishedMatch.SetNumber)
+	if err != nil {
+		logger.Logger.Error("Failed to render TFT board", zap.String("matchID", finishedMatch.MatchID), zap.Error(err))
+		return nil
+	}
+	return image.Bytes()
+}
@@ -0,0 +1,266 @@
+package transfer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"discord-bot/internal/app/features/onboarding"
+	apiHelper "discord-bot/internal/app/helper/api"
+	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/logger"
+	"discord-bot/types/embed"
+	"discord-bot/types/riotid"
+	"discord-bot/types/subscription"
+	"discord-bot/types/summoner"
+	"discord-bot/types/transfer"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// Scopes of an export
+const (
+	ScopeChannel = "channel"
+	ScopeGuild   = "guild"
+)
+
+// Limits of an import file, every entry costs a few Riot API requests
+const (
+	MaxImportEntries = 500
+	maxImportSize    = 1 << 20
+)
+
+// progressInterval is the minimum time between two progress reports of an import
+const progressInterval = 5 * time.Second
+
+// Progress is the state of a running import
+type Progress struct {
+	Total    int
+	Done     int
+	Imported int
+	Skipped  int
+	Failures []string
+}
+
+// Export builds a file with the subscriptions of a channel or guild together with the settings of their channels
+func Export(scope, format, channelID, guildID string) (*discordgo.File, int, error) {
+	var tracked []summoner.TrackedSummoner
+	var err error
+	if scope == ScopeGuild {
+		tracked, err = databaseHelper.GetTrackedSummonersForGuild(guildID)
+	} else {
+		scope = ScopeChannel
+		tracked, err = databaseHelper.GetTrackedSummonersForChannel(channelID)
+	}
+	if err != nil {
+		logger.Logger.Error("Failed to load tracked summoners for export", zap.String("scope", scope), zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to load tracked summoners: %v", err)
+	}
+
+	channelSettings := make(map[string]*transfer.Settings)
+	entries := make([]transfer.Entry, 0, len(tracked))
+	for _, t := range tracked {
+		s, ok := channelSettings[t.ChannelID]
+		if !ok {
+			stored, err := databaseHelper.GetChannelSettings(t.ChannelID)
+			if err != nil {
+				logger.Logger.Error("Failed to load channel settings for export", zap.String("channelID", t.ChannelID), zap.Error(err))
+				return nil, 0, fmt.Errorf("failed to load channel settings: %v", err)
+			}
+			s = transfer.FromChannelSettings(stored)
+			channelSettings[t.ChannelID] = s
+		}
+		entries = append(entries, transfer.Entry{
+			RiotID:    t.Summoner.GetNameTag(),
+			Region:    t.Summoner.Region,
+			PUUID:     t.Summoner.PUUID,
+			ChannelID: t.ChannelID,
+			Games:     t.Games,
+			Settings:  s,
+		})
+	}
+
+	data, err := transfer.Encode(entries, format)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to encode export: %v", err)
+	}
+
+	logger.Logger.Info("Exported tracked summoners", zap.String("scope", scope), zap.String("format", format), zap.Int("entries", len(entries)))
+	return &discordgo.File{
+		Name:        fmt.Sprintf("summoners-%s.%s", scope, format),
+		ContentType: contentType(format),
+		Reader:      bytes.NewReader(data),
+	}, len(entries), nil
+}
+
+func contentType(format string) string {
+	if format == transfer.FormatCSV {
+		return "text/csv"
+	}
+	return "application/json"
+}
+
+// Download fetches and decodes an import file attached to an interaction
+func Download(url string, size int) ([]transfer.Entry, error) {
+	if size > maxImportSize {
+		return nil, fmt.Errorf("the file is too large, the limit is %d KB", maxImportSize/1024)
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download the file: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download the file: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImportSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the file: %v", err)
+	}
+	if len(data) > maxImportSize {
+		return nil, fmt.Errorf("the file is too large, the limit is %d KB", maxImportSize/1024)
+	}
+
+	entries, err := transfer.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("the file contains no summoners")
+	}
+	if len(entries) > MaxImportEntries {
+		return nil, fmt.Errorf("the file contains %d summoners, the limit is %d", len(entries), MaxImportEntries)
+	}
+	return entries, nil
+}
+
+// Import subscribes the summoners of the entries, resolving each of them through the rate limited Riot API.
+// Entries keep their channel if it belongs to the guild, otherwise they are added to channelID.
+// The settings of an entry are applied once to its own channel if it belongs to the guild, never to channelID,
+// so the settings of the invoking channel are not replaced by those of a foreign one. report is called periodically and when the import is finished.
+func Import(entries []transfer.Entry, channelID, guildID string, guildChannels map[string]bool, report func(progress *Progress)) {
+	logger.Logger.Info("Importing summoners", zap.String("guildID", guildID), zap.Int("entries", len(entries)))
+
+	progress := &Progress{Total: len(entries)}
+	settingsApplied := make(map[string]bool)
+	lastReport := time.Now()
+
+	for index, entry := range entries {
+		targetChannel := channelID
+		if guildChannels[entry.ChannelID] {
+			targetChannel = entry.ChannelID
+		}
+
+		imported, err := importEntry(entry, targetChannel, guildID)
+		switch {
+		case err != nil:
+			logger.Logger.Warn("Failed to import summoner", zap.String("riotID", entry.RiotID), zap.Error(err))
+			progress.Failures = append(progress.Failures, fmt.Sprintf("Entry %d (%s): %v", index+1, entry.RiotID, err))
+		case imported:
+			progress.Imported++
+		default:
+			progress.Skipped++
+		}
+
+		if err == nil && entry.Settings != nil && entry.ChannelID == targetChannel && !settingsApplied[targetChannel] {
+			settingsApplied[targetChannel] = true
+			if err := databaseHelper.SaveChannelSettings(entry.Settings.ChannelSettings(targetChannel, guildID)); err != nil {
+				logger.Logger.Error("Failed to import channel settings", zap.String("channelID", targetChannel), zap.Error(err))
+			}
+		}
+
+		progress.Done++
+		if time.Since(lastReport) >= progressInterval && progress.Done < progress.Total {
+			lastReport = time.Now()
+			report(progress)
+		}
+	}
+
+	logger.Logger.Info("Imported summoners", zap.String("guildID", guildID), zap.Int("imported", progress.Imported), zap.Int("skipped", progress.Skipped), zap.Int("failed", len(progress.Failures)))
+	report(progress)
+}
+
+// importEntry subscribes the summoner of an entry to a channel, it returns false if the summoner was already tracked there
+func importEntry(entry transfer.Entry, channelID, guildID string) (bool, error) {
+	region := strings.ToUpper(strings.TrimSpace(entry.Region))
+	if region == "" {
+		return false, fmt.Errorf("missing region")
+	}
+
+	games := entry.Games
+	if games == "" {
+		games = subscription.GamesLoL
+	}
+	if !subscription.IsValid(games) {
+		return false, fmt.Errorf("unknown games %q", entry.Games)
+	}
+
+	summoner, err := resolveSummoner(entry, region)
+	if err != nil {
+		return false, err
+	}
+
+	err = databaseHelper.SaveChannelForSummoner(summoner.PUUID, channelID, guildID)
+	if errors.Is(err, databaseHelper.ErrAlreadyMapped) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if games != subscription.GamesLoL {
+		if err := databaseHelper.SetSubscriptionGames(summoner.PUUID, channelID, games); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// resolveSummoner finds the summoner of an entry by its PUUID and falls back to its Riot ID,
+// since PUUIDs of exports from bots with another API key cannot be decrypted
+func resolveSummoner(entry transfer.Entry, region string) (*summoner.Summoner, error) {
+	if entry.PUUID != "" {
+		if s, err := databaseHelper.GetSummonerByPUUIDFromDB(entry.PUUID); err == nil {
+			return s, nil
+		}
+		if s, err := apiHelper.GetSummonerByPUUID(entry.PUUID, region); err == nil {
+			if err := databaseHelper.SaveSummonerToDB(*s); err != nil {
+				return nil, fmt.Errorf("failed to save summoner to database: %v", err)
+			}
+			return s, nil
+		}
+	}
+
+	id, err := riotid.Parse(entry.RiotID)
+	if err != nil {
+		return nil, err
+	}
+	return onboarding.FindOrFetchSummoner(id.Name, id.Tag, region)
+}
+
+// ProgressEmbed describes the state of an import
+func ProgressEmbed(progress *Progress) *discordgo.MessageEmbed {
+	title := "Importing summoners..."
+	if progress.Done == progress.Total {
+		title = "Import finished"
+	}
+
+	e := embed.NewEmbed().
+		SetTitle(title).
+		SetDescription(fmt.Sprintf("%d of %d entries processed", progress.Done, progress.Total)).
+		AddField("Imported", fmt.Sprintf("%d", progress.Imported)).
+		AddField("Already tracked", fmt.Sprintf("%d", progress.Skipped)).
+		AddField("Failed", fmt.Sprintf("%d", len(progress.Failures))).
+		InlineAllFields()
+
+	if len(progress.Failures) > 0 {
+		e.AddField("Failures", strings.Join(progress.Failures, "\n"))
+	}
+	return e.Truncate().MessageEmbed
+}
@@ -0,0 +1,106 @@
+package apiHelper
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"discord-bot/types/clash"
+)
+
+// GetClashTournaments fetches the active and upcoming Clash tournaments of a platform
+func GetClashTournaments(region string) ([]clash.Tournament, error) {
+	var apiResponse []struct {
+		ID               int    `json:"id"`
+		ThemeID          int    `json:"themeId"`
+		NameKey          string `json:"nameKey"`
+		NameKeySecondary string `json:"nameKeySecondary"`
+		Schedule         []struct {
+			ID               int   `json:"id"`
+			RegistrationTime int64 `json:"registrationTime"`
+			StartTime        int64 `json:"startTime"`
+			Cancelled        bool  `json:"cancelled"`
+		} `json:"schedule"`
+	}
+	err := getJSON(region, "", "/lol/clash/v1/tournaments", &apiResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Clash tournaments: %w", err)
+	}
+
+	var tournaments []clash.Tournament
+	for _, t := range apiResponse {
+		tournament := clash.Tournament{ID: t.ID, ThemeID: t.ThemeID, NameKey: t.NameKey, NameKeySecondary: t.NameKeySecondary}
+		for _, phase := range t.Schedule {
+			tournament.Schedule = append(tournament.Schedule, clash.Phase{
+				ID:               phase.ID,
+				RegistrationTime: time.UnixMilli(phase.RegistrationTime),
+				StartTime:        time.UnixMilli(phase.StartTime),
+				Cancelled:        phase.Cancelled,
+			})
+		}
+		tournaments = append(tournaments, tournament)
+	}
+	return tournaments, nil
+}
+
+// GetClashPlayers fetches the Clash registrations of a summoner, empty if they are not registered
+func GetClashPlayers(puuid, region string) ([]clash.Player, error) {
+	var apiResponse []struct {
+		PUUID    string `json:"puuid"`
+		TeamID   string `json:"teamId"`
+		Position string `json:"position"`
+		Role     string `json:"role"`
+	}
+	err := getJSON(region, "", fmt.Sprintf("/lol/clash/v1/players/by-puuid/%s", puuid), &apiResponse)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Clash players: %w", err)
+	}
+
+	var players []clash.Player
+	for _, p := range apiResponse {
+		if p.PUUID == "" {
+			p.PUUID = puuid
+		}
+		players = append(players, clash.Player{PUUID: p.PUUID, TeamID: p.TeamID, Position: p.Position, Role: p.Role})
+	}
+	return players, nil
+}
+
+// GetClashTeam fetches a Clash team with its players
+func GetClashTeam(teamID, region string) (*clash.Team, error) {
+	var apiResponse struct {
+		ID           string `json:"id"`
+		TournamentID int    `json:"tournamentId"`
+		Name         string `json:"name"`
+		IconID       int    `json:"iconId"`
+		Tier         int    `json:"tier"`
+		Captain      string `json:"captain"`
+		Abbreviation string `json:"abbreviation"`
+		Players      []struct {
+			PUUID    string `json:"puuid"`
+			Position string `json:"position"`
+			Role     string `json:"role"`
+		} `json:"players"`
+	}
+	err := getJSON(region, "", fmt.Sprintf("/lol/clash/v1/teams/%s", teamID), &apiResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Clash team: %w", err)
+	}
+
+	team := &clash.Team{
+		ID:           apiResponse.ID,
+		TournamentID: apiResponse.TournamentID,
+		Name:         apiResponse.Name,
+		Abbreviation: apiResponse.Abbreviation,
+		IconID:       apiResponse.IconID,
+		Tier:         apiResponse.Tier,
+		Captain:      apiResponse.Captain,
+	}
+	for _, p := range apiResponse.Players {
+		team.Players = append(team.Players, clash.Player{PUUID: p.PUUID, TeamID: apiResponse.ID, Position: p.Position, Role: p.Role})
+	}
+	return team, nil
+}
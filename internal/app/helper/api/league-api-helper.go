@@ -3,16 +3,21 @@ package apiHelper
 import (
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"time"
 
 	"discord-bot/internal/app/constants"
+	assethelper "discord-bot/internal/app/helper/assets"
 	databaseHelper "discord-bot/internal/app/helper/database"
 	"discord-bot/internal/logger"
+	"discord-bot/types/league"
+	"discord-bot/types/mastery"
 	"discord-bot/types/match"
 	"discord-bot/types/rank"
 	"discord-bot/types/summoner"
@@ -26,6 +31,9 @@ var (
 	requestQueue chan request
 )
 
+// ErrNotFound is returned when the Riot API answers with 404, e.g. when a summoner is not in game
+var ErrNotFound = errors.New("not found")
+
 type request struct {
 	url      string
 	response chan *http.Response
@@ -33,6 +41,8 @@ type request struct {
 }
 
 func init() {
+	logger.InitLogger()
+
 	// Create a custom transport with a shared TLS connection
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{
@@ -103,18 +113,56 @@ func makeRequest(url string) (*http.Response, error) {
 	}
 }
 
+// getJSON requests path on a platform or regional host and decodes the JSON response into target
+func getJSON(platform, region, path string, target interface{}) error {
+	err := LoadEnv()
+	if err != nil {
+		return fmt.Errorf("error loading .env file")
+	}
+
+	apiKey := os.Getenv("RIOT_API_TOKEN")
+	if apiKey == "" {
+		return fmt.Errorf("API token not found in environment variables")
+	}
+
+	baseUrl, err := getBaseURL(platform, region)
+	if err != nil {
+		return err
+	}
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	resp, err := makeRequest(baseUrl + path + separator + "api_key=" + apiKey)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, target)
+}
+
 func processRequests() {
 	for req := range requestQueue {
 		waitForRateLimiters()
 		resp, err := client.Get(req.url)
 		if err != nil {
-			logger.Logger.Error("Failed to make request", zap.Error(err))
+			logRequestFailure(req.url, "Failed to make request", zap.Error(err))
 			req.err <- fmt.Errorf("failed to make request: %w", err)
 			continue
 		}
 
 		if resp.StatusCode == 404 {
-			req.err <- fmt.Errorf("not found")
+			req.err <- ErrNotFound
 			continue
 		}
 		if resp.StatusCode == 429 {
@@ -123,13 +171,13 @@ func processRequests() {
 			waitForRateLimiters()
 			resp, err = client.Get(req.url)
 			if err != nil {
-				logger.Logger.Error("Failed to make request after retries", zap.Error(err))
+				logRequestFailure(req.url, "Failed to make request after retries", zap.Error(err))
 				req.err <- fmt.Errorf("failed to make request after retries: %w", err)
 				continue
 			}
 		}
 		if resp.StatusCode != http.StatusOK {
-			logger.Logger.Error("Failed to make request", zap.Int("status", resp.StatusCode))
+			logRequestFailure(req.url, "Failed to make request", zap.Int("status", resp.StatusCode))
 			req.err <- fmt.Errorf("failed to make request: status %d", resp.StatusCode)
 			continue
 		}
@@ -138,6 +186,15 @@ func processRequests() {
 	}
 }
 
+// logRequestFailure logs a failed request as an error, or as a warning if its platform is under maintenance
+func logRequestFailure(requestURL, msg string, fields ...zap.Field) {
+	if underMaintenanceURL(requestURL) {
+		logger.Logger.Warn(msg+" during platform maintenance", fields...)
+		return
+	}
+	logger.Logger.Error(msg, fields...)
+}
+
 func GetSummonerByTag(name, tagLine, region string) (*summoner.Summoner, error) {
 	err := LoadEnv()
 	if err != nil {
@@ -154,8 +211,10 @@ func GetSummonerByTag(name, tagLine, region string) (*summoner.Summoner, error)
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/riot/account/v1/accounts/by-riot-id/%s/%s?api_key=%s", baseURL, name, tagLine, apiKey)
-	resp, err := makeRequest(url)
+	// Names may contain spaces and non-Latin characters, so both parts have to be escaped
+	escapedName, escapedTagLine := url.PathEscape(name), url.PathEscape(tagLine)
+	requestURL := fmt.Sprintf("%s/riot/account/v1/accounts/by-riot-id/%s/%s?api_key=%s", baseURL, escapedName, escapedTagLine, apiKey)
+	resp, err := makeRequest(requestURL)
 	if err != nil {
 		return nil, err
 	}
@@ -182,56 +241,68 @@ func GetSummonerByTag(name, tagLine, region string) (*summoner.Summoner, error)
 		return nil, err
 	}
 
-	return GetSummonerByPUUID(accountData.PUUID, region)
+	return GetSummonerByPUUID(accountData.PUUID, region, &accountData.Name, &accountData.TagLine)
 }
 
 func GetSummonerProfileIconIDByPUUID(puuid, region string) (int, error) {
+	summonerData, err := getSummonerData(puuid, region)
+	if err != nil {
+		return 0, err
+	}
+
+	return summonerData.ProfileIconID, nil
+}
+
+// summonerData is the summoner-v4 representation of a summoner
+type summonerData struct {
+	ID            string `json:"id"`
+	AccountID     string `json:"accountId"`
+	PUUID         string `json:"puuid"`
+	ProfileIconID int    `json:"profileIconId"`
+	RevisionDate  int64  `json:"revisionDate"`
+	SummonerLevel int    `json:"summonerLevel"`
+}
+
+// getSummonerData fetches the summoner-v4 data of a summoner by PUUID
+func getSummonerData(puuid, region string) (*summonerData, error) {
 	err := LoadEnv()
 	if err != nil {
-		return 0, fmt.Errorf("error loading .env file")
+		return nil, fmt.Errorf("error loading .env file")
 	}
 
 	apiKey := os.Getenv("RIOT_API_TOKEN")
 	if apiKey == "" {
-		return 0, fmt.Errorf("API token not found in environment variables")
+		return nil, fmt.Errorf("API token not found in environment variables")
 	}
 
 	baseUrl, err := getBaseURL(region, "")
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	url := fmt.Sprintf("%s/lol/summoner/v4/summoners/by-puuid/%s?api_key=%s", baseUrl, puuid, apiKey)
 	resp, err := makeRequest(url)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("failed to fetch summoner data: %s", resp.Status)
+		return nil, fmt.Errorf("failed to fetch summoner data: %s", resp.Status)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	var summonerData struct {
-		ID            string `json:"id"`
-		AccountID     string `json:"accountId"`
-		PUUID         string `json:"puuid"`
-		ProfileIconID int    `json:"profileIconId"`
-		RevisionDate  int64  `json:"revisionDate"`
-		SummonerLevel int    `json:"summonerLevel"`
-	}
-
-	err = json.Unmarshal(body, &summonerData)
+	var data summonerData
+	err = json.Unmarshal(body, &data)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return summonerData.ProfileIconID, nil
+	return &data, nil
 }
 
 func GetSummonerByPUUID(puuid, region string, optionalArgs ...*string) (*summoner.Summoner, error) {
@@ -244,16 +315,22 @@ func GetSummonerByPUUID(puuid, region string, optionalArgs ...*string) (*summone
 		tag = *optionalArgs[1]
 	}
 
-	err := LoadEnv()
+	summoner, err := getUnrankedSummonerByPUUID(puuid, region, name, tag)
 	if err != nil {
-		return nil, fmt.Errorf("error loading .env file")
+		return nil, err
 	}
 
-	apiKey := os.Getenv("RIOT_API_TOKEN")
-	if apiKey == "" {
-		return nil, fmt.Errorf("API token not found in environment variables")
+	summoner.SoloRank, summoner.FlexRank, err = GetSummonerRank(summoner.ID, region)
+	if err != nil {
+		return summoner, err
 	}
 
+	return summoner, nil
+}
+
+// getUnrankedSummonerByPUUID fetches a summoner without its ranks, for callers that fetch the league entries themselves.
+// The Riot ID is only fetched if name or tag is empty.
+func getUnrankedSummonerByPUUID(puuid, region, name, tag string) (*summoner.Summoner, error) {
 	if name == "" || tag == "" {
 		var err error
 		name, tag, err = GetNameTagByPUUID(puuid)
@@ -262,19 +339,59 @@ func GetSummonerByPUUID(puuid, region string, optionalArgs ...*string) (*summone
 		}
 	}
 
+	summonerData, err := getSummonerData(puuid, region)
+	if err != nil {
+		return nil, err
+	}
+
+	s := summoner.NewSummoner(name, tag, summonerData.AccountID, summonerData.ID, puuid, summonerData.ProfileIconID, 0, 0, time.Now(), region)
+	s.Level = summonerData.SummonerLevel
+	return s, nil
+}
+
+func GetSummonerRank(summonerID, region string) (rank.Rank, rank.Rank, error) {
+	entries, err := GetLeagueEntries(summonerID, region)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var soloRank, flexRank rank.Rank = 0, 0
+
+	if soloEntry := league.FindEntry(entries, league.QueueSolo); soloEntry != nil {
+		soloRank = soloEntry.Rank()
+	}
+	if flexEntry := league.FindEntry(entries, league.QueueFlex); flexEntry != nil {
+		flexRank = flexEntry.Rank()
+	}
+
+	return soloRank, flexRank, nil
+}
+
+// GetLeagueEntries fetches the league entries of a summoner for all ranked queues
+func GetLeagueEntries(summonerID, region string) ([]league.Entry, error) {
+	err := LoadEnv()
+	if err != nil {
+		return nil, fmt.Errorf("error loading .env file")
+	}
+
+	apiKey := os.Getenv("RIOT_API_TOKEN")
+	if apiKey == "" {
+		return nil, fmt.Errorf("API token not found in environment variables")
+	}
+
 	baseUrl, err := getBaseURL(region, "")
 	if err != nil {
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/lol/summoner/v4/summoners/by-puuid/%s?api_key=%s", baseUrl, puuid, apiKey)
+	url := fmt.Sprintf("%s/lol/league/v4/entries/by-summoner/%s?api_key=%s", baseUrl, summonerID, apiKey)
 	resp, err := makeRequest(url)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch summoner data: %s", resp.Status)
+		return nil, fmt.Errorf("failed to fetch summoner rank: %s", resp.Status)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -283,102 +400,65 @@ func GetSummonerByPUUID(puuid, region string, optionalArgs ...*string) (*summone
 	}
 	defer resp.Body.Close()
 
-	var summonerData struct {
-		ID            string `json:"id"`
-		AccountID     string `json:"accountId"`
-		PUUID         string `json:"puuid"`
-		ProfileIconID int    `json:"profileIconId"`
-		RevisionDate  int64  `json:"revisionDate"`
-		SummonerLevel int    `json:"summonerLevel"`
-	}
-
-	err = json.Unmarshal(body, &summonerData)
+	var entries []league.Entry
+	err = json.Unmarshal(body, &entries)
 	if err != nil {
 		return nil, err
 	}
 
-	solorank, rankFlex, err := GetSummonerRank(summonerData.ID, region)
-
-	summoner := summoner.NewSummoner(
-		name,
-		tag, // TagLine
-		summonerData.AccountID,
-		summonerData.ID,
-		puuid,
-		summonerData.ProfileIconID,
-		solorank,
-		rankFlex, // FlexRank
-		time.Now(),
-		region, // Updated
-	)
+	return entries, nil
+}
 
-	if err != nil {
-		return summoner, err
-	}
+// GetTopChampionMasteries fetches the champions with the highest mastery of a summoner
+func GetTopChampionMasteries(puuid, region string, count int) ([]mastery.ChampionMastery, error) {
+	return getChampionMasteries(region, fmt.Sprintf("%s/top?count=%d&", puuid, count))
+}
 
-	return summoner, nil
+// GetChampionMasteries fetches the mastery of a summoner on every champion played, highest first
+func GetChampionMasteries(puuid, region string) ([]mastery.ChampionMastery, error) {
+	return getChampionMasteries(region, puuid+"?")
 }
 
-func GetSummonerRank(summonerID, region string) (rank.Rank, rank.Rank, error) {
+// getChampionMasteries requests champion-masteries/by-puuid/<path>, path ends with the separator for the api key
+func getChampionMasteries(region, path string) ([]mastery.ChampionMastery, error) {
 	err := LoadEnv()
 	if err != nil {
-		return 0, 0, fmt.Errorf("error loading .env file")
+		return nil, fmt.Errorf("error loading .env file")
 	}
 
 	apiKey := os.Getenv("RIOT_API_TOKEN")
 	if apiKey == "" {
-		return 0, 0, fmt.Errorf("API token not found in environment variables")
+		return nil, fmt.Errorf("API token not found in environment variables")
 	}
 
 	baseUrl, err := getBaseURL(region, "")
 	if err != nil {
-		return 0, 0, err
+		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/lol/league/v4/entries/by-summoner/%s?api_key=%s", baseUrl, summonerID, apiKey)
+	url := fmt.Sprintf("%s/lol/champion-mastery/v4/champion-masteries/by-puuid/%sapi_key=%s", baseUrl, path, apiKey)
 	resp, err := makeRequest(url)
 	if err != nil {
-		return 0, 0, err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, 0, fmt.Errorf("failed to fetch summoner rank: %s", resp.Status)
+		return nil, fmt.Errorf("failed to fetch champion masteries: %s", resp.Status)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return 0, 0, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	var rankData []struct {
-		QueueType    string `json:"queueType"`
-		Tier         string `json:"tier"`
-		Rank         string `json:"rank"`
-		LeaguePoints int    `json:"leaguePoints"`
-	}
-
-	err = json.Unmarshal(body, &rankData)
+	var masteries []mastery.ChampionMastery
+	err = json.Unmarshal(body, &masteries)
 	if err != nil {
-		return 0, 0, err
-	}
-
-	if len(rankData) == 0 {
-		return 0, 0, nil
-	}
-
-	var soloRank, flexRank rank.Rank = 0, 0
-
-	for _, entry := range rankData {
-		rankStr := fmt.Sprintf("%s %s %d LP", entry.Tier, entry.Rank, entry.LeaguePoints)
-		if entry.QueueType == "RANKED_SOLO_5x5" {
-			soloRank = rank.FromString(rankStr)
-		} else if entry.QueueType == "RANKED_FLEX_SR" {
-			flexRank = rank.FromString(rankStr)
-		}
+		return nil, err
 	}
 
-	return soloRank, flexRank, nil
+	return masteries, nil
 }
 
 func GetLastRankedMatchIDbyPUUID(puuid string) (string, error) {
@@ -451,6 +531,10 @@ func GetMatchByID(matchId string) (*match.Match, error) {
 	url := fmt.Sprintf("%s/lol/match/v5/matches/%s?api_key=%s", baseUrl, matchId, apiKey)
 	logger.Logger.Info("Request URL", zap.String("url", url)) // Updated code
 	resp, err := makeRequest(url)
+	if errors.Is(err, ErrNotFound) {
+		// Matches are only available once they are finished
+		return nil, ErrNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error making request: %v", err)
 	}
@@ -472,7 +556,13 @@ func GetMatchByID(matchId string) (*match.Match, error) {
 			GameID string `json:"matchId"`
 		} `json:"metadata"`
 		Info struct {
-			QueueID      int `json:"queueId"`
+			QueueID      int    `json:"queueId"`
+			GameDuration int64  `json:"gameDuration"`
+			GameVersion  string `json:"gameVersion"`
+			Teams        []struct {
+				TeamID int  `json:"teamId"`
+				Win    bool `json:"win"`
+			} `json:"teams"`
 			Participants []struct {
 				PUUID      string `json:"puuid"`
 				TeamID     int    `json:"teamId"`
@@ -504,6 +594,14 @@ func GetMatchByID(matchId string) (*match.Match, error) {
 				Item4          int    `json:"item4"`
 				Item5          int    `json:"item5"`
 				Item6          int    `json:"item6"`
+				Kills          int    `json:"kills"`
+				Deaths         int    `json:"deaths"`
+				Assists        int    `json:"assists"`
+				MinionsKilled  int    `json:"totalMinionsKilled"`
+				NeutralKilled  int    `json:"neutralMinionsKilled"`
+				GoldEarned     int    `json:"goldEarned"`
+				ChampionDamage int    `json:"totalDamageDealtToChampions"`
+				Placement      int    `json:"placement"`
 			} `json:"participants"`
 		} `json:"info"`
 	}
@@ -512,19 +610,19 @@ func GetMatchByID(matchId string) (*match.Match, error) {
 		return nil, fmt.Errorf("failed to unmarshal response body: %v", err)
 	}
 
-	gameType := "Flex"
-	if apiResponse.Info.QueueID == 440 {
-		gameType = "Flex"
-	} else if apiResponse.Info.QueueID == 420 {
-		gameType = "Solo/Duo"
-	} else {
-		gameType = "UNRANKED"
-	}
-
 	matchData := &match.Match{
-		GameID:   apiResponse.Metadata.GameID,
-		Teams:    [2]match.Team{{TeamID: 100}, {TeamID: 200}},
-		GameType: gameType,
+		GameID:      apiResponse.Metadata.GameID,
+		Teams:       [2]match.Team{{TeamID: 100}, {TeamID: 200}},
+		Queue:       assethelper.GetQueue(apiResponse.Info.QueueID),
+		Duration:    time.Duration(apiResponse.Info.GameDuration) * time.Second,
+		GameVersion: apiResponse.Info.GameVersion,
+	}
+	for _, team := range apiResponse.Info.Teams {
+		for i := range matchData.Teams {
+			if matchData.Teams[i].TeamID == team.TeamID {
+				matchData.Teams[i].Win = team.Win
+			}
+		}
 	}
 
 	for _, participant := range apiResponse.Info.Participants {
@@ -593,6 +691,15 @@ func GetMatchByID(matchId string) (*match.Match, error) {
 			Spells: match.Spells{
 				SpellIDs: []int{participant.Spell1ID, participant.Spell2ID},
 			},
+			Stats: match.Stats{
+				Kills:       participant.Kills,
+				Deaths:      participant.Deaths,
+				Assists:     participant.Assists,
+				CreepScore:  participant.MinionsKilled + participant.NeutralKilled,
+				GoldEarned:  participant.GoldEarned,
+				DamageDealt: participant.ChampionDamage,
+				Placement:   participant.Placement,
+			},
 		})
 	}
 
@@ -600,7 +707,31 @@ func GetMatchByID(matchId string) (*match.Match, error) {
 	return matchData, nil
 }
 
-func GetOngoingMatchByPUUID(puuid, region string) (*match.Match, error) {
+// activeGame is the spectator-v5 representation of a running game
+type activeGame struct {
+	GameID          int64  `json:"gameId"`
+	QueueID         int    `json:"gameQueueConfigId"`
+	GameMode        string `json:"gameMode"`
+	GameStartTime   int64  `json:"gameStartTime"`
+	BannedChampions []struct {
+		ChampionID int `json:"championId"`
+		TeamID     int `json:"teamId"`
+		PickTurn   int `json:"pickTurn"`
+	} `json:"bannedChampions"`
+	Participants []struct {
+		PUUID      string      `json:"puuid"`
+		RiotID     string      `json:"riotId"`
+		TeamID     int         `json:"teamId"`
+		ChampionID int         `json:"championId"`
+		Perks      match.Perks `json:"perks"`
+		SummonerID string      `json:"summonerId"`
+		Spell1ID   int         `json:"spell1Id"`
+		Spell2ID   int         `json:"spell2Id"`
+	} `json:"participants"`
+}
+
+// getActiveGame fetches the running game of a summoner, nil if the summoner is not in game
+func getActiveGame(puuid, region string) (*activeGame, error) {
 	apiKey := os.Getenv("RIOT_API_TOKEN")
 	if apiKey == "" {
 		return nil, fmt.Errorf("API token not found in environment variables")
@@ -613,31 +744,18 @@ func GetOngoingMatchByPUUID(puuid, region string) (*match.Match, error) {
 
 	url := fmt.Sprintf("%s/lol/spectator/v5/active-games/by-summoner/%s?api_key=%s", baseUrl, puuid, apiKey)
 	resp, err := makeRequest(url)
+	if errors.Is(err, ErrNotFound) {
+		// No ongoing match found
+		return nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request to Riot Games API: %v", err)
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		// No ongoing match found
-		return nil, nil
-	} else if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected response status: %v", resp.Status)
 	}
 
-	var apiResponse struct {
-		GameID       int64 `json:"gameId"`
-		QueueID      int   `json:"gameQueueConfigId"`
-		Participants []struct {
-			PUUID      string      `json:"puuid"`
-			TeamID     int         `json:"teamId"`
-			ChampionID int         `json:"championId"`
-			Perks      match.Perks `json:"perks"`
-			SummonerID string      `json:"summonerId"`
-			Spell1ID   int         `json:"spell1Id"`
-			Spell2ID   int         `json:"spell2Id"`
-		} `json:"participants"`
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %v", err)
@@ -645,29 +763,48 @@ func GetOngoingMatchByPUUID(puuid, region string) (*match.Match, error) {
 
 	defer resp.Body.Close()
 
+	var apiResponse activeGame
 	err = json.Unmarshal(body, &apiResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response body: %v", err)
 	}
 
-	gameType := "Flex"
-	if apiResponse.QueueID == 440 {
-		gameType = "Flex"
-	} else if apiResponse.QueueID == 420 {
-		gameType = "Solo/Duo"
-	} else {
-		gameType = "UNRANKED"
-	}
-
-	gameIDStr := fmt.Sprintf("%d", apiResponse.GameID)
+	return &apiResponse, nil
+}
 
+// newMatchFromActiveGame creates a match without participants from a running game
+func newMatchFromActiveGame(game *activeGame) *match.Match {
 	ongoingMatch := &match.Match{
-		GameID:   gameIDStr,
+		GameID:   fmt.Sprintf("%d", game.GameID),
 		Teams:    [2]match.Team{{TeamID: 100}, {TeamID: 200}},
-		GameType: gameType,
+		Queue:    assethelper.GetQueue(game.QueueID),
+		GameMode: game.GameMode,
+	}
+
+	if game.GameStartTime > 0 {
+		ongoingMatch.GameStart = time.UnixMilli(game.GameStartTime)
 	}
 
-	gameIsKnown, err := databaseHelper.IsMatchExists(gameIDStr)
+	for _, ban := range game.BannedChampions {
+		ongoingMatch.Bans = append(ongoingMatch.Bans, match.Ban{
+			ChampionID: ban.ChampionID,
+			TeamID:     ban.TeamID,
+			PickTurn:   ban.PickTurn,
+		})
+	}
+
+	return ongoingMatch
+}
+
+func GetOngoingMatchByPUUID(puuid, region string) (*match.Match, error) {
+	apiResponse, err := getActiveGame(puuid, region)
+	if err != nil || apiResponse == nil {
+		return nil, err
+	}
+
+	ongoingMatch := newMatchFromActiveGame(apiResponse)
+
+	gameIsKnown, err := databaseHelper.IsMatchExists(ongoingMatch.GameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check if match exists: %v", err)
 	}
@@ -689,21 +826,31 @@ func GetOngoingMatchByPUUID(puuid, region string) (*match.Match, error) {
 		}
 
 		if summoner == nil {
-			summoner, err = GetSummonerByPUUID(participant.PUUID, region)
+			// The ranks are taken from the league entries below, so they are not fetched twice
+			name, tag, _ := strings.Cut(participant.RiotID, "#")
+			summoner, err = getUnrankedSummonerByPUUID(participant.PUUID, region, name, tag)
 			if err != nil {
 				logger.Logger.Error("failed to fetch summoner by PUUID", zap.Error(err)) // Updated code
 				continue
-			} else {
-				databaseHelper.SaveSummonerToDB(*summoner)
 			}
+		}
+
+		// The league entries update the ranks and carry the win rates shown on the match start image
+		var soloEntry *league.Entry
+		entries, err := GetLeagueEntries(summoner.ID, region)
+		if err != nil {
+			logger.Logger.Error("failed to get new summoner rank", zap.Error(err)) // Updated code
 		} else {
-			summoner.SoloRank, summoner.FlexRank, err = GetSummonerRank(summoner.ID, region)
-			if err != nil {
-				logger.Logger.Error("failed to get new summoner rank", zap.Error(err)) // Updated code
-			} else {
-				databaseHelper.SaveSummonerToDB(*summoner)
+			soloEntry = league.FindEntry(entries, league.QueueSolo)
+			summoner.SoloRank, summoner.FlexRank = 0, 0
+			if soloEntry != nil {
+				summoner.SoloRank = soloEntry.Rank()
+			}
+			if flexEntry := league.FindEntry(entries, league.QueueFlex); flexEntry != nil {
+				summoner.FlexRank = flexEntry.Rank()
 			}
 		}
+		databaseHelper.SaveSummonerToDB(*summoner)
 
 		logger.Logger.Info("Summoner", zap.Any("summoner", summoner)) // Updated code
 
@@ -715,12 +862,77 @@ func GetOngoingMatchByPUUID(puuid, region string) (*match.Match, error) {
 			Spells: match.Spells{
 				SpellIDs: []int{participant.Spell1ID, participant.Spell2ID},
 			},
+			SoloEntry: soloEntry,
 		})
 	}
 
 	return ongoingMatch, nil
 }
 
+// GetActiveGameByPUUID fetches the running game of any summoner including the solo league entries of all participants.
+// Unlike GetOngoingMatchByPUUID it neither checks nor writes the database, so it can be used for untracked summoners.
+// Returns nil if the summoner is not in game.
+func GetActiveGameByPUUID(puuid, region string) (*match.Match, error) {
+	apiResponse, err := getActiveGame(puuid, region)
+	if err != nil || apiResponse == nil {
+		return nil, err
+	}
+
+	liveMatch := newMatchFromActiveGame(apiResponse)
+
+	for _, participant := range apiResponse.Participants {
+		teamIndex := 0
+		if participant.TeamID == 200 {
+			teamIndex = 1
+		}
+
+		name, tag, _ := strings.Cut(participant.RiotID, "#")
+		var liveSummoner summoner.Summoner
+
+		knownSummoner, _ := databaseHelper.GetSummonerByPUUIDFromDB(participant.PUUID)
+		if knownSummoner != nil {
+			liveSummoner = *knownSummoner
+		} else {
+			summonerData, err := getSummonerData(participant.PUUID, region)
+			if err != nil {
+				logger.Logger.Error("failed to fetch summoner data", zap.String("PUUID", participant.PUUID), zap.Error(err))
+				continue
+			}
+			liveSummoner = *summoner.NewSummoner(name, tag, summonerData.AccountID, summonerData.ID, participant.PUUID, summonerData.ProfileIconID, 0, 0, time.Now(), region)
+		}
+		if name != "" {
+			liveSummoner.Name = name
+			liveSummoner.TagLine = tag
+		}
+
+		liveParticipant := match.Participant{
+			Summoner:   liveSummoner,
+			Perks:      participant.Perks,
+			ChampionID: participant.ChampionID,
+			Spells: match.Spells{
+				SpellIDs: []int{participant.Spell1ID, participant.Spell2ID},
+			},
+		}
+
+		entries, err := GetLeagueEntries(liveSummoner.ID, region)
+		if err != nil {
+			logger.Logger.Error("failed to fetch league entries", zap.String("PUUID", participant.PUUID), zap.Error(err))
+		} else {
+			liveParticipant.SoloEntry = league.FindEntry(entries, league.QueueSolo)
+			if liveParticipant.SoloEntry != nil {
+				liveParticipant.Summoner.SoloRank = liveParticipant.SoloEntry.Rank()
+			}
+			if flexEntry := league.FindEntry(entries, league.QueueFlex); flexEntry != nil {
+				liveParticipant.Summoner.FlexRank = flexEntry.Rank()
+			}
+		}
+
+		liveMatch.Teams[teamIndex].Participants = append(liveMatch.Teams[teamIndex].Participants, liveParticipant)
+	}
+
+	return liveMatch, nil
+}
+
 func GetNameTagByPUUID(puuid string) (string, string, error) {
 	err := LoadEnv()
 	if err != nil {
@@ -761,3 +973,47 @@ func GetNameTagByPUUID(puuid string) (string, string, error) {
 
 	return account.GameName, account.TagLine, nil
 }
+
+// GetActiveShard returns the platform a player currently plays League of Legends on, e.g. after a region transfer.
+// account-v1 serves the active shard of League through its region endpoint.
+func GetActiveShard(puuid string) (string, error) {
+	err := LoadEnv()
+	if err != nil {
+		return "", fmt.Errorf("error loading .env file")
+	}
+
+	apiKey := os.Getenv("RIOT_API_TOKEN")
+	if apiKey == "" {
+		return "", fmt.Errorf("API token not found in environment variables")
+	}
+
+	baseUrl, err := getBaseURL("", "EUROPE")
+	if err != nil {
+		return "", err
+	}
+
+	requestURL := fmt.Sprintf("%s/riot/account/v1/region/by-game/lol/by-puuid/%s?api_key=%s", baseUrl, puuid, apiKey)
+	resp, err := makeRequest(requestURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to make request to Riot Games API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch active shard: %s", resp.Status)
+	}
+
+	var shard struct {
+		Region string `json:"region"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&shard)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	platform := strings.ToUpper(shard.Region)
+	if _, ok := constants.Platforms[platform]; !ok {
+		return "", fmt.Errorf("unknown active shard %q", shard.Region)
+	}
+	return platform, nil
+}
@@ -0,0 +1,58 @@
+package apiHelper
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	"discord-bot/internal/app/constants"
+	"discord-bot/types/status"
+)
+
+var (
+	maintenance   = make(map[string]bool)
+	maintenanceMu sync.RWMutex
+)
+
+// GetPlatformStatus fetches the incidents and maintenances of a platform from lol-status-v4
+func GetPlatformStatus(region string) (*status.PlatformStatus, error) {
+	var platformStatus status.PlatformStatus
+	err := getJSON(region, "", "/lol/status/v4/platform-data", &platformStatus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch platform status: %w", err)
+	}
+	return &platformStatus, nil
+}
+
+// SetMaintenance records whether a maintenance of a platform is in progress.
+// Failed requests to a platform under maintenance are expected and only logged as warnings.
+func SetMaintenance(platform string, active bool) {
+	maintenanceMu.Lock()
+	defer maintenanceMu.Unlock()
+	if active {
+		maintenance[platform] = true
+	} else {
+		delete(maintenance, platform)
+	}
+}
+
+// UnderMaintenance reports whether a maintenance of a platform is in progress according to the last status poll
+func UnderMaintenance(platform string) bool {
+	maintenanceMu.RLock()
+	defer maintenanceMu.RUnlock()
+	return maintenance[platform]
+}
+
+// underMaintenanceURL reports whether a request URL is addressed to a platform under maintenance
+func underMaintenanceURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	for platform, host := range constants.Platforms {
+		if parsed.Host == host {
+			return UnderMaintenance(platform)
+		}
+	}
+	return false
+}
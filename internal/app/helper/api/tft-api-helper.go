@@ -0,0 +1,112 @@
+package apiHelper
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"discord-bot/types/league"
+	"discord-bot/types/tft"
+)
+
+// ActiveTFTGame is a running TFT game as returned by the TFT spectator endpoint
+type ActiveTFTGame struct {
+	GameID          int64 `json:"gameId"`
+	GameQueueConfig int   `json:"gameQueueConfigId"`
+	GameStartTime   int64 `json:"gameStartTime"`
+}
+
+// IsRanked reports whether the game is played in the ranked queue
+func (g *ActiveTFTGame) IsRanked() bool {
+	return g.GameQueueConfig == tft.QueueRanked
+}
+
+// GetTFTLeagueEntries fetches the TFT league entries of a summoner, the ranked one has queue type league.QueueTFT
+func GetTFTLeagueEntries(puuid, region string) ([]league.Entry, error) {
+	var entries []league.Entry
+	err := getJSON(region, "", fmt.Sprintf("/tft/league/v1/by-puuid/%s", puuid), &entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch TFT league entries: %w", err)
+	}
+	return entries, nil
+}
+
+// GetLastTFTMatchID fetches the ID of the latest TFT match of a summoner, empty if they never played
+func GetLastTFTMatchID(puuid string) (string, error) {
+	var matchIDs []string
+	err := getJSON("", "EUROPE", fmt.Sprintf("/tft/match/v1/matches/by-puuid/%s/ids?start=0&count=1", puuid), &matchIDs)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch last TFT match: %w", err)
+	}
+	if len(matchIDs) == 0 {
+		return "", nil
+	}
+	return matchIDs[0], nil
+}
+
+// GetTFTMatch fetches a finished TFT match with the final boards of all participants
+func GetTFTMatch(matchID string) (*tft.Match, error) {
+	var apiResponse struct {
+		Metadata struct {
+			MatchID string `json:"match_id"`
+		} `json:"metadata"`
+		Info struct {
+			GameDatetime int64   `json:"game_datetime"`
+			GameLength   float64 `json:"game_length"`
+			QueueID      int     `json:"queue_id"`
+			SetNumber    int     `json:"tft_set_number"`
+			Participants []struct {
+				PUUID     string `json:"puuid"`
+				Placement int    `json:"placement"`
+				Level     int    `json:"level"`
+				Traits    []struct {
+					Name     string `json:"name"`
+					NumUnits int    `json:"num_units"`
+					Style    int    `json:"style"`
+				} `json:"traits"`
+				Units []struct {
+					CharacterID string   `json:"character_id"`
+					ItemNames   []string `json:"itemNames"`
+					Rarity      int      `json:"rarity"`
+					Tier        int      `json:"tier"`
+				} `json:"units"`
+			} `json:"participants"`
+		} `json:"info"`
+	}
+	err := getJSON("", "EUROPE", fmt.Sprintf("/tft/match/v1/matches/%s", matchID), &apiResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch TFT match: %w", err)
+	}
+
+	tftMatch := &tft.Match{
+		MatchID:      apiResponse.Metadata.MatchID,
+		QueueID:      apiResponse.Info.QueueID,
+		SetNumber:    apiResponse.Info.SetNumber,
+		GameDatetime: time.UnixMilli(apiResponse.Info.GameDatetime),
+		GameLength:   time.Duration(apiResponse.Info.GameLength * float64(time.Second)),
+	}
+	for _, p := range apiResponse.Info.Participants {
+		participant := tft.Participant{PUUID: p.PUUID, Placement: p.Placement, Level: p.Level}
+		for _, trait := range p.Traits {
+			participant.Traits = append(participant.Traits, tft.Trait{Name: trait.Name, NumUnits: trait.NumUnits, Style: trait.Style})
+		}
+		for _, unit := range p.Units {
+			participant.Units = append(participant.Units, tft.Unit{CharacterID: unit.CharacterID, Tier: unit.Tier, Rarity: unit.Rarity, Items: unit.ItemNames})
+		}
+		tftMatch.Participants = append(tftMatch.Participants, participant)
+	}
+	return tftMatch, nil
+}
+
+// GetActiveTFTGame fetches the running TFT game of a summoner, nil if they are not in a TFT game
+func GetActiveTFTGame(puuid, region string) (*ActiveTFTGame, error) {
+	var game ActiveTFTGame
+	err := getJSON(region, "", fmt.Sprintf("/lol/spectator/tft/v5/active-games/by-puuid/%s", puuid), &game)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch active TFT game: %w", err)
+	}
+	return &game, nil
+}
@@ -0,0 +1,166 @@
+package assetprovider
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	assethelper "discord-bot/internal/app/helper/assets"
+	"discord-bot/internal/app/helper/cdragon"
+	"discord-bot/internal/logger"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+// Modes of providing icons to embeds, chosen with ASSET_MODE
+const (
+	// ModeCDN lets Discord load icons from CommunityDragon when a message is viewed
+	ModeCDN = "cdn"
+	// ModeAttach caches icons locally and attaches them to each message
+	ModeAttach = "attach"
+	// ModeHTTP caches icons locally and serves them from the bot's own HTTP endpoint at ASSET_BASE_URL
+	ModeHTTP = "http"
+)
+
+// Kinds of icons, also the first path segment below /assets/ in ModeHTTP
+const (
+	kindProfileIcon = "profile-icon"
+	kindChampion    = "champion"
+	kindRank        = "rank"
+)
+
+// rankTiers are the tiers with a ranked crest, including the crest shown for unranked players
+var rankTiers = map[string]bool{
+	"iron": true, "bronze": true, "silver": true, "gold": true, "platinum": true,
+	"emerald": true, "diamond": true, "master": true, "grandmaster": true, "challenger": true, "unranked": true,
+}
+
+// Mode returns the configured asset mode, ModeCDN if ASSET_MODE is unset or unknown
+func Mode() string {
+	switch mode := strings.ToLower(os.Getenv("ASSET_MODE")); mode {
+	case ModeAttach, ModeHTTP:
+		return mode
+	default:
+		return ModeCDN
+	}
+}
+
+// baseURL is the public address of the HTTP endpoint without trailing slash, empty if it is not configured
+func baseURL() string {
+	return strings.TrimRight(os.Getenv("ASSET_BASE_URL"), "/")
+}
+
+// Asset is an icon that can be shown in an embed
+type Asset struct {
+	kind     string
+	key      string
+	cacheDir string
+	cdnURL   string
+	open     func() (*os.File, error)
+}
+
+// ProfileIcon returns the asset of a summoner profile icon
+func ProfileIcon(profileIconID int) Asset {
+	return Asset{
+		kind:     kindProfileIcon,
+		key:      strconv.Itoa(profileIconID),
+		cacheDir: assethelper.ProfileIconCacheDir,
+		cdnURL:   cdragon.GetProfileIconURL(profileIconID),
+		open:     func() (*os.File, error) { return assethelper.GetProfileIconFile(profileIconID) },
+	}
+}
+
+// ChampionSquare returns the asset of a square champion portrait
+func ChampionSquare(championID int) Asset {
+	return Asset{
+		kind:     kindChampion,
+		key:      strconv.Itoa(championID),
+		cacheDir: assethelper.ChampionCacheDir,
+		cdnURL:   cdragon.GetChampionSquareURL(championID),
+		open:     func() (*os.File, error) { return assethelper.GetChampionSquareFile(championID) },
+	}
+}
+
+// RankCrest returns the asset of the mini crest of a tier like "GOLD" or "gold"
+func RankCrest(tier string) Asset {
+	tier = strings.ToLower(tier)
+	return Asset{
+		kind:     kindRank,
+		key:      tier,
+		cacheDir: assethelper.RankCacheDir,
+		cdnURL:   cdragon.GetRankedPictureURL(tier),
+		open:     func() (*os.File, error) { return assethelper.GetRankCrestFile(tier) },
+	}
+}
+
+// FileName is the name of the asset as a message attachment
+func (a Asset) FileName() string {
+	return fmt.Sprintf("%s-%s.png", a.kind, a.key)
+}
+
+// URL returns the address of an asset for messages that cannot carry attachments.
+// Outside of ModeCDN the bot's HTTP endpoint is used if ASSET_BASE_URL is set, otherwise CommunityDragon.
+func URL(a Asset) string {
+	if Mode() != ModeCDN {
+		if base := baseURL(); base != "" {
+			issue(a)
+			return fmt.Sprintf("%s/assets/%s/%s.png", base, a.kind, a.key)
+		}
+	}
+	return a.cdnURL
+}
+
+// Attachments collects the icons of one message. In ModeAttach every icon is attached once,
+// in the other modes no files are added and URL behaves like the package level URL.
+type Attachments struct {
+	mode  string
+	files []*discordgo.File
+	names map[string]bool
+}
+
+// NewAttachments returns an empty collection for one message using the configured mode
+func NewAttachments() *Attachments {
+	return &Attachments{mode: Mode(), names: make(map[string]bool)}
+}
+
+// URL returns the address of an asset in the message, attaching it if needed.
+// Icons that cannot be loaded fall back to CommunityDragon, so a missing icon never blocks a message.
+func (at *Attachments) URL(a Asset) string {
+	if at.mode != ModeAttach {
+		return URL(a)
+	}
+
+	name := a.FileName()
+	if at.names[name] {
+		return "attachment://" + name
+	}
+
+	file, err := a.open()
+	if err != nil {
+		logger.Logger.Warn("Failed to load asset, using CDN", zap.String("asset", name), zap.Error(err))
+		return a.cdnURL
+	}
+	data, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		logger.Logger.Warn("Failed to read asset, using CDN", zap.String("asset", name), zap.Error(err))
+		return a.cdnURL
+	}
+
+	at.files = append(at.files, &discordgo.File{Name: name, ContentType: "image/png", Reader: bytes.NewReader(data)})
+	at.names[name] = true
+	return "attachment://" + name
+}
+
+// Files returns the files to attach to the message
+func (at *Attachments) Files() []*discordgo.File {
+	return at.files
+}
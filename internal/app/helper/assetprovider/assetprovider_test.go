@@ -0,0 +1,95 @@
+package assetprovider
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestURL(t *testing.T) {
+	asset := ProfileIcon(29)
+
+	t.Setenv("ASSET_MODE", "")
+	if got := URL(asset); got != "https://cdn.communitydragon.org/latest/profile-icon/29" {
+		t.Errorf("expected CDN URL, got %s", got)
+	}
+
+	t.Setenv("ASSET_MODE", ModeHTTP)
+	t.Setenv("ASSET_BASE_URL", "https://bot.example.com/")
+	if got := URL(asset); got != "https://bot.example.com/assets/profile-icon/29.png" {
+		t.Errorf("expected local URL, got %s", got)
+	}
+
+	t.Setenv("ASSET_BASE_URL", "")
+	if got := URL(asset); !strings.HasPrefix(got, "https://cdn.communitydragon.org/") {
+		t.Errorf("expected CDN URL without a base URL, got %s", got)
+	}
+}
+
+func TestAttachments(t *testing.T) {
+	t.Setenv("ASSET_MODE", ModeAttach)
+
+	path := filepath.Join(t.TempDir(), "icon.png")
+	if err := os.WriteFile(path, []byte("png"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	asset := Asset{kind: kindChampion, key: "62", cdnURL: "https://cdn.example.com/62", open: func() (*os.File, error) { return os.Open(path) }}
+	missing := Asset{kind: kindChampion, key: "1", cdnURL: "https://cdn.example.com/1", open: func() (*os.File, error) { return nil, errors.New("offline") }}
+
+	attachments := NewAttachments()
+	if got := attachments.URL(asset); got != "attachment://champion-62.png" {
+		t.Errorf("expected attachment URL, got %s", got)
+	}
+	attachments.URL(asset)
+	if got := attachments.URL(missing); got != "https://cdn.example.com/1" {
+		t.Errorf("expected CDN fallback for a missing asset, got %s", got)
+	}
+	if files := attachments.Files(); len(files) != 1 || files[0].Name != "champion-62.png" {
+		t.Errorf("expected the asset to be attached once, got %+v", files)
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	valid := map[string]string{
+		"/assets/profile-icon/29.png": "profile-icon-29.png",
+		"/assets/champion/62.png":     "champion-62.png",
+		"/assets/rank/gold.png":       "rank-gold.png",
+	}
+	for path, expected := range valid {
+		asset, ok := parsePath(path)
+		if !ok || asset.FileName() != expected {
+			t.Errorf("parsePath(%s): expected %s, got %+v", path, expected, asset)
+		}
+	}
+
+	for _, path := range []string{"/assets/rank/../../etc/passwd.png", "/assets/champion/abc.png", "/assets/other/1.png", "/assets/rank/gold", "/assets/champion/-1.png"} {
+		if _, ok := parsePath(path); ok {
+			t.Errorf("parsePath(%s): expected to be rejected", path)
+		}
+	}
+}
+
+func TestHandlerRejectsUnknownAssets(t *testing.T) {
+	t.Setenv("ASSET_MODE", ModeHTTP)
+	t.Setenv("ASSET_BASE_URL", "https://bot.example.com")
+
+	// Profile icons that the bot never linked to are not downloaded on request
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/assets/profile-icon/987654321.png", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown profile icon, got %d", recorder.Code)
+	}
+
+	asset := ProfileIcon(987654321)
+	if asset.known() {
+		t.Error("expected the profile icon to be unknown before its URL was handed out")
+	}
+	URL(asset)
+	if !asset.known() {
+		t.Error("expected the profile icon to be known once its URL was handed out")
+	}
+}
@@ -0,0 +1,155 @@
+package assetprovider
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	assethelper "discord-bot/internal/app/helper/assets"
+	"discord-bot/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+// defaultAddr is where the HTTP endpoint listens unless ASSET_HTTP_ADDR is set
+const defaultAddr = ":8080"
+
+// maxCachedAssets caps the number of icons per kind the endpoint downloads into the asset cache,
+// and the number of URLs handed out in messages that are remembered
+const maxCachedAssets = 10000
+
+var (
+	issuedMu sync.Mutex
+	issued   = make(map[string]bool)
+)
+
+// issue remembers an asset whose URL was put in a message, so the endpoint may download it
+func issue(a Asset) {
+	issuedMu.Lock()
+	defer issuedMu.Unlock()
+	if len(issued) >= maxCachedAssets {
+		issued = make(map[string]bool)
+	}
+	issued[a.FileName()] = true
+}
+
+// known reports whether the endpoint may download an asset that is not cached yet:
+// its URL was handed out by the bot, it is a champion of the static data or a ranked tier
+func (a Asset) known() bool {
+	issuedMu.Lock()
+	wasIssued := issued[a.FileName()]
+	issuedMu.Unlock()
+	if wasIssued {
+		return true
+	}
+
+	switch a.kind {
+	case kindRank:
+		return rankTiers[a.key]
+	case kindChampion:
+		id, err := strconv.Atoi(a.key)
+		if err != nil {
+			return false
+		}
+		_, ok := assethelper.GetCatalog("").Champions[id]
+		return ok
+	}
+	return false
+}
+
+// Initialize starts the HTTP endpoint serving cached icons when ASSET_MODE is http
+func Initialize() {
+	if Mode() != ModeHTTP {
+		return
+	}
+	if baseURL() == "" {
+		logger.Logger.Warn("ASSET_MODE is http but ASSET_BASE_URL is not set, embeds keep using CommunityDragon")
+	}
+
+	addr := os.Getenv("ASSET_HTTP_ADDR")
+	if addr == "" {
+		addr = defaultAddr
+	}
+	server := &http.Server{
+		Addr:         addr,
+		Handler:      Handler(),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+	go func() {
+		logger.Logger.Info("Starting asset server", zap.String("addr", addr))
+		logger.Logger.Error("Asset server stopped", zap.Error(server.ListenAndServe()))
+	}()
+}
+
+// Handler serves icons at /assets/<kind>/<key>.png. Cached icons are served as they are,
+// others are only downloaded into the asset cache if they are known and the cache of their kind is not full.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/assets/", func(w http.ResponseWriter, r *http.Request) {
+		asset, ok := parsePath(r.URL.Path)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		file, err := assethelper.OpenCachedFile(path.Join(asset.cacheDir, asset.key+".png"))
+		if err != nil {
+			if !asset.known() {
+				http.NotFound(w, r)
+				return
+			}
+			if assethelper.CachedFileCount(asset.cacheDir) >= maxCachedAssets {
+				logger.Logger.Warn("Asset cache is full", zap.String("kind", asset.kind))
+				http.Error(w, "asset unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			file, err = asset.open()
+		}
+		if err != nil {
+			logger.Logger.Warn("Failed to serve asset", zap.String("path", r.URL.Path), zap.Error(err))
+			http.Error(w, "asset unavailable", http.StatusBadGateway)
+			return
+		}
+		defer file.Close()
+
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		if _, err := io.Copy(w, file); err != nil {
+			logger.Logger.Warn("Failed to write asset", zap.String("path", r.URL.Path), zap.Error(err))
+		}
+	})
+	return mux
+}
+
+// parsePath resolves a request path to an asset, only known kinds and well-formed keys are accepted
+func parsePath(path string) (Asset, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/assets/"), "/")
+	if len(parts) != 2 || !strings.HasSuffix(parts[1], ".png") {
+		return Asset{}, false
+	}
+	key := strings.TrimSuffix(parts[1], ".png")
+
+	switch parts[0] {
+	case kindProfileIcon, kindChampion:
+		id, err := strconv.Atoi(key)
+		if err != nil || id < 0 {
+			return Asset{}, false
+		}
+		if parts[0] == kindProfileIcon {
+			return ProfileIcon(id), true
+		}
+		return ChampionSquare(id), true
+	case kindRank:
+		if !rankTiers[key] {
+			return Asset{}, false
+		}
+		return RankCrest(key), true
+	}
+	return Asset{}, false
+}
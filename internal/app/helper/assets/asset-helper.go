@@ -1,20 +1,28 @@
 package assethelper
 
 import (
+	"discord-bot/internal/app/helper/cdragon"
+	"discord-bot/internal/app/helper/staticdata"
+	"discord-bot/types/catalog"
 	"discord-bot/types/match"
-	"encoding/json"
+	"discord-bot/types/queue"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
-	"strconv"
+	"strings"
+	"time"
 
 	"discord-bot/internal/logger"
 
 	"go.uber.org/zap"
 )
 
-var runeData map[int]string
-var spellData map[int]string
+// bundledCatalog holds the static data shipped with the bot, used when the static data of a patch is unavailable
+var bundledCatalog = catalog.New()
+
+// queues holds the queues of assets/queues.json, which follows Riot's queues.json so it can be replaced by a newer copy
+var queues = queue.NewCatalog()
 
 func init() {
 	logger.InitLogger()
@@ -27,167 +35,101 @@ func init() {
 	}
 	logger.Logger.Info("Current working directory", zap.String("directory", wd))
 
-	// Use an absolute path to the runes.json file
-	filePath := filepath.Join(wd, "assets/15.1.1/jsonmaps/runes.json")
-	file, err := os.Open(filePath)
-	if err != nil {
-		logger.Logger.Error("Error opening runes.json", zap.Error(err))
-		return
-	}
-	defer file.Close()
-
-	var runes []struct {
-		ID    int `json:"id"`
-		Slots []struct {
-			Runes []struct {
-				ID   int    `json:"id"`
-				Icon string `json:"icon"`
-			} `json:"runes"`
-		} `json:"slots"`
-	}
-
-	if err := json.NewDecoder(file).Decode(&runes); err != nil {
-		logger.Logger.Error("Error decoding runes.json", zap.Error(err))
-		return
+	files := []struct {
+		name   string
+		decode func([]byte) error
+	}{
+		{"items.json", bundledCatalog.DecodeItems},
+		{"runes.json", bundledCatalog.DecodeRunes},
+		{"spells.json", bundledCatalog.DecodeSpells},
 	}
-
-	runeData = make(map[int]string)
-	for _, runeCategory := range runes {
-		for _, slot := range runeCategory.Slots {
-			for _, rune := range slot.Runes {
-				runeData[rune.ID] = rune.Icon
-			}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(wd, "assets/15.1.1/jsonmaps", file.name))
+		if err != nil {
+			logger.Logger.Error("Error opening "+file.name, zap.Error(err))
+			continue
+		}
+		if err := file.decode(data); err != nil {
+			logger.Logger.Error("Error decoding "+file.name, zap.Error(err))
 		}
 	}
 
-	// Parse spells.json
-	filePath = filepath.Join(wd, "assets/15.1.1/jsonmaps/spells.json")
-	file, err = os.Open(filePath)
+	data, err := os.ReadFile(filepath.Join(wd, "assets/queues.json"))
 	if err != nil {
-		logger.Logger.Error("Error opening spells.json", zap.Error(err))
+		logger.Logger.Error("Error opening queues.json", zap.Error(err))
 		return
 	}
-	defer file.Close()
-
-	var spells struct {
-		Data map[string]struct {
-			Key   string `json:"key"`
-			Image struct {
-				Full string `json:"full"`
-			} `json:"image"`
-		} `json:"data"`
+	if err := queues.Decode(data); err != nil {
+		logger.Logger.Error("Error decoding queues.json", zap.Error(err))
 	}
+}
 
-	if err := json.NewDecoder(file).Decode(&spells); err != nil {
-		logger.Logger.Error("Error decoding spells.json", zap.Error(err))
-		return
-	}
+// GetQueue returns the queue with the given ID, queues missing from queues.json are reported as CategoryOther
+func GetQueue(queueID int) queue.Queue {
+	return queues.Get(queueID)
+}
 
-	spellData = make(map[int]string)
-	for _, spell := range spells.Data {
-		spellID, err := strconv.Atoi(spell.Key)
-		if err != nil {
-			logger.Logger.Error("Error converting spell ID to int", zap.String("spellID", spell.Key), zap.Error(err))
-			continue
-		}
-		spellData[spellID] = spell.Image.Full
+// GetCatalog returns the champions, items, runes and spells of the patch of gameVersion, an empty gameVersion uses the latest patch.
+// If the patch cannot be loaded the bundled data is returned, which has no champions, so names fall back to IDs.
+func GetCatalog(gameVersion string) *catalog.Catalog {
+	patch, err := staticdata.Default().Patch(gameVersion)
+	if err != nil {
+		logger.Logger.Warn("Static data unavailable, using bundled catalog", zap.String("gameVersion", gameVersion), zap.Error(err))
+		return bundledCatalog
 	}
+	return patch.Catalog
 }
 
-// GetRuneIconByID searches for the rune ID in runes.json and returns the corresponding icon path.
+// GetRuneIconByID returns the path of the bundled icon of a rune or rune tree
 func GetRuneIconByID(runeID int) (string, error) {
+	r, ok := bundledCatalog.Runes[runeID]
+	if !ok {
+		return "", fmt.Errorf("rune ID %d not found", runeID)
+	}
 	wd, err := os.Getwd()
 	if err != nil {
 		return "", fmt.Errorf("error getting current working directory: %w", err)
 	}
-
-	filePath := filepath.Join(wd, "assets/15.1.1/jsonmaps/runes.json")
-	file, err := os.Open(filePath)
-	if err != nil {
-		return "", fmt.Errorf("error opening runes.json: %w", err)
-	}
-	defer file.Close()
-
-	var runes []struct {
-		ID    int    `json:"id"`
-		Icon  string `json:"icon"`
-		Slots []struct {
-			Runes []struct {
-				ID   int    `json:"id"`
-				Icon string `json:"icon"`
-			} `json:"runes"`
-		} `json:"slots"`
-	}
-
-	if err := json.NewDecoder(file).Decode(&runes); err != nil {
-		return "", fmt.Errorf("error decoding runes.json: %w", err)
-	}
-
-	for _, runeCategory := range runes {
-		if runeCategory.ID == runeID {
-			return filepath.Join(wd, "assets/15.1.1/", runeCategory.Icon), nil
-		}
-	}
-
-	for _, runeCategory := range runes {
-		for _, slot := range runeCategory.Slots {
-			for _, rune := range slot.Runes {
-				if rune.ID == runeID {
-					return filepath.Join(wd, "assets/15.1.1/", rune.Icon), nil
-				}
-			}
-		}
-	}
-
-	return "", fmt.Errorf("rune ID %d not found", runeID)
+	return filepath.Join(wd, "assets/15.1.1/", r.Icon), nil
 }
 
 // GetItemFiles takes a list of item IDs and returns a slice of os.File pointers corresponding to the assets.
-func GetItemFiles(itemIDs []int) ([]*os.File, error) {
+// Icons are resolved for the patch of gameVersion, an empty gameVersion uses the latest patch.
+// Item 0 and items without an icon are returned as the empty slot image.
+func GetItemFiles(gameVersion string, itemIDs []int) ([]*os.File, error) {
 	var files []*os.File
-	wd, err := os.Getwd()
-	if err != nil {
-		return nil, fmt.Errorf("error getting current working directory: %w", err)
-	}
-	var file *os.File
-
 	for _, itemID := range itemIDs {
+		var file *os.File
+		var err error
 		if itemID == 0 {
-			defaultImagePath := wd + "/assets/15.1.1/template/template_empty.png"
-			file, err = os.Open(defaultImagePath)
-			if err != nil {
-				return nil, fmt.Errorf("failed to open default image: %w", err)
-			}
+			file, err = GetPlaceholderFile()
 		} else {
-
-			filePath := filepath.Join(wd, "assets/15.1.1/items", fmt.Sprintf("%d.png", itemID))
-			file, err = os.Open(filePath)
-			if err != nil {
-				return nil, fmt.Errorf("failed to open file for item ID %d: %w", itemID, err)
-			}
+			file, err = versionedFile(gameVersion, "item", itemID, (*staticdata.Patch).ItemFile, func() (*os.File, error) {
+				return openBundled(filepath.Join("items", fmt.Sprintf("%d.png", itemID)))
+			})
+		}
+		if err != nil {
+			closeFiles(files)
+			return nil, fmt.Errorf("failed to open file for item ID %d: %w", itemID, err)
 		}
 		files = append(files, file)
 	}
 	return files, nil
 }
 
-// GetPerkFiles takes a list of perk IDs and returns a slice of os.File pointers corresponding to the assets.
-func GetPerkFiles(perks match.Perks) ([]*os.File, error) {
+// GetPerkFiles returns the icons of the keystone and the secondary rune tree of a rune page
+func GetPerkFiles(gameVersion string, perks match.Perks) ([]*os.File, error) {
 	var files []*os.File
 	var styleIDs []int
-	styleIDs = append(styleIDs, perks.PerkIDs[0], perks.PerkSubStyle)
+	if len(perks.PerkIDs) > 0 {
+		styleIDs = append(styleIDs, perks.PerkIDs[0])
+	}
+	styleIDs = append(styleIDs, perks.PerkSubStyle)
 
 	for _, perkID := range styleIDs {
-		iconPath, err := GetRuneIconByID(perkID)
-		if err != nil {
-			logger.Logger.Error("Error getting rune icon by ID", zap.Int("perkID", perkID), zap.Error(err))
-			continue
-		} else {
-			logger.Logger.Info("Icon path", zap.String("path", iconPath))
-		}
-
-		file, err := os.Open(iconPath)
+		file, err := GetRuneFile(gameVersion, perkID)
 		if err != nil {
+			closeFiles(files)
 			return nil, fmt.Errorf("failed to open file for perk ID %d: %w", perkID, err)
 		}
 		files = append(files, file)
@@ -195,24 +137,167 @@ func GetPerkFiles(perks match.Perks) ([]*os.File, error) {
 	return files, nil
 }
 
+// GetRuneFile returns the icon of a rune or rune tree for the patch of gameVersion
+func GetRuneFile(gameVersion string, runeID int) (*os.File, error) {
+	return versionedFile(gameVersion, "rune", runeID, (*staticdata.Patch).RuneFile, func() (*os.File, error) {
+		iconPath, err := GetRuneIconByID(runeID)
+		if err != nil {
+			return nil, err
+		}
+		return os.Open(iconPath)
+	})
+}
+
 // GetSpellFiles takes a list of spell IDs and returns a slice of os.File pointers corresponding to the assets.
-func GetSpellFiles(spellIDs []int) ([]*os.File, error) {
+// Icons are resolved for the patch of gameVersion like items.
+func GetSpellFiles(gameVersion string, spellIDs []int) ([]*os.File, error) {
 	var files []*os.File
 	for _, spellID := range spellIDs {
-		imagePath, ok := spellData[spellID]
-		if !ok {
-			return nil, fmt.Errorf("image not found for spell ID %d", spellID)
-		}
-		wd, err := os.Getwd()
-		if err != nil {
-			return nil, fmt.Errorf("error getting current working directory: %w", err)
-		}
-		filePath := filepath.Join(wd, "assets/15.1.1/spells", imagePath)
-		file, err := os.Open(filePath)
+		file, err := versionedFile(gameVersion, "spell", spellID, (*staticdata.Patch).SpellFile, func() (*os.File, error) {
+			spell, ok := bundledCatalog.Spells[spellID]
+			if !ok {
+				return nil, fmt.Errorf("image not found for spell ID %d", spellID)
+			}
+			return openBundled(filepath.Join("spells", spell.Image))
+		})
 		if err != nil {
+			closeFiles(files)
 			return nil, fmt.Errorf("failed to open file for spell ID %d: %w", spellID, err)
 		}
 		files = append(files, file)
 	}
 	return files, nil
 }
+
+// versionedFile opens an icon of the patch of gameVersion through the static data manager.
+// If the patch cannot be loaded the bundled assets are used, and IDs unknown to both get the placeholder,
+// so a single new item or rune never drops a whole notification.
+func versionedFile(gameVersion, kind string, id int, open func(*staticdata.Patch, int) (*os.File, error), bundled func() (*os.File, error)) (*os.File, error) {
+	patch, err := staticdata.Default().Patch(gameVersion)
+	if err == nil {
+		file, err := open(patch, id)
+		if err == nil {
+			return file, nil
+		}
+		logger.Logger.Warn("Failed to get icon from static data", zap.String("kind", kind), zap.Int("id", id), zap.String("version", patch.Version), zap.Error(err))
+	} else {
+		logger.Logger.Warn("Static data unavailable, using bundled assets", zap.String("gameVersion", gameVersion), zap.Error(err))
+	}
+
+	if file, err := bundled(); err == nil {
+		return file, nil
+	}
+	logger.Logger.Warn("Unknown icon, using placeholder", zap.String("kind", kind), zap.Int("id", id))
+	return GetPlaceholderFile()
+}
+
+// openBundled opens an asset shipped with the bot for patch 15.1.1
+func openBundled(name string) (*os.File, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("error getting current working directory: %w", err)
+	}
+	return os.Open(filepath.Join(wd, "assets/15.1.1", name))
+}
+
+func closeFiles(files []*os.File) {
+	for _, file := range files {
+		file.Close()
+	}
+}
+
+// cdnClient is used to download assets that are not shipped with the bot
+var cdnClient = &http.Client{Timeout: 10 * time.Second}
+
+// Directories of the disk cache below assets/cache
+const (
+	ChampionCacheDir    = "champions"
+	ProfileIconCacheDir = "profile-icons"
+	RankCacheDir        = "ranks"
+)
+
+// GetChampionSquareFile returns the square portrait of a champion.
+// Portraits are not shipped with the bot, so they are downloaded from CommunityDragon on first use and cached on disk.
+func GetChampionSquareFile(championID int) (*os.File, error) {
+	return getCachedFile(fmt.Sprintf("%s/%d.png", ChampionCacheDir, championID), cdragon.GetChampionSquareURL(championID))
+}
+
+// GetProfileIconFile returns a profile icon, downloaded and cached like champion portraits
+func GetProfileIconFile(profileIconID int) (*os.File, error) {
+	return getCachedFile(fmt.Sprintf("%s/%d.png", ProfileIconCacheDir, profileIconID), cdragon.GetProfileIconURL(profileIconID))
+}
+
+// GetRankCrestFile returns the mini crest of a ranked tier like "gold", downloaded and cached like champion portraits
+func GetRankCrestFile(tier string) (*os.File, error) {
+	tier = strings.ToLower(tier)
+	return getCachedFile(fmt.Sprintf("%s/%s.png", RankCacheDir, tier), cdragon.GetRankedPictureURL(tier))
+}
+
+// GetTFTUnitFile returns the square portrait of a TFT unit, downloaded and cached like champion portraits
+func GetTFTUnitFile(characterID string, setNumber int) (*os.File, error) {
+	return getCachedFile(fmt.Sprintf("tft/%d/%s.png", setNumber, strings.ToLower(characterID)), cdragon.GetTFTUnitSquareURL(characterID, setNumber))
+}
+
+// OpenCachedFile opens an asset of the disk cache like "champions/62.png" without downloading it
+func OpenCachedFile(name string) (*os.File, error) {
+	filePath, err := cachePath(name)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(filePath)
+}
+
+// CachedFileCount returns the number of assets in a directory of the disk cache, 0 if it does not exist yet
+func CachedFileCount(dir string) int {
+	dirPath, err := cachePath(dir)
+	if err != nil {
+		return 0
+	}
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return 0
+	}
+	return len(entries)
+}
+
+func cachePath(name string) (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("error getting current working directory: %w", err)
+	}
+	return filepath.Join(wd, "assets/cache", name), nil
+}
+
+// getCachedFile opens an asset of the disk cache, downloading it from url if it is not cached yet
+func getCachedFile(name, url string) (*os.File, error) {
+	filePath, err := cachePath(name)
+	if err != nil {
+		return nil, err
+	}
+	if file, err := os.Open(filePath); err == nil {
+		return file, nil
+	}
+
+	if err := staticdata.Download(cdnClient, url, filePath); err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	return os.Open(filePath)
+}
+
+// GetPlaceholderFile returns the empty slot image used when an asset is missing
+func GetPlaceholderFile() (*os.File, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("error getting current working directory: %w", err)
+	}
+	return os.Open(filepath.Join(wd, "assets/15.1.1/template/template_empty.png"))
+}
+
+// GetTemplateFile returns the background of the build image
+func GetTemplateFile() (*os.File, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("error getting current working directory: %w", err)
+	}
+	return os.Open(filepath.Join(wd, "assets/15.1.1/template/template.png"))
+}
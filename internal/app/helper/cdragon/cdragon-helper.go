@@ -1,8 +1,12 @@
 package cdragon
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 const baseCDNURL = "https://cdn.communitydragon.org/latest"
+const baseRawURL = "https://raw.communitydragon.org/latest/game/assets"
 const baseRankedURL = "https://raw.communitydragon.org/latest/plugins/rcp-fe-lol-static-assets/global/default/images/ranked-mini-crests"
 
 // GetProfileIconURL generates the URL for a given profile icon ID
@@ -20,4 +24,10 @@ func GetChampionSquareURL(championID int) string {
 	return fmt.Sprintf("%s/champion/%d/square", baseCDNURL, championID)
 }
 
+// GetTFTUnitSquareURL generates the URL of the square portrait of a TFT unit like "TFT13_Jinx" in the given set
+func GetTFTUnitSquareURL(characterID string, setNumber int) string {
+	id := strings.ToLower(characterID)
+	return fmt.Sprintf("%s/characters/%s/hud/%s_square.tft_set%d.png", baseRawURL, id, id, setNumber)
+}
+
 // Add more functions for other resources as needed
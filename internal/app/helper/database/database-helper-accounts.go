@@ -0,0 +1,154 @@
+package databaseHelper
+
+import (
+	"database/sql"
+	"discord-bot/types/account"
+	"discord-bot/types/rank"
+	"fmt"
+)
+
+// LinkAccount links a summoner to a Discord user, creating the user if needed
+func LinkAccount(userID, puuid string) error {
+	_, err := db.Exec(`INSERT INTO DiscordUser (UserID) VALUES ($1) ON CONFLICT (UserID) DO NOTHING`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to save discord user: %v", err)
+	}
+
+	res, err := db.Exec(`INSERT INTO DiscordUserAccount (UserID, SummonerPUUID) VALUES ($1, $2) ON CONFLICT (UserID, SummonerPUUID) DO NOTHING`, userID, puuid)
+	if err != nil {
+		return fmt.Errorf("failed to link account: %v", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("account is already linked")
+	}
+	return nil
+}
+
+// UnlinkAccount removes the link between a Discord user and a summoner
+func UnlinkAccount(userID, puuid string) error {
+	res, err := db.Exec(`DELETE FROM DiscordUserAccount WHERE UserID = $1 AND SummonerPUUID = $2`, userID, puuid)
+	if err != nil {
+		return fmt.Errorf("failed to unlink account: %v", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("account is not linked")
+	}
+	return nil
+}
+
+// GetLinkedAccounts retrieves all summoners linked to a Discord user, oldest link first
+func GetLinkedAccounts(userID string) ([]account.LinkedAccount, error) {
+	rows, err := db.Query(`
+        SELECT s.Name, s.TagLine, s.AccountID, s.ID, s.PUUID, s.ProfileIconID, s.SoloRank, s.FlexRank, s.Updated, s.Region, dua.Linked, dua.Verified
+        FROM DiscordUserAccount dua
+        JOIN Summoner s ON s.PUUID = dua.SummonerPUUID
+        WHERE dua.UserID = $1
+        ORDER BY dua.Linked
+    `, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get linked accounts: %v", err)
+	}
+	defer rows.Close()
+
+	var accounts []account.LinkedAccount
+	for rows.Next() {
+		a := account.LinkedAccount{UserID: userID}
+		var soloRank, flexRank int
+		s := &a.Summoner
+		err := rows.Scan(&s.Name, &s.TagLine, &s.AccountID, &s.ID, &s.PUUID, &s.ProfileIconID, &soloRank, &flexRank, &s.Updated, &s.Region, &a.Linked, &a.Verified)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan linked account: %v", err)
+		}
+		s.SoloRank = rank.Rank(soloRank)
+		s.FlexRank = rank.Rank(flexRank)
+		accounts = append(accounts, a)
+	}
+
+	return accounts, nil
+}
+
+// IsAccountLinked checks whether a summoner is linked to a Discord user
+func IsAccountLinked(userID, puuid string) (bool, error) {
+	var exists bool
+	err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM DiscordUserAccount WHERE UserID = $1 AND SummonerPUUID = $2)`, userID, puuid).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if account is linked: %v", err)
+	}
+	return exists, nil
+}
+
+// SetAccountVerified marks the link between a Discord user and a summoner as verified
+func SetAccountVerified(userID, puuid string) error {
+	res, err := db.Exec(`UPDATE DiscordUserAccount SET Verified = TRUE, VerifiedAt = NOW() WHERE UserID = $1 AND SummonerPUUID = $2`, userID, puuid)
+	if err != nil {
+		return fmt.Errorf("failed to verify account: %v", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("account is not linked")
+	}
+	return nil
+}
+
+// GetDiscordUser retrieves the preferences of a Discord user, nil if the user never linked an account
+func GetDiscordUser(userID string) (*account.DiscordUser, error) {
+	u := account.DiscordUser{UserID: userID}
+	err := db.QueryRow(`SELECT MentionOptIn FROM DiscordUser WHERE UserID = $1`, userID).Scan(&u.MentionOptIn)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get discord user: %v", err)
+	}
+	return &u, nil
+}
+
+// SetMentionOptIn stores whether a Discord user wants to be mentioned in notifications
+func SetMentionOptIn(userID string, optIn bool) error {
+	_, err := db.Exec(`
+        INSERT INTO DiscordUser (UserID, MentionOptIn) VALUES ($1, $2)
+        ON CONFLICT (UserID) DO UPDATE SET MentionOptIn = EXCLUDED.MentionOptIn
+    `, userID, optIn)
+	if err != nil {
+		return fmt.Errorf("failed to set mention opt-in: %v", err)
+	}
+	return nil
+}
+
+// GetMentionUsersForSummoner retrieves the Discord users who linked a summoner and opted in to mentions
+func GetMentionUsersForSummoner(puuid string) ([]string, error) {
+	rows, err := db.Query(`
+        SELECT du.UserID
+        FROM DiscordUserAccount dua
+        JOIN DiscordUser du ON du.UserID = dua.UserID
+        WHERE dua.SummonerPUUID = $1 AND du.MentionOptIn
+        ORDER BY du.UserID
+    `, puuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mention users for summoner: %v", err)
+	}
+	defer rows.Close()
+
+	var users []string
+	for rows.Next() {
+		var userID string
+		err := rows.Scan(&userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %v", err)
+		}
+		users = append(users, userID)
+	}
+
+	return users, nil
+}
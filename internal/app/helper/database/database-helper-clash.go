@@ -0,0 +1,50 @@
+package databaseHelper
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// IsClashCheckDue reports whether the Clash registration of a summoner was not checked within interval
+func IsClashCheckDue(puuid string, interval time.Duration) (bool, error) {
+	var checked sql.NullTime
+	err := db.QueryRow(`SELECT ClashChecked FROM Summoner WHERE PUUID = $1`, puuid).Scan(&checked)
+	if err != nil {
+		return false, fmt.Errorf("failed to get Clash check time: %v", err)
+	}
+	return !checked.Valid || time.Since(checked.Time) >= interval, nil
+}
+
+// SetClashChecked stores that the Clash registration of a summoner was just checked
+func SetClashChecked(puuid string) error {
+	_, err := db.Exec(`UPDATE Summoner SET ClashChecked = CURRENT_TIMESTAMP WHERE PUUID = $1`, puuid)
+	if err != nil {
+		return fmt.Errorf("failed to set Clash check time: %v", err)
+	}
+	return nil
+}
+
+// MarkClashAnnounced remembers that a Clash announcement was posted in a channel.
+// It returns false if the announcement was already posted, so every team is announced once per channel
+// even if several of its members are tracked.
+func MarkClashAnnounced(channelID, key string) (bool, error) {
+	res, err := db.Exec(`INSERT INTO ClashAnnouncement (ChannelID, AnnouncementKey) VALUES ($1, $2) ON CONFLICT DO NOTHING`, channelID, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark Clash announcement: %v", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// DeleteClashAnnouncementsForChannel removes the remembered Clash announcements of a channel
+func DeleteClashAnnouncementsForChannel(channelID string) error {
+	_, err := db.Exec(`DELETE FROM ClashAnnouncement WHERE ChannelID = $1`, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to delete Clash announcements: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package databaseHelper
+
+import (
+	"database/sql"
+	"discord-bot/types/digest"
+	"discord-bot/types/history"
+	"discord-bot/types/rank"
+	"fmt"
+	"time"
+)
+
+// SaveRankChange stores a rank change of a summoner after a ranked game
+func SaveRankChange(change history.RankChange) error {
+	_, err := db.Exec(`
+        INSERT INTO RankHistory (SummonerPUUID, GameID, QueueType, ChampionID, OldRank, NewRank, Recorded)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `, change.SummonerPUUID, change.GameID, change.QueueType, change.ChampionID, int(change.OldRank), int(change.NewRank), change.Recorded)
+	if err != nil {
+		return fmt.Errorf("failed to save rank change: %v", err)
+	}
+	return nil
+}
+
+// GetRankChangesForChannel retrieves all rank changes of summoners mapped to a channel within [from, to), oldest first
+func GetRankChangesForChannel(channelID string, from, to time.Time) ([]history.RankChange, error) {
+	rows, err := db.Query(`
+        SELECT rh.SummonerPUUID, s.Name, s.TagLine, rh.GameID, rh.QueueType, rh.ChampionID, rh.OldRank, rh.NewRank, rh.Recorded
+        FROM RankHistory rh
+        JOIN SummonerChannel sc ON sc.SummonerPUUID = rh.SummonerPUUID
+        JOIN Summoner s ON s.PUUID = rh.SummonerPUUID
+        WHERE sc.ChannelID = $1 AND rh.Recorded >= $2 AND rh.Recorded < $3
+        ORDER BY rh.Recorded ASC
+    `, channelID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rank changes: %v", err)
+	}
+	defer rows.Close()
+
+	var changes []history.RankChange
+	for rows.Next() {
+		var c history.RankChange
+		var name, tagLine string
+		var oldRank, newRank int
+		err := rows.Scan(&c.SummonerPUUID, &name, &tagLine, &c.GameID, &c.QueueType, &c.ChampionID, &oldRank, &newRank, &c.Recorded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rank change: %v", err)
+		}
+		c.NameTag = name + "#" + tagLine
+		c.OldRank = rank.Rank(oldRank)
+		c.NewRank = rank.Rank(newRank)
+		changes = append(changes, c)
+	}
+
+	return changes, nil
+}
+
+// SaveDigestSchedule creates or replaces the digest schedule of a channel for the schedule's period
+func SaveDigestSchedule(schedule digest.Schedule) error {
+	_, err := db.Exec(`
+        INSERT INTO DigestSchedule (ChannelID, GuildID, Period, CronExpression, Timezone, Content, Created)
+        VALUES ($1, $2, $3, $4, $5, $6, NOW())
+        ON CONFLICT (ChannelID, Period) DO UPDATE SET
+            CronExpression = EXCLUDED.CronExpression,
+            Timezone = EXCLUDED.Timezone,
+            Content = EXCLUDED.Content,
+            Created = EXCLUDED.Created,
+            LastRun = NULL
+    `, schedule.ChannelID, schedule.GuildID, schedule.Period, schedule.CronExpression, schedule.Timezone, schedule.ContentString())
+	if err != nil {
+		return fmt.Errorf("failed to save digest schedule: %v", err)
+	}
+	return nil
+}
+
+// DeleteDigestSchedule removes the digest schedule of a channel for a period
+func DeleteDigestSchedule(channelID, period string) error {
+	res, err := db.Exec(`DELETE FROM DigestSchedule WHERE ChannelID = $1 AND Period = $2`, channelID, period)
+	if err != nil {
+		return fmt.Errorf("failed to delete digest schedule: %v", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no %s digest scheduled for channel %s", period, channelID)
+	}
+	return nil
+}
+
+// GetDigestSchedules retrieves all digest schedules, optionally filtered by channel
+func GetDigestSchedules(channelID ...string) ([]digest.Schedule, error) {
+	query := `SELECT ChannelID, GuildID, Period, CronExpression, Timezone, Content, Created, LastRun FROM DigestSchedule`
+	var args []interface{}
+	if len(channelID) > 0 {
+		query += ` WHERE ChannelID = $1`
+		args = append(args, channelID[0])
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query digest schedules: %v", err)
+	}
+	defer rows.Close()
+
+	var schedules []digest.Schedule
+	for rows.Next() {
+		var s digest.Schedule
+		var content string
+		var lastRun sql.NullTime
+		err := rows.Scan(&s.ChannelID, &s.GuildID, &s.Period, &s.CronExpression, &s.Timezone, &content, &s.Created, &lastRun)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan digest schedule: %v", err)
+		}
+		s.Content = digest.ParseContent(content)
+		if lastRun.Valid {
+			s.LastRun = &lastRun.Time
+		}
+		schedules = append(schedules, s)
+	}
+
+	return schedules, nil
+}
+
+// UpdateDigestLastRun sets the time a digest schedule was last executed
+func UpdateDigestLastRun(channelID, period string, lastRun time.Time) error {
+	_, err := db.Exec(`UPDATE DigestSchedule SET LastRun = $1 WHERE ChannelID = $2 AND Period = $3`, lastRun, channelID, period)
+	if err != nil {
+		return fmt.Errorf("failed to update digest last run: %v", err)
+	}
+	return nil
+}
+
+// DeleteDigestSchedulesForChannel removes all digest schedules of a channel
+func DeleteDigestSchedulesForChannel(channelID string) error {
+	_, err := db.Exec(`DELETE FROM DigestSchedule WHERE ChannelID = $1`, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to delete digest schedules for channel: %v", err)
+	}
+	return nil
+}
+
+// DeleteDigestSchedulesForGuild removes all digest schedules of a guild
+func DeleteDigestSchedulesForGuild(guildID string) error {
+	_, err := db.Exec(`DELETE FROM DigestSchedule WHERE GuildID = $1`, guildID)
+	if err != nil {
+		return fmt.Errorf("failed to delete digest schedules for guild: %v", err)
+	}
+	return nil
+}
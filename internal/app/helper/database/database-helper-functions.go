@@ -3,13 +3,16 @@ package databaseHelper
 import (
 	"database/sql"
 	"discord-bot/types/match"
+	"discord-bot/types/queue"
 	"discord-bot/types/rank"
 	"discord-bot/types/summoner"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
+	assethelper "discord-bot/internal/app/helper/assets"
 	"discord-bot/internal/logger"
 
 	_ "github.com/lib/pq"
@@ -19,6 +22,9 @@ import (
 
 var db *sql.DB
 
+// ErrAlreadyMapped is returned by SaveChannelForSummoner when the summoner is already tracked in the channel
+var ErrAlreadyMapped = errors.New("summoner is already tracked in this channel")
+
 // InitDB initializes the database connection with a connection pool
 func InitDB() error {
 	var err error
@@ -140,13 +146,20 @@ func LoadSummonersFromDB() (map[string]*summoner.Summoner, error) {
 	return summoners, nil
 }
 
-// GetDBSummonerByName retrieves a Summoner instance by name, tag, and region from the database
+// GetDBSummonerByName retrieves a Summoner instance by name and tag from the database, falling back to previous Riot IDs after a rename
 func GetDBSummonerByName(name, tag string) (*summoner.Summoner, error) {
 	var s summoner.Summoner
 	var soloRank, flexRank int
 	err := db.QueryRow(`SELECT Name, TagLine, AccountID, ID, PUUID, ProfileIconID, SoloRank, FlexRank, Updated, Region FROM Summoner WHERE LOWER(Name) = LOWER($1) AND LOWER(TagLine) = LOWER($2)`, name, tag).Scan(&s.Name, &s.TagLine, &s.AccountID, &s.ID, &s.PUUID, &s.ProfileIconID, &soloRank, &flexRank, &s.Updated, &s.Region)
 	if err != nil {
 		if err == sql.ErrNoRows {
+			previous, err := GetSummonerByPreviousName(name, tag)
+			if err == nil {
+				return previous, nil
+			}
+			if err != sql.ErrNoRows {
+				return nil, fmt.Errorf("failed to get summoner by previous name: %v", err)
+			}
 			return nil, fmt.Errorf("summoner with name %s, tag %s not found", name, tag)
 		}
 		return nil, fmt.Errorf("failed to get summoner by name, tag, and region: %v", err)
@@ -173,7 +186,7 @@ func GetSummonerByPUUIDFromDB(puuid string) (*summoner.Summoner, error) {
 	return &s, nil
 }
 
-// SaveChannelForSummoner saves a channel for a summoner by their PUUID
+// SaveChannelForSummoner saves a channel for a summoner by their PUUID, it returns ErrAlreadyMapped if the mapping exists
 func SaveChannelForSummoner(puuid, channel, guildID string) error {
 	res, err := db.Exec(`INSERT INTO SummonerChannel (SummonerPUUID, ChannelID, GuildID) VALUES ($1, $2, $3) ON CONFLICT (SummonerPUUID, ChannelID) DO NOTHING`, puuid, channel, guildID)
 	if err != nil {
@@ -184,7 +197,7 @@ func SaveChannelForSummoner(puuid, channel, guildID string) error {
 		return fmt.Errorf("failed to get rows affected: %v", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("channel for summoner already exists")
+		return ErrAlreadyMapped
 	}
 	return nil
 }
@@ -281,11 +294,12 @@ func SaveOngoingMatchToDB(ongoingMatch *match.Match) error {
 	}
 
 	_, err = tx.Exec(`
-        INSERT INTO Match (GameID, GameType)
-        VALUES ($1, $2)
+        INSERT INTO Match (GameID, GameType, QueueID)
+        VALUES ($1, $2, $3)
         ON CONFLICT (GameID) DO UPDATE SET
-            GameType = EXCLUDED.GameType
-    `, ongoingMatch.GameID, ongoingMatch.GameType)
+            GameType = EXCLUDED.GameType,
+            QueueID = EXCLUDED.QueueID
+    `, ongoingMatch.GameID, ongoingMatch.Queue.Name(), ongoingMatch.Queue.ID)
 	if err != nil {
 		tx.Rollback()
 		return fmt.Errorf("failed to save ongoing match: %v", err)
@@ -329,7 +343,7 @@ func SaveOngoingMatchToDB(ongoingMatch *match.Match) error {
 
 // LoadOngoingMatchFromDB loads an array of Matches instances from the database
 func LoadOngoingMatchFromDB() (map[string]*match.Match, error) {
-	rows, err := db.Query(`SELECT GameID, GameType FROM Match`)
+	rows, err := db.Query(`SELECT GameID, QueueID FROM Match`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query ongoing matches: %v", err)
 	}
@@ -338,10 +352,12 @@ func LoadOngoingMatchFromDB() (map[string]*match.Match, error) {
 	ongoingMatches := make(map[string]*match.Match)
 	for rows.Next() {
 		var m match.Match
-		err := rows.Scan(&m.GameID, &m.GameType)
+		var queueID int
+		err := rows.Scan(&m.GameID, &queueID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan ongoing match: %v", err)
 		}
+		m.Queue = assethelper.GetQueue(queueID)
 
 		// Load participants for the match
 		participantRows, err := db.Query(`SELECT SummonerPUUID, ChampionID, TeamID, Perks, Spells FROM Participant WHERE GameID = $1`, m.GameID)
@@ -383,10 +399,10 @@ func LoadOngoingMatchFromDB() (map[string]*match.Match, error) {
 	return ongoingMatches, nil
 }
 
-// SummonerExists checks if a summoner with the given name, tag, and region already exists
+// SummonerExists checks if a summoner with the given name, tag, and region already exists, ignoring the case of the Riot ID
 func SummonerExists(name, tag, region string) (bool, error) {
 	var exists bool
-	err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM Summoner WHERE Name = $1 AND TagLine = $2 AND Region = $3)`, name, tag, region).Scan(&exists)
+	err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM Summoner WHERE LOWER(Name) = LOWER($1) AND LOWER(TagLine) = LOWER($2) AND Region = $3)`, name, tag, region).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check if summoner exists: %v", err)
 	}
@@ -504,10 +520,10 @@ func UpdateOngoingToFinishedGame(oldGameID string, newMatch *match.Match) error
 	// Update the Match table
 	query := `
         UPDATE Match
-        SET GameID = $1, GameType = $2
-        WHERE GameID = $3
+        SET GameID = $1, GameType = $2, QueueID = $3
+        WHERE GameID = $4
     `
-	_, err = tx.Exec(query, newMatch.GameID, newMatch.GameType, oldGameID)
+	_, err = tx.Exec(query, newMatch.GameID, newMatch.Queue.Name(), newMatch.Queue.ID, oldGameID)
 	if err != nil {
 		tx.Rollback()
 		return fmt.Errorf("failed to update match with old GameID %s: %v", oldGameID, err)
@@ -545,3 +561,74 @@ func UpdateOngoingToFinishedGame(oldGameID string, newMatch *match.Match) error
 
 	return nil
 }
+
+// DeleteStaleOngoingNonRankedGames deletes the stored running non-ranked games of a summoner that started more than maxAge ago.
+// Their result never became available, e.g. for custom games, so they are not looked up again.
+func DeleteStaleOngoingNonRankedGames(puuid string, maxAge time.Duration) (int, error) {
+	rows, err := db.Query(`
+        SELECT m.GameID
+        FROM Match m
+        JOIN Participant p ON p.GameID = m.GameID
+        WHERE p.SummonerPUUID = $1 AND m.QueueID NOT IN ($2, $3) AND POSITION('_' IN m.GameID) = 0 AND m.Started < $4
+    `, puuid, queue.RankedSoloID, queue.RankedFlexID, time.Now().Add(-maxAge))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stale ongoing games: %v", err)
+	}
+	var gameIDs []string
+	for rows.Next() {
+		var gameID string
+		if err := rows.Scan(&gameID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan game ID: %v", err)
+		}
+		gameIDs = append(gameIDs, gameID)
+	}
+	rows.Close()
+	if len(gameIDs) == 0 {
+		return 0, nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	for _, gameID := range gameIDs {
+		if _, err := tx.Exec(`DELETE FROM Participant WHERE GameID = $1`, gameID); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("failed to delete participants of stale game %s: %v", gameID, err)
+		}
+		if _, err := tx.Exec(`DELETE FROM Match WHERE GameID = $1`, gameID); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("failed to delete stale game %s: %v", gameID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return len(gameIDs), nil
+}
+
+// GetOngoingNonRankedGameIDs retrieves the IDs of the stored running games of a summoner that are not played in a ranked queue
+func GetOngoingNonRankedGameIDs(puuid string) ([]string, error) {
+	// Finished games are stored with their platform prefix like "EUW1_", running ones without it
+	rows, err := db.Query(`
+        SELECT m.GameID
+        FROM Match m
+        JOIN Participant p ON p.GameID = m.GameID
+        WHERE p.SummonerPUUID = $1 AND m.QueueID NOT IN ($2, $3) AND POSITION('_' IN m.GameID) = 0
+    `, puuid, queue.RankedSoloID, queue.RankedFlexID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ongoing games: %v", err)
+	}
+	defer rows.Close()
+
+	var gameIDs []string
+	for rows.Next() {
+		var gameID string
+		if err := rows.Scan(&gameID); err != nil {
+			return nil, fmt.Errorf("failed to scan game ID: %v", err)
+		}
+		gameIDs = append(gameIDs, gameID)
+	}
+	return gameIDs, nil
+}
@@ -0,0 +1,85 @@
+package databaseHelper
+
+import (
+	"database/sql"
+	"discord-bot/types/history"
+	"discord-bot/types/rank"
+	"discord-bot/types/summoner"
+	"fmt"
+	"time"
+)
+
+// SaveNameChange stores the previous Riot ID and platform of a summoner
+func SaveNameChange(change history.NameChange) error {
+	_, err := db.Exec(`
+        INSERT INTO SummonerNameHistory (SummonerPUUID, Name, TagLine, Region, Changed)
+        VALUES ($1, $2, $3, $4, $5)
+    `, change.SummonerPUUID, change.Name, change.TagLine, change.Region, change.Changed)
+	if err != nil {
+		return fmt.Errorf("failed to save name change: %v", err)
+	}
+	return nil
+}
+
+// GetNameHistory retrieves the previous Riot IDs of a summoner, newest first
+func GetNameHistory(puuid string) ([]history.NameChange, error) {
+	rows, err := db.Query(`
+        SELECT SummonerPUUID, Name, TagLine, Region, Changed
+        FROM SummonerNameHistory
+        WHERE SummonerPUUID = $1
+        ORDER BY Changed DESC
+    `, puuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query name history: %v", err)
+	}
+	defer rows.Close()
+
+	var changes []history.NameChange
+	for rows.Next() {
+		var c history.NameChange
+		if err := rows.Scan(&c.SummonerPUUID, &c.Name, &c.TagLine, &c.Region, &c.Changed); err != nil {
+			return nil, fmt.Errorf("failed to scan name change: %v", err)
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// GetSummonerByPreviousName retrieves the summoner that most recently used a Riot ID, sql.ErrNoRows if none did
+func GetSummonerByPreviousName(name, tag string) (*summoner.Summoner, error) {
+	var s summoner.Summoner
+	var soloRank, flexRank int
+	err := db.QueryRow(`
+        SELECT s.Name, s.TagLine, s.AccountID, s.ID, s.PUUID, s.ProfileIconID, s.SoloRank, s.FlexRank, s.Updated, s.Region
+        FROM SummonerNameHistory h
+        JOIN Summoner s ON s.PUUID = h.SummonerPUUID
+        WHERE LOWER(h.Name) = LOWER($1) AND LOWER(h.TagLine) = LOWER($2)
+        ORDER BY h.Changed DESC
+        LIMIT 1
+    `, name, tag).Scan(&s.Name, &s.TagLine, &s.AccountID, &s.ID, &s.PUUID, &s.ProfileIconID, &soloRank, &flexRank, &s.Updated, &s.Region)
+	if err != nil {
+		return nil, err
+	}
+	s.SoloRank = rank.Rank(soloRank)
+	s.FlexRank = rank.Rank(flexRank)
+	return &s, nil
+}
+
+// IsIdentityCheckDue reports whether the Riot ID and platform of a summoner were not checked within interval
+func IsIdentityCheckDue(puuid string, interval time.Duration) (bool, error) {
+	var checked sql.NullTime
+	err := db.QueryRow(`SELECT IdentityChecked FROM Summoner WHERE PUUID = $1`, puuid).Scan(&checked)
+	if err != nil {
+		return false, fmt.Errorf("failed to get identity check time: %v", err)
+	}
+	return !checked.Valid || time.Since(checked.Time) >= interval, nil
+}
+
+// UpdateIdentityChecked records that the Riot ID and platform of a summoner were just checked
+func UpdateIdentityChecked(puuid string) error {
+	_, err := db.Exec(`UPDATE Summoner SET IdentityChecked = $1 WHERE PUUID = $2`, time.Now(), puuid)
+	if err != nil {
+		return fmt.Errorf("failed to update identity check time: %v", err)
+	}
+	return nil
+}
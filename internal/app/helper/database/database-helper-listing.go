@@ -0,0 +1,90 @@
+package databaseHelper
+
+import (
+	"database/sql"
+	"discord-bot/types/rank"
+	"discord-bot/types/summoner"
+	"fmt"
+	"strings"
+)
+
+// trackedSummonerQuery selects summoners with their channel mapping and the time of their last recorded game
+const trackedSummonerQuery = `
+    SELECT s.Name, s.TagLine, s.AccountID, s.ID, s.PUUID, s.ProfileIconID, s.SoloRank, s.FlexRank, s.Updated, s.Region,
+           sc.ChannelID, sc.GuildID, sc.Games,
+           (SELECT MAX(rh.Recorded) FROM RankHistory rh WHERE rh.SummonerPUUID = s.PUUID)
+    FROM Summoner s
+    JOIN SummonerChannel sc ON s.PUUID = sc.SummonerPUUID
+`
+
+// GetTrackedSummonersForChannel retrieves all summoners mapped to a channel, ordered by name
+func GetTrackedSummonersForChannel(channelID string) ([]summoner.TrackedSummoner, error) {
+	return queryTrackedSummoners(trackedSummonerQuery+` WHERE sc.ChannelID = $1 ORDER BY LOWER(s.Name), LOWER(s.TagLine)`, channelID)
+}
+
+// GetTrackedSummonersForGuild retrieves all summoners mapped to any channel of a guild, ordered by name
+func GetTrackedSummonersForGuild(guildID string) ([]summoner.TrackedSummoner, error) {
+	return queryTrackedSummoners(trackedSummonerQuery+` WHERE sc.GuildID = $1 ORDER BY LOWER(s.Name), LOWER(s.TagLine), sc.ChannelID`, guildID)
+}
+
+func queryTrackedSummoners(query string, args ...interface{}) ([]summoner.TrackedSummoner, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tracked summoners: %v", err)
+	}
+	defer rows.Close()
+
+	var tracked []summoner.TrackedSummoner
+	for rows.Next() {
+		var t summoner.TrackedSummoner
+		var soloRank, flexRank int
+		var lastGame sql.NullTime
+		s := &t.Summoner
+		err := rows.Scan(&s.Name, &s.TagLine, &s.AccountID, &s.ID, &s.PUUID, &s.ProfileIconID, &soloRank, &flexRank, &s.Updated, &s.Region, &t.ChannelID, &t.GuildID, &t.Games, &lastGame)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tracked summoner: %v", err)
+		}
+		s.SoloRank = rank.Rank(soloRank)
+		s.FlexRank = rank.Rank(flexRank)
+		if lastGame.Valid {
+			t.LastGame = &lastGame.Time
+		}
+		tracked = append(tracked, t)
+	}
+
+	return tracked, nil
+}
+
+// SearchSummonersForChannel retrieves up to limit summoners mapped to a channel whose name and tag start with the given prefixes
+func SearchSummonersForChannel(channelID, namePrefix, tagPrefix string, limit int) ([]summoner.Summoner, error) {
+	rows, err := db.Query(`
+        SELECT s.Name, s.TagLine, s.PUUID, s.Region
+        FROM Summoner s
+        JOIN SummonerChannel sc ON s.PUUID = sc.SummonerPUUID
+        WHERE sc.ChannelID = $1 AND LOWER(s.Name) LIKE $2 ESCAPE '\' AND LOWER(s.TagLine) LIKE $3 ESCAPE '\'
+        ORDER BY LOWER(s.Name), LOWER(s.TagLine)
+        LIMIT $4
+    `, channelID, likePrefix(namePrefix), likePrefix(tagPrefix), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search summoners: %v", err)
+	}
+	defer rows.Close()
+
+	var summoners []summoner.Summoner
+	for rows.Next() {
+		var s summoner.Summoner
+		err := rows.Scan(&s.Name, &s.TagLine, &s.PUUID, &s.Region)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan summoner: %v", err)
+		}
+		summoners = append(summoners, s)
+	}
+
+	return summoners, nil
+}
+
+// likePrefix builds a case-insensitive LIKE pattern matching everything starting with prefix
+func likePrefix(prefix string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return escaper.Replace(strings.ToLower(prefix)) + "%"
+}
@@ -0,0 +1,76 @@
+package databaseHelper
+
+import (
+	"database/sql"
+	"discord-bot/types/mastery"
+	"fmt"
+	"time"
+)
+
+// GetChampionMasteries retrieves the last mastery snapshot of a summoner, empty if none was taken yet
+func GetChampionMasteries(puuid string) ([]mastery.ChampionMastery, error) {
+	rows, err := db.Query(`
+        SELECT ChampionID, ChampionLevel, ChampionPoints, LastPlayTime
+        FROM ChampionMastery
+        WHERE SummonerPUUID = $1
+        ORDER BY ChampionPoints DESC
+    `, puuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query champion masteries: %v", err)
+	}
+	defer rows.Close()
+
+	var masteries []mastery.ChampionMastery
+	for rows.Next() {
+		var m mastery.ChampionMastery
+		if err := rows.Scan(&m.ChampionID, &m.ChampionLevel, &m.ChampionPoints, &m.LastPlayTime); err != nil {
+			return nil, fmt.Errorf("failed to scan champion mastery: %v", err)
+		}
+		masteries = append(masteries, m)
+	}
+	return masteries, nil
+}
+
+// SaveChampionMasteries stores a mastery snapshot of a summoner and records when it was taken
+func SaveChampionMasteries(puuid string, masteries []mastery.ChampionMastery) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	for _, m := range masteries {
+		_, err = tx.Exec(`
+            INSERT INTO ChampionMastery (SummonerPUUID, ChampionID, ChampionLevel, ChampionPoints, LastPlayTime)
+            VALUES ($1, $2, $3, $4, $5)
+            ON CONFLICT (SummonerPUUID, ChampionID) DO UPDATE SET
+                ChampionLevel = EXCLUDED.ChampionLevel,
+                ChampionPoints = EXCLUDED.ChampionPoints,
+                LastPlayTime = EXCLUDED.LastPlayTime
+        `, puuid, m.ChampionID, m.ChampionLevel, m.ChampionPoints, m.LastPlayTime)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to save champion mastery: %v", err)
+		}
+	}
+
+	_, err = tx.Exec(`UPDATE Summoner SET MasteryChecked = $1 WHERE PUUID = $2`, time.Now(), puuid)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update mastery check time: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit champion masteries: %v", err)
+	}
+	return nil
+}
+
+// IsMasteryCheckDue reports whether no mastery snapshot of a summoner was taken within interval
+func IsMasteryCheckDue(puuid string, interval time.Duration) (bool, error) {
+	var checked sql.NullTime
+	err := db.QueryRow(`SELECT MasteryChecked FROM Summoner WHERE PUUID = $1`, puuid).Scan(&checked)
+	if err != nil {
+		return false, fmt.Errorf("failed to get mastery check time: %v", err)
+	}
+	return !checked.Valid || time.Since(checked.Time) >= interval, nil
+}
@@ -0,0 +1,60 @@
+package databaseHelper
+
+import (
+	"fmt"
+)
+
+// AddCommandRole allows a role to use the management commands of a guild
+func AddCommandRole(guildID, roleID string) error {
+	_, err := db.Exec(`INSERT INTO GuildCommandRole (GuildID, RoleID) VALUES ($1, $2) ON CONFLICT (GuildID, RoleID) DO NOTHING`, guildID, roleID)
+	if err != nil {
+		return fmt.Errorf("failed to add command role: %v", err)
+	}
+	return nil
+}
+
+// RemoveCommandRole removes a role from the management command allowlist of a guild
+func RemoveCommandRole(guildID, roleID string) error {
+	res, err := db.Exec(`DELETE FROM GuildCommandRole WHERE GuildID = $1 AND RoleID = $2`, guildID, roleID)
+	if err != nil {
+		return fmt.Errorf("failed to remove command role: %v", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("role is not on the allowlist")
+	}
+	return nil
+}
+
+// GetCommandRoles retrieves the roles allowed to use the management commands of a guild
+func GetCommandRoles(guildID string) ([]string, error) {
+	rows, err := db.Query(`SELECT RoleID FROM GuildCommandRole WHERE GuildID = $1 ORDER BY RoleID`, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get command roles: %v", err)
+	}
+	defer rows.Close()
+
+	var roleIDs []string
+	for rows.Next() {
+		var roleID string
+		err := rows.Scan(&roleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan command role: %v", err)
+		}
+		roleIDs = append(roleIDs, roleID)
+	}
+
+	return roleIDs, nil
+}
+
+// DeleteCommandRolesForGuild removes the management command allowlist of a guild
+func DeleteCommandRolesForGuild(guildID string) error {
+	_, err := db.Exec(`DELETE FROM GuildCommandRole WHERE GuildID = $1`, guildID)
+	if err != nil {
+		return fmt.Errorf("failed to delete command roles for guild: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package databaseHelper
+
+import (
+	"discord-bot/types/rank"
+	"discord-bot/types/roles"
+	"discord-bot/types/summoner"
+	"fmt"
+)
+
+// SaveRankRole creates or updates the role a tier of a queue is mapped to in a guild
+func SaveRankRole(rankRole roles.RankRole) error {
+	_, err := db.Exec(`
+        INSERT INTO GuildRankRole (GuildID, QueueType, Tier, RoleID) VALUES ($1, $2, $3, $4)
+        ON CONFLICT (GuildID, QueueType, Tier) DO UPDATE SET RoleID = EXCLUDED.RoleID
+    `, rankRole.GuildID, rankRole.QueueType, rankRole.Tier, rankRole.RoleID)
+	if err != nil {
+		return fmt.Errorf("failed to save rank role: %v", err)
+	}
+	return nil
+}
+
+// DeleteRankRole removes the role mapping of a tier of a queue in a guild
+func DeleteRankRole(guildID, queueType, tier string) error {
+	res, err := db.Exec(`DELETE FROM GuildRankRole WHERE GuildID = $1 AND QueueType = $2 AND Tier = $3`, guildID, queueType, tier)
+	if err != nil {
+		return fmt.Errorf("failed to delete rank role: %v", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no role is mapped to %s in %s", tier, queueType)
+	}
+	return nil
+}
+
+// DeleteRankRolesForGuild removes all role mappings of a guild
+func DeleteRankRolesForGuild(guildID string) error {
+	_, err := db.Exec(`DELETE FROM GuildRankRole WHERE GuildID = $1`, guildID)
+	if err != nil {
+		return fmt.Errorf("failed to delete rank roles for guild: %v", err)
+	}
+	return nil
+}
+
+// GetRankRoles retrieves the role mappings of a guild
+func GetRankRoles(guildID string) ([]roles.RankRole, error) {
+	rows, err := db.Query(`SELECT GuildID, QueueType, Tier, RoleID FROM GuildRankRole WHERE GuildID = $1 ORDER BY QueueType, Tier`, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rank roles: %v", err)
+	}
+	defer rows.Close()
+
+	var rankRoles []roles.RankRole
+	for rows.Next() {
+		var r roles.RankRole
+		err := rows.Scan(&r.GuildID, &r.QueueType, &r.Tier, &r.RoleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rank role: %v", err)
+		}
+		rankRoles = append(rankRoles, r)
+	}
+
+	return rankRoles, nil
+}
+
+// GetGuildsWithRankRoles retrieves the IDs of all guilds with at least one role mapping
+func GetGuildsWithRankRoles() ([]string, error) {
+	return queryGuildIDs(`SELECT DISTINCT GuildID FROM GuildRankRole`)
+}
+
+// GetGuildsWithRankRolesForSummoner retrieves the IDs of the guilds with role mappings that track a summoner in one of their channels
+func GetGuildsWithRankRolesForSummoner(puuid string) ([]string, error) {
+	return queryGuildIDs(`
+        SELECT DISTINCT rr.GuildID
+        FROM GuildRankRole rr
+        JOIN SummonerChannel sc ON sc.GuildID = rr.GuildID
+        WHERE sc.SummonerPUUID = $1
+    `, puuid)
+}
+
+func queryGuildIDs(query string, args ...interface{}) ([]string, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guilds with rank roles: %v", err)
+	}
+	defer rows.Close()
+
+	var guilds []string
+	for rows.Next() {
+		var guildID string
+		err := rows.Scan(&guildID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan guild: %v", err)
+		}
+		guilds = append(guilds, guildID)
+	}
+
+	return guilds, nil
+}
+
+// GetVerifiedUsersInGuild retrieves the IDs of the Discord users with a verified account tracked in a channel of a guild
+func GetVerifiedUsersInGuild(guildID string) ([]string, error) {
+	return queryUserIDs(`
+        SELECT DISTINCT dua.UserID
+        FROM DiscordUserAccount dua
+        JOIN SummonerChannel sc ON sc.SummonerPUUID = dua.SummonerPUUID
+        WHERE sc.GuildID = $1 AND dua.Verified
+        ORDER BY dua.UserID
+    `, guildID)
+}
+
+// GetVerifiedUsersForSummoner retrieves the IDs of the Discord users who verified a summoner
+func GetVerifiedUsersForSummoner(puuid string) ([]string, error) {
+	return queryUserIDs(`SELECT UserID FROM DiscordUserAccount WHERE SummonerPUUID = $1 AND Verified ORDER BY UserID`, puuid)
+}
+
+func queryUserIDs(query string, args ...interface{}) ([]string, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %v", err)
+	}
+	defer rows.Close()
+
+	var users []string
+	for rows.Next() {
+		var userID string
+		err := rows.Scan(&userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %v", err)
+		}
+		users = append(users, userID)
+	}
+
+	return users, nil
+}
+
+// GetVerifiedSummonersForUser retrieves the summoners a Discord user verified
+func GetVerifiedSummonersForUser(userID string) ([]summoner.Summoner, error) {
+	rows, err := db.Query(`
+        SELECT s.Name, s.TagLine, s.AccountID, s.ID, s.PUUID, s.ProfileIconID, s.SoloRank, s.FlexRank, s.Updated, s.Region
+        FROM DiscordUserAccount dua
+        JOIN Summoner s ON s.PUUID = dua.SummonerPUUID
+        WHERE dua.UserID = $1 AND dua.Verified
+    `, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verified summoners: %v", err)
+	}
+	defer rows.Close()
+
+	var summoners []summoner.Summoner
+	for rows.Next() {
+		var s summoner.Summoner
+		var soloRank, flexRank int
+		err := rows.Scan(&s.Name, &s.TagLine, &s.AccountID, &s.ID, &s.PUUID, &s.ProfileIconID, &soloRank, &flexRank, &s.Updated, &s.Region)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan summoner: %v", err)
+		}
+		s.SoloRank = rank.Rank(soloRank)
+		s.FlexRank = rank.Rank(flexRank)
+		summoners = append(summoners, s)
+	}
+
+	return summoners, nil
+}
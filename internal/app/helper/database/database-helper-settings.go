@@ -0,0 +1,123 @@
+package databaseHelper
+
+import (
+	"database/sql"
+	"discord-bot/types/settings"
+	"fmt"
+	"strings"
+)
+
+// GetChannelSettings retrieves the notification settings of a channel, falling back to the defaults if none are stored.
+// The defaults carry the guild of the channel, so guild settings like the profile site apply to unconfigured channels too.
+func GetChannelSettings(channelID string) (*settings.ChannelSettings, error) {
+	s := settings.NewChannelSettings(channelID, "")
+	var optInQueues string
+	err := db.QueryRow(`
+        SELECT GuildID, NotifyStart, NotifyEnd, NotifyMastery, NotifyClash, NotifyStatus, QueueFilter, OptInQueues, MinLPChange, ImagesEnabled, Language, QuietStart, QuietEnd, Timezone
+        FROM ChannelSettings WHERE ChannelID = $1
+    `, channelID).Scan(&s.GuildID, &s.NotifyStart, &s.NotifyEnd, &s.NotifyMastery, &s.NotifyClash, &s.NotifyStatus, &s.QueueFilter, &optInQueues, &s.MinLPChange, &s.ImagesEnabled, &s.Language, &s.QuietStart, &s.QuietEnd, &s.Timezone)
+	if err == sql.ErrNoRows {
+		s.GuildID, err = GetGuildIDForChannel(channelID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel settings: %v", err)
+	}
+	if optInQueues != "" {
+		s.OptInQueues = strings.Split(optInQueues, ",")
+	}
+	return s, nil
+}
+
+// GetGuildIDForChannel retrieves the guild of a channel from its tracked summoners or digests, empty if the channel is unknown
+func GetGuildIDForChannel(channelID string) (string, error) {
+	var guildID string
+	err := db.QueryRow(`
+        SELECT GuildID FROM SummonerChannel WHERE ChannelID = $1
+        UNION ALL
+        SELECT GuildID FROM DigestSchedule WHERE ChannelID = $1
+        LIMIT 1
+    `, channelID).Scan(&guildID)
+	if err != nil && err != sql.ErrNoRows {
+		return "", fmt.Errorf("failed to get guild of channel: %v", err)
+	}
+	return guildID, nil
+}
+
+// SaveChannelSettings creates or updates the notification settings of a channel
+func SaveChannelSettings(s *settings.ChannelSettings) error {
+	_, err := db.Exec(`
+        INSERT INTO ChannelSettings (ChannelID, GuildID, NotifyStart, NotifyEnd, NotifyMastery, QueueFilter, MinLPChange, ImagesEnabled, Language, QuietStart, QuietEnd, Timezone, OptInQueues, NotifyClash, NotifyStatus)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+        ON CONFLICT (ChannelID) DO UPDATE SET
+            GuildID = EXCLUDED.GuildID,
+            NotifyStart = EXCLUDED.NotifyStart,
+            NotifyEnd = EXCLUDED.NotifyEnd,
+            NotifyMastery = EXCLUDED.NotifyMastery,
+            QueueFilter = EXCLUDED.QueueFilter,
+            MinLPChange = EXCLUDED.MinLPChange,
+            ImagesEnabled = EXCLUDED.ImagesEnabled,
+            Language = EXCLUDED.Language,
+            QuietStart = EXCLUDED.QuietStart,
+            QuietEnd = EXCLUDED.QuietEnd,
+            Timezone = EXCLUDED.Timezone,
+            OptInQueues = EXCLUDED.OptInQueues,
+            NotifyClash = EXCLUDED.NotifyClash,
+            NotifyStatus = EXCLUDED.NotifyStatus
+    `, s.ChannelID, s.GuildID, s.NotifyStart, s.NotifyEnd, s.NotifyMastery, s.QueueFilter, s.MinLPChange, s.ImagesEnabled, s.Language, s.QuietStart, s.QuietEnd, s.Timezone, strings.Join(s.OptInQueues, ","), s.NotifyClash, s.NotifyStatus)
+	if err != nil {
+		return fmt.Errorf("failed to save channel settings: %v", err)
+	}
+	return nil
+}
+
+// DeleteChannelSettings removes the notification settings of a channel
+func DeleteChannelSettings(channelID string) error {
+	_, err := db.Exec(`DELETE FROM ChannelSettings WHERE ChannelID = $1`, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to delete channel settings: %v", err)
+	}
+	return nil
+}
+
+// DeleteChannelSettingsForGuild removes the notification settings of all channels of a guild
+func DeleteChannelSettingsForGuild(guildID string) error {
+	_, err := db.Exec(`DELETE FROM ChannelSettings WHERE GuildID = $1`, guildID)
+	if err != nil {
+		return fmt.Errorf("failed to delete channel settings for guild: %v", err)
+	}
+	return nil
+}
+
+// GetGuildSettings retrieves the settings of a guild, falling back to the defaults if none are stored
+func GetGuildSettings(guildID string) (*settings.GuildSettings, error) {
+	s := settings.NewGuildSettings(guildID)
+	err := db.QueryRow(`SELECT ProfileSite, ImageTheme FROM GuildSettings WHERE GuildID = $1`, guildID).Scan(&s.ProfileSite, &s.ImageTheme)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get guild settings: %v", err)
+	}
+	return s, nil
+}
+
+// SaveGuildSettings creates or updates the settings of a guild
+func SaveGuildSettings(s *settings.GuildSettings) error {
+	_, err := db.Exec(`
+        INSERT INTO GuildSettings (GuildID, ProfileSite, ImageTheme)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (GuildID) DO UPDATE SET
+            ProfileSite = EXCLUDED.ProfileSite,
+            ImageTheme = EXCLUDED.ImageTheme
+    `, s.GuildID, s.ProfileSite, s.ImageTheme)
+	if err != nil {
+		return fmt.Errorf("failed to save guild settings: %v", err)
+	}
+	return nil
+}
+
+// DeleteGuildSettings removes the settings of a guild
+func DeleteGuildSettings(guildID string) error {
+	_, err := db.Exec(`DELETE FROM GuildSettings WHERE GuildID = $1`, guildID)
+	if err != nil {
+		return fmt.Errorf("failed to delete guild settings: %v", err)
+	}
+	return nil
+}
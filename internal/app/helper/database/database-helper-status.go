@@ -0,0 +1,112 @@
+package databaseHelper
+
+import (
+	"fmt"
+)
+
+// StatusMessage is a posted announcement of a Riot incident or maintenance, edited when Riot posts an update
+type StatusMessage struct {
+	ChannelID string
+	Platform  string
+	EntryKey  string // status.Entry.Key of the announced entry
+	MessageID string
+	Version   string // status.Entry.Version the message shows
+}
+
+// GetTrackedPlatforms retrieves the platforms with at least one summoner tracked in a channel
+func GetTrackedPlatforms() ([]string, error) {
+	rows, err := db.Query(`
+        SELECT DISTINCT s.Region FROM Summoner s
+        JOIN SummonerChannel sc ON sc.SummonerPUUID = s.PUUID
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tracked platforms: %v", err)
+	}
+	defer rows.Close()
+
+	var platforms []string
+	for rows.Next() {
+		var platform string
+		if err := rows.Scan(&platform); err != nil {
+			return nil, fmt.Errorf("failed to scan platform: %v", err)
+		}
+		platforms = append(platforms, platform)
+	}
+	return platforms, nil
+}
+
+// GetStatusChannelsForPlatform retrieves the channels that opted in to Riot status notifications and track a summoner on the platform
+func GetStatusChannelsForPlatform(platform string) ([]string, error) {
+	rows, err := db.Query(`
+        SELECT DISTINCT cs.ChannelID FROM ChannelSettings cs
+        JOIN SummonerChannel sc ON sc.ChannelID = cs.ChannelID
+        JOIN Summoner s ON s.PUUID = sc.SummonerPUUID
+        WHERE cs.NotifyStatus AND s.Region = $1
+    `, platform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status channels: %v", err)
+	}
+	defer rows.Close()
+
+	var channels []string
+	for rows.Next() {
+		var channel string
+		if err := rows.Scan(&channel); err != nil {
+			return nil, fmt.Errorf("failed to scan channel: %v", err)
+		}
+		channels = append(channels, channel)
+	}
+	return channels, nil
+}
+
+// GetStatusMessages retrieves the posted status announcements of a platform
+func GetStatusMessages(platform string) ([]StatusMessage, error) {
+	rows, err := db.Query(`SELECT ChannelID, Platform, EntryKey, MessageID, Version FROM StatusMessage WHERE Platform = $1`, platform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status messages: %v", err)
+	}
+	defer rows.Close()
+
+	var messages []StatusMessage
+	for rows.Next() {
+		var m StatusMessage
+		if err := rows.Scan(&m.ChannelID, &m.Platform, &m.EntryKey, &m.MessageID, &m.Version); err != nil {
+			return nil, fmt.Errorf("failed to scan status message: %v", err)
+		}
+		messages = append(messages, m)
+	}
+	return messages, nil
+}
+
+// SaveStatusMessage creates or updates a posted status announcement
+func SaveStatusMessage(m StatusMessage) error {
+	_, err := db.Exec(`
+        INSERT INTO StatusMessage (ChannelID, Platform, EntryKey, MessageID, Version)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (ChannelID, Platform, EntryKey) DO UPDATE SET
+            MessageID = EXCLUDED.MessageID,
+            Version = EXCLUDED.Version
+    `, m.ChannelID, m.Platform, m.EntryKey, m.MessageID, m.Version)
+	if err != nil {
+		return fmt.Errorf("failed to save status message: %v", err)
+	}
+	return nil
+}
+
+// DeleteStatusMessage forgets a status announcement once its incident or maintenance is resolved
+func DeleteStatusMessage(channelID, platform, entryKey string) error {
+	_, err := db.Exec(`DELETE FROM StatusMessage WHERE ChannelID = $1 AND Platform = $2 AND EntryKey = $3`, channelID, platform, entryKey)
+	if err != nil {
+		return fmt.Errorf("failed to delete status message: %v", err)
+	}
+	return nil
+}
+
+// DeleteStatusMessagesForChannel forgets the status announcements of a channel
+func DeleteStatusMessagesForChannel(channelID string) error {
+	_, err := db.Exec(`DELETE FROM StatusMessage WHERE ChannelID = $1`, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to delete status messages: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package databaseHelper
+
+import (
+	"discord-bot/types/rank"
+	"discord-bot/types/subscription"
+	"fmt"
+)
+
+// TFTState is what the bot remembers about the TFT games of a summoner between two checks
+type TFTState struct {
+	Rank         rank.Rank // Ranked TFT rank after the last known game, 0 if unranked
+	LastMatchID  string    // Latest tft-match-v1 match, empty if none was seen yet
+	ActiveGameID int64     // Game the last match start notification was sent for
+}
+
+// GetChannelsForSummonerByGame retrieves the channels whose subscription of a summoner follows game (subscription.GamesLoL or GamesTFT)
+func GetChannelsForSummonerByGame(puuid, game string) ([]string, error) {
+	rows, err := db.Query(`SELECT ChannelID FROM SummonerChannel WHERE SummonerPUUID = $1 AND (Games = $2 OR Games = $3)`, puuid, game, subscription.GamesBoth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels for summoner: %v", err)
+	}
+	defer rows.Close()
+
+	var channels []string
+	for rows.Next() {
+		var channel string
+		if err := rows.Scan(&channel); err != nil {
+			return nil, fmt.Errorf("failed to scan channel: %v", err)
+		}
+		channels = append(channels, channel)
+	}
+	return channels, nil
+}
+
+// SetSubscriptionGames changes which games a channel follows for a summoner
+func SetSubscriptionGames(puuid, channelID, games string) error {
+	res, err := db.Exec(`UPDATE SummonerChannel SET Games = $1 WHERE SummonerPUUID = $2 AND ChannelID = $3`, games, puuid, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to update subscription: %v", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("summoner is not tracked in this channel")
+	}
+	return nil
+}
+
+// GetTFTState retrieves the remembered TFT state of a summoner
+func GetTFTState(puuid string) (*TFTState, error) {
+	var state TFTState
+	var tftRank int
+	err := db.QueryRow(`SELECT TFTRank, LastTFTMatchID, ActiveTFTGameID FROM Summoner WHERE PUUID = $1`, puuid).Scan(&tftRank, &state.LastMatchID, &state.ActiveGameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TFT state: %v", err)
+	}
+	state.Rank = rank.Rank(tftRank)
+	return &state, nil
+}
+
+// SaveTFTState stores the TFT state of a summoner
+func SaveTFTState(puuid string, state *TFTState) error {
+	_, err := db.Exec(`
+        UPDATE Summoner SET TFTRank = $1, LastTFTMatchID = $2, ActiveTFTGameID = $3
+        WHERE PUUID = $4
+    `, int(state.Rank), state.LastMatchID, state.ActiveGameID, puuid)
+	if err != nil {
+		return fmt.Errorf("failed to save TFT state: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+package staticdata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"discord-bot/internal/logger"
+	"discord-bot/types/catalog"
+
+	"go.uber.org/zap"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+// DefaultBaseURL is the Data Dragon CDN, override it with DDRAGON_BASE_URL e.g. for a mirror
+const DefaultBaseURL = "https://ddragon.leagueoflegends.com"
+
+// DefaultCacheDir is where downloaded patches are stored, override it with STATIC_DATA_DIR
+const DefaultCacheDir = "assets/cache/ddragon"
+
+// versionsTTL is how long the list of released patches is kept before it is fetched again
+const versionsTTL = time.Hour
+
+// failureBackoff is how long a failed download of the versions or of a patch is not retried
+const failureBackoff = time.Minute
+
+// ErrUnknownID is returned for IDs the static data of a patch does not contain
+var ErrUnknownID = errors.New("unknown ID")
+
+// Patch is the static data of one Data Dragon version
+type Patch struct {
+	*catalog.Catalog
+	Version string
+
+	manager *Manager
+}
+
+// Manager downloads static data and icons per patch into a versioned cache directory
+type Manager struct {
+	baseURL  string
+	cacheDir string
+	client   *http.Client
+
+	mu             sync.Mutex
+	versions       []string
+	refreshed      time.Time
+	refreshing     bool
+	versionsErr    error
+	versionsFailed time.Time
+	patches        map[string]*patchLoad
+}
+
+// patchLoad is the download of one patch, done is closed once patch or err is set
+type patchLoad struct {
+	done     chan struct{}
+	patch    *Patch
+	err      error
+	finished time.Time
+}
+
+// NewManager creates a manager for a Data Dragon compatible server
+func NewManager(baseURL, cacheDir string) *Manager {
+	return &Manager{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		cacheDir: cacheDir,
+		client:   &http.Client{Timeout: 30 * time.Second},
+		patches:  make(map[string]*patchLoad),
+	}
+}
+
+var (
+	defaultOnce    sync.Once
+	defaultManager *Manager
+)
+
+// Default returns the manager configured by DDRAGON_BASE_URL and STATIC_DATA_DIR
+func Default() *Manager {
+	defaultOnce.Do(func() {
+		baseURL := os.Getenv("DDRAGON_BASE_URL")
+		if baseURL == "" {
+			baseURL = DefaultBaseURL
+		}
+		cacheDir := os.Getenv("STATIC_DATA_DIR")
+		if cacheDir == "" {
+			cacheDir = DefaultCacheDir
+		}
+		defaultManager = NewManager(baseURL, cacheDir)
+	})
+	return defaultManager
+}
+
+// Versions returns the released patches, newest first.
+// The list is fetched without holding the lock, callers get the known versions while it is refreshed
+// and after a failed refresh until failureBackoff expired.
+func (m *Manager) Versions() ([]string, error) {
+	m.mu.Lock()
+	if m.versions != nil && (time.Since(m.refreshed) < versionsTTL || m.refreshing || time.Since(m.versionsFailed) < failureBackoff) {
+		versions := m.versions
+		m.mu.Unlock()
+		return versions, nil
+	}
+	if m.versionsErr != nil && time.Since(m.versionsFailed) < failureBackoff {
+		err := m.versionsErr
+		m.mu.Unlock()
+		return nil, err
+	}
+	m.refreshing = true
+	m.mu.Unlock()
+
+	var versions []string
+	err := m.getJSON(m.baseURL+"/api/versions.json", &versions)
+	if err == nil && len(versions) == 0 {
+		err = fmt.Errorf("no patch versions available")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refreshing = false
+	if err != nil {
+		m.versionsErr = err
+		m.versionsFailed = time.Now()
+		if m.versions != nil {
+			logger.Logger.Warn("Failed to refresh patch versions, using the known ones", zap.Error(err))
+			return m.versions, nil
+		}
+		return nil, err
+	}
+
+	m.versions = versions
+	m.refreshed = time.Now()
+	m.versionsErr = nil
+	return versions, nil
+}
+
+// ResolveVersion maps the gameVersion of a match like "15.3.612.4321" to the Data Dragon version of its patch.
+// An empty or unreleased game version resolves to the latest patch.
+func (m *Manager) ResolveVersion(gameVersion string) (string, error) {
+	versions, err := m.Versions()
+	if err != nil {
+		return "", err
+	}
+	return resolveVersion(versions, gameVersion), nil
+}
+
+func resolveVersion(versions []string, gameVersion string) string {
+	parts := strings.Split(gameVersion, ".")
+	if len(parts) >= 2 {
+		prefix := parts[0] + "." + parts[1] + "."
+		for _, version := range versions {
+			if strings.HasPrefix(version, prefix) {
+				return version
+			}
+		}
+	}
+	return versions[0]
+}
+
+// Patch returns the static data of the patch of a game version, downloading it on first use.
+// Concurrent callers of the same patch share one download, a failed download is not retried until failureBackoff expired.
+func (m *Manager) Patch(gameVersion string) (*Patch, error) {
+	version, err := m.ResolveVersion(gameVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	load, ok := m.patches[version]
+	if ok {
+		select {
+		case <-load.done:
+			if load.err == nil || time.Since(load.finished) < failureBackoff {
+				m.mu.Unlock()
+				return load.patch, load.err
+			}
+		default:
+			m.mu.Unlock()
+			<-load.done
+			return load.patch, load.err
+		}
+	}
+	load = &patchLoad{done: make(chan struct{})}
+	m.patches[version] = load
+	m.mu.Unlock()
+
+	load.patch, load.err = m.loadPatch(version)
+	load.finished = time.Now()
+	close(load.done)
+	if load.err != nil {
+		return nil, load.err
+	}
+	logger.Logger.Info("Loaded static data", zap.String("version", version), zap.Int("items", len(load.patch.Items)), zap.Int("champions", len(load.patch.Champions)))
+	return load.patch, nil
+}
+
+func (m *Manager) loadPatch(version string) (*Patch, error) {
+	patch := &Patch{Catalog: catalog.New(), Version: version, manager: m}
+
+	files := []struct {
+		name   string
+		decode func([]byte) error
+	}{
+		{"item.json", patch.DecodeItems},
+		{"summoner.json", patch.DecodeSpells},
+		{"runesReforged.json", patch.DecodeRunes},
+		{"champion.json", patch.DecodeChampions},
+	}
+	for _, file := range files {
+		data, err := m.loadData(version, file.name)
+		if err != nil {
+			return nil, err
+		}
+		if err := file.decode(data); err != nil {
+			return nil, fmt.Errorf("%s of patch %s: %w", file.name, version, err)
+		}
+	}
+	return patch, nil
+}
+
+// loadData reads a data file of a patch from the cache, downloading it if it is not cached yet
+func (m *Manager) loadData(version, name string) ([]byte, error) {
+	path, err := m.cachedFile(filepath.Join(version, "data", name), fmt.Sprintf("%s/cdn/%s/data/en_US/%s", m.baseURL, version, name))
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// ItemFile opens the icon of an item, ErrUnknownID if the patch has no such item
+func (p *Patch) ItemFile(itemID int) (*os.File, error) {
+	item, ok := p.Items[itemID]
+	if !ok {
+		return nil, fmt.Errorf("item %d: %w", itemID, ErrUnknownID)
+	}
+	return p.manager.icon(filepath.Join(p.Version, "img", "item", item.Image), fmt.Sprintf("%s/cdn/%s/img/item/%s", p.manager.baseURL, p.Version, item.Image))
+}
+
+// SpellFile opens the icon of a summoner spell, ErrUnknownID if the patch has no such spell
+func (p *Patch) SpellFile(spellID int) (*os.File, error) {
+	spell, ok := p.Spells[spellID]
+	if !ok {
+		return nil, fmt.Errorf("spell %d: %w", spellID, ErrUnknownID)
+	}
+	return p.manager.icon(filepath.Join(p.Version, "img", "spell", spell.Image), fmt.Sprintf("%s/cdn/%s/img/spell/%s", p.manager.baseURL, p.Version, spell.Image))
+}
+
+// RuneFile opens the icon of a rune or rune tree, ErrUnknownID if the patch has no such rune
+func (p *Patch) RuneFile(runeID int) (*os.File, error) {
+	r, ok := p.Runes[runeID]
+	if !ok {
+		return nil, fmt.Errorf("rune %d: %w", runeID, ErrUnknownID)
+	}
+	// Rune icons are not versioned on Data Dragon
+	return p.manager.icon(filepath.Join(p.Version, "img", filepath.FromSlash(r.Icon)), fmt.Sprintf("%s/cdn/img/%s", p.manager.baseURL, r.Icon))
+}
+
+// ChampionFile opens the square portrait of a champion, ErrUnknownID if the patch has no such champion
+func (p *Patch) ChampionFile(championID int) (*os.File, error) {
+	champion, ok := p.Champions[championID]
+	if !ok {
+		return nil, fmt.Errorf("champion %d: %w", championID, ErrUnknownID)
+	}
+	return p.manager.icon(filepath.Join(p.Version, "img", "champion", champion.Image), fmt.Sprintf("%s/cdn/%s/img/champion/%s", p.manager.baseURL, p.Version, champion.Image))
+}
+
+func (m *Manager) icon(name, url string) (*os.File, error) {
+	path, err := m.cachedFile(name, url)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// cachedFile returns the path of a file in the cache directory, downloading it from url if it is not cached yet
+func (m *Manager) cachedFile(name, url string) (string, error) {
+	path := filepath.Join(m.cacheDir, name)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	if err := Download(m.client, url, path); err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	return path, nil
+}
+
+// Download stores the content of url at path.
+// It is written to a temporary file first so concurrent readers never see a partial file.
+func Download(client *http.Client, url, path string) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), "download_*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	_, err = io.Copy(tmpFile, resp.Body)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpFile.Name())
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		os.Remove(tmpFile.Name())
+		return fmt.Errorf("failed to store cache file: %w", err)
+	}
+	return nil
+}
+
+func (m *Manager) getJSON(url string, v interface{}) error {
+	resp, err := m.client.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch %s: status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
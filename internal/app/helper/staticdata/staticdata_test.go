@@ -0,0 +1,144 @@
+package staticdata
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestServer serves a minimal Data Dragon with the patches 15.3.1 and 15.2.1
+func newTestServer(t *testing.T, requests *int32) *httptest.Server {
+	files := map[string]string{
+		"/api/versions.json":                                                      `["15.3.1", "15.2.1"]`,
+		"/cdn/15.2.1/data/en_US/item.json":                                        `{"data": {"1001": {"name": "Boots", "image": {"full": "1001.png"}}}}`,
+		"/cdn/15.2.1/data/en_US/summoner.json":                                    `{"data": {"SummonerFlash": {"key": "4", "name": "Flash", "image": {"full": "SummonerFlash.png"}}}}`,
+		"/cdn/15.2.1/data/en_US/runesReforged.json":                               `[{"id": 8000, "name": "Precision", "icon": "perk-images/Styles/7201_Precision.png", "slots": [{"runes": [{"id": 8005, "name": "Press the Attack", "icon": "perk-images/Styles/Precision/PressTheAttack/PressTheAttack.png"}]}]}]`,
+		"/cdn/15.2.1/data/en_US/champion.json":                                    `{"data": {"MonkeyKing": {"id": "MonkeyKing", "key": "62", "name": "Wukong", "image": {"full": "MonkeyKing.png"}}}}`,
+		"/cdn/15.2.1/img/item/1001.png":                                           "item",
+		"/cdn/15.2.1/img/spell/SummonerFlash.png":                                 "spell",
+		"/cdn/img/perk-images/Styles/Precision/PressTheAttack/PressTheAttack.png": "rune",
+		"/cdn/15.2.1/img/champion/MonkeyKing.png":                                 "champion",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, content)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestResolveVersion(t *testing.T) {
+	versions := []string{"15.3.1", "15.2.1", "15.1.1"}
+	tests := map[string]string{
+		"15.2.612.4321": "15.2.1",
+		"15.3.1":        "15.3.1",
+		"16.1.1.1":      "15.3.1",
+		"":              "15.3.1",
+	}
+	for gameVersion, expected := range tests {
+		if got := resolveVersion(versions, gameVersion); got != expected {
+			t.Errorf("resolveVersion(%q): expected %s, got %s", gameVersion, expected, got)
+		}
+	}
+}
+
+func TestPatch(t *testing.T) {
+	var requests int32
+	server := newTestServer(t, &requests)
+	cacheDir := t.TempDir()
+	manager := NewManager(server.URL, cacheDir)
+
+	patch, err := manager.Patch("15.2.544.1234")
+	if err != nil {
+		t.Fatalf("failed to load patch: %v", err)
+	}
+	if patch.Version != "15.2.1" {
+		t.Errorf("expected patch 15.2.1, got %s", patch.Version)
+	}
+	if patch.Items[1001].Name != "Boots" || patch.Spells[4].Name != "Flash" || patch.Champions[62].Name != "Wukong" {
+		t.Errorf("unexpected static data: %+v %+v %+v", patch.Items, patch.Spells, patch.Champions)
+	}
+	if patch.Runes[8000].Name != "Precision" || patch.Runes[8005].Name != "Press the Attack" {
+		t.Errorf("expected rune trees and runes, got %+v", patch.Runes)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "15.2.1", "data", "item.json")); err != nil {
+		t.Errorf("expected item.json in the versioned cache: %v", err)
+	}
+
+	opens := map[string]func() (*os.File, error){
+		"item":     func() (*os.File, error) { return patch.ItemFile(1001) },
+		"spell":    func() (*os.File, error) { return patch.SpellFile(4) },
+		"rune":     func() (*os.File, error) { return patch.RuneFile(8005) },
+		"champion": func() (*os.File, error) { return patch.ChampionFile(62) },
+	}
+	for expected, open := range opens {
+		file, err := open()
+		if err != nil {
+			t.Errorf("failed to open %s icon: %v", expected, err)
+			continue
+		}
+		content, _ := io.ReadAll(file)
+		file.Close()
+		if string(content) != expected {
+			t.Errorf("expected %s icon, got %q", expected, content)
+		}
+	}
+
+	// Cached patches and icons are not downloaded again
+	before := atomic.LoadInt32(&requests)
+	if _, err := manager.Patch("15.2.1"); err != nil {
+		t.Fatalf("failed to load cached patch: %v", err)
+	}
+	file, err := patch.ItemFile(1001)
+	if err != nil {
+		t.Fatalf("failed to open cached icon: %v", err)
+	}
+	file.Close()
+	if after := atomic.LoadInt32(&requests); after != before {
+		t.Errorf("expected no requests for cached data, got %d", after-before)
+	}
+}
+
+func TestUnknownID(t *testing.T) {
+	var requests int32
+	server := newTestServer(t, &requests)
+	patch, err := NewManager(server.URL, t.TempDir()).Patch("15.2.1")
+	if err != nil {
+		t.Fatalf("failed to load patch: %v", err)
+	}
+	if _, err := patch.ItemFile(999999); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("expected ErrUnknownID, got %v", err)
+	}
+	if _, err := patch.RuneFile(1); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("expected ErrUnknownID, got %v", err)
+	}
+}
+
+func TestPatchUnavailable(t *testing.T) {
+	var requests int32
+	server := newTestServer(t, &requests)
+	manager := NewManager(server.URL, t.TempDir())
+	// 15.3.1 is listed as released but its data files are missing
+	if _, err := manager.Patch(""); err == nil {
+		t.Error("expected an error for a patch without data files")
+	}
+
+	// The failure is remembered instead of downloading the patch again on every call
+	before := atomic.LoadInt32(&requests)
+	if _, err := manager.Patch(""); err == nil {
+		t.Error("expected the remembered error for a patch without data files")
+	}
+	if after := atomic.LoadInt32(&requests); after != before {
+		t.Errorf("expected no requests during the failure backoff, got %d", after-before)
+	}
+}
@@ -0,0 +1,159 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five-field cron expression (minute hour day-of-month month day-of-week)
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	domRestricted, dowRestricted  bool
+}
+
+type fieldBounds struct {
+	name     string
+	min, max int
+}
+
+var bounds = []fieldBounds{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Parse parses a cron expression like "0 18 * * 0" (every Sunday at 18:00).
+// Every field supports "*", single values, lists ("1,3"), ranges ("1-5") and steps ("*/15").
+// Day of week accepts both 0 and 7 for Sunday.
+func Parse(expression string) (*Schedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression, got %d", len(fields))
+	}
+
+	var parsed [5]uint64
+	for i, field := range fields {
+		bits, err := parseField(field, bounds[i])
+		if err != nil {
+			return nil, err
+		}
+		parsed[i] = bits
+	}
+
+	// Sunday may be written as 7, fold it onto 0
+	if parsed[4]&(1<<7) != 0 {
+		parsed[4] |= 1
+		parsed[4] &^= 1 << 7
+	}
+
+	return &Schedule{
+		minute:        parsed[0],
+		hour:          parsed[1],
+		dom:           parsed[2],
+		month:         parsed[3],
+		dow:           parsed[4],
+		domRestricted: fields[2] != "*",
+		dowRestricted: fields[4] != "*",
+	}, nil
+}
+
+func parseField(field string, b fieldBounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			var err error
+			rangePart = part[:idx]
+			step, err = strconv.Atoi(part[idx+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", part[idx+1:], b.name)
+			}
+		}
+
+		start, end := b.min, b.max
+		if rangePart != "*" {
+			if idx := strings.Index(rangePart, "-"); idx >= 0 {
+				var err error
+				start, err = parseValue(rangePart[:idx], b)
+				if err != nil {
+					return 0, err
+				}
+				end, err = parseValue(rangePart[idx+1:], b)
+				if err != nil {
+					return 0, err
+				}
+				if start > end {
+					return 0, fmt.Errorf("invalid range %q in %s field", rangePart, b.name)
+				}
+			} else {
+				value, err := parseValue(rangePart, b)
+				if err != nil {
+					return 0, err
+				}
+				start = value
+				if step == 1 {
+					end = value
+				}
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(value string, b fieldBounds) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", value, b.name)
+	}
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("value %d out of range [%d-%d] in %s field", v, b.min, b.max, b.name)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t that matches the schedule, in t's location.
+// A zero time is returned if nothing matches within the next five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches follows the classic cron rule: if both day fields are restricted, either may match
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	}
+
+	for _, test := range tests {
+		if _, err := Parse(test); err == nil {
+			t.Errorf("Expected error for %q, got nil", test)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+
+	tests := []struct {
+		expression string
+		from       time.Time
+		expected   time.Time
+	}{
+		// Every Sunday at 18:00
+		{"0 18 * * 0", time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC), time.Date(2025, 1, 26, 18, 0, 0, 0, time.UTC)},
+		// 7 is Sunday as well
+		{"0 18 * * 7", time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC), time.Date(2025, 1, 26, 18, 0, 0, 0, time.UTC)},
+		// Strictly after the given time
+		{"0 18 * * 0", time.Date(2025, 1, 26, 18, 0, 0, 0, time.UTC), time.Date(2025, 2, 2, 18, 0, 0, 0, time.UTC)},
+		// Steps and lists
+		{"*/15 9,21 * * *", time.Date(2025, 1, 22, 9, 31, 0, 0, time.UTC), time.Date(2025, 1, 22, 9, 45, 0, 0, time.UTC)},
+		{"*/15 9,21 * * *", time.Date(2025, 1, 22, 9, 50, 0, 0, time.UTC), time.Date(2025, 1, 22, 21, 0, 0, 0, time.UTC)},
+		// Ranges on weekdays
+		{"30 8 * * 1-5", time.Date(2025, 1, 24, 9, 0, 0, 0, time.UTC), time.Date(2025, 1, 27, 8, 30, 0, 0, time.UTC)},
+		// First of the month, rolls over the year
+		{"0 0 1 * *", time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either may match
+		{"0 12 15 * 1", time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 13, 12, 0, 0, 0, time.UTC)},
+		// Timezone aware
+		{"0 20 * * *", time.Date(2025, 1, 22, 12, 0, 0, 0, berlin), time.Date(2025, 1, 22, 20, 0, 0, 0, berlin)},
+	}
+
+	for _, test := range tests {
+		schedule, err := Parse(test.expression)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", test.expression, err)
+		}
+		result := schedule.Next(test.from)
+		if !result.Equal(test.expected) {
+			t.Errorf("%q from %v: expected %v, got %v", test.expression, test.from, test.expected, result)
+		}
+	}
+}
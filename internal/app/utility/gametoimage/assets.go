@@ -0,0 +1,34 @@
+package gametoimage
+
+import (
+	"os"
+
+	assethelper "discord-bot/internal/app/helper/assets"
+	"discord-bot/types/catalog"
+)
+
+// assetLoader opens the static data and icons drawn on the images
+type assetLoader struct {
+	catalog        func(gameVersion string) *catalog.Catalog
+	itemFiles      func(gameVersion string, itemIDs []int) ([]*os.File, error)
+	spellFiles     func(gameVersion string, spellIDs []int) ([]*os.File, error)
+	runeFile       func(gameVersion string, runeID int) (*os.File, error)
+	championSquare func(championID int) (*os.File, error)
+	rankCrest      func(tier string) (*os.File, error)
+	tftUnit        func(characterID string, setNumber int) (*os.File, error)
+	placeholder    func() (*os.File, error)
+	template       func() (*os.File, error)
+}
+
+// assets loads from the asset helper, which downloads what is not cached yet. Tests replace it with local fixtures.
+var assets = assetLoader{
+	catalog:        assethelper.GetCatalog,
+	itemFiles:      assethelper.GetItemFiles,
+	spellFiles:     assethelper.GetSpellFiles,
+	runeFile:       assethelper.GetRuneFile,
+	championSquare: assethelper.GetChampionSquareFile,
+	rankCrest:      assethelper.GetRankCrestFile,
+	tftUnit:        assethelper.GetTFTUnitFile,
+	placeholder:    assethelper.GetPlaceholderFile,
+	template:       assethelper.GetTemplateFile,
+}
@@ -0,0 +1,43 @@
+package gametoimage
+
+import (
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"sync"
+
+	"github.com/nfnt/resize"
+)
+
+// cacheKey identifies a decoded asset in a given size, size 0 keeps the original size
+type cacheKey struct {
+	path string
+	size int
+}
+
+// imageCache holds decoded and resized assets, images are never modified after they are stored
+var imageCache sync.Map
+
+// cachedImage returns the decoded image of an asset file resized to size x size and closes the file.
+// Assets are decoded and resized only once, so renders running in parallel share them.
+func cachedImage(file *os.File, size int) (image.Image, error) {
+	defer file.Close()
+
+	key := cacheKey{path: file.Name(), size: size}
+	if img, ok := imageCache.Load(key); ok {
+		return img.(image.Image), nil
+	}
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", file.Name(), err)
+	}
+	if size > 0 {
+		img = resize.Resize(uint(size), uint(size), img, resize.Lanczos3)
+	}
+
+	actual, _ := imageCache.LoadOrStore(key, img)
+	return actual.(image.Image), nil
+}
@@ -0,0 +1,47 @@
+package gametoimage
+
+import (
+	"sync"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// The Go fonts are compiled into the binary, so text rendering does not depend on fonts installed on the host
+var (
+	fontsOnce   sync.Once
+	regularFont *truetype.Font
+	boldFont    *truetype.Font
+)
+
+func loadFonts() {
+	var err error
+	if regularFont, err = truetype.Parse(goregular.TTF); err != nil {
+		regularFont = nil
+	}
+	if boldFont, err = truetype.Parse(gobold.TTF); err != nil {
+		boldFont = nil
+	}
+}
+
+// regularFace returns the bundled regular font in the given size, the built-in bitmap font if it cannot be parsed
+func regularFace(size float64) font.Face {
+	fontsOnce.Do(loadFonts)
+	return newFace(regularFont, size)
+}
+
+// boldFace returns the bundled bold font in the given size
+func boldFace(size float64) font.Face {
+	fontsOnce.Do(loadFonts)
+	return newFace(boldFont, size)
+}
+
+func newFace(f *truetype.Font, size float64) font.Face {
+	if f == nil {
+		return basicfont.Face7x13
+	}
+	return truetype.NewFace(f, &truetype.Options{Size: size, Hinting: font.HintingFull})
+}
@@ -1,17 +1,15 @@
 package gametoimage
 
 import (
-	assethelper "discord-bot/internal/app/helper/assets"
+	"bytes"
+	"discord-bot/types/catalog"
 	"discord-bot/types/match"
 	"fmt"
 	"image"
-	"image/png"
-	"os"
 
 	"discord-bot/internal/logger"
 
 	"github.com/fogleman/gg"
-	"github.com/nfnt/resize"
 	"go.uber.org/zap"
 )
 
@@ -19,244 +17,121 @@ func init() {
 	logger.InitLogger()
 }
 
-// ImageBuilder is the main structure for handling the template image and assets.
-type ImageBuilder struct {
-	Template image.Image
-	Context  *gg.Context
-}
-
-// GameToImage generates an image based on the game data provided.
-func GameToImage(participant match.Participant) (*os.File, error) {
-	builder, err := NewImageBuilder()
-	if err != nil {
-		logger.Logger.Error("Failed to create image builder", zap.Error(err))
-		return nil, err
-	}
-	wd, err := os.Getwd()
-	if err != nil {
-		logger.Logger.Error("Failed to get working directory", zap.Error(err))
-		return nil, err
-	}
-
-	defaultImagePath := wd + "/assets/15.1.1/template/template_empty.png"
-	defaultImage, err := os.Open(defaultImagePath)
-	if err != nil {
-		logger.Logger.Error("Failed to open default image", zap.String("path", defaultImagePath), zap.Error(err))
-		return nil, fmt.Errorf("failed to open default image: %w", err)
-	}
-
-	// Log the default image path
-	logger.Logger.Info("Default image path", zap.String("path", defaultImagePath))
-
-	itemImages, err := assethelper.GetItemFiles(participant.Items.ItemIDs)
-	if err != nil {
-		logger.Logger.Error("Failed to get item files", zap.Error(err))
-		return nil, fmt.Errorf("failed to get item files: %w", err)
-	}
-
-	// Adding Items
-	for i := 0; i < 6; i++ {
-		if i < len(itemImages) {
-			// Log the item image path
-			logger.Logger.Info("Item image path", zap.String("path", itemImages[i].Name()))
-
-			err = builder.AddImage(itemImages[i], float64(i*64+64), 0, 64, 64)
-			if err != nil {
-				logger.Logger.Error("Failed to add item image", zap.Error(err))
-				return nil, fmt.Errorf("failed to add item image: %w", err)
-			}
-		} else {
-			err = builder.AddImage(defaultImage, float64(i*64+64), 0, 64, 64)
-			if err != nil {
-				logger.Logger.Error("Failed to add default image", zap.Error(err))
-				return nil, fmt.Errorf("failed to add default image: %w", err)
-			}
-		}
+// GameToImage renders the items, summoner spells, keystones and stats of a participant in the layout of a theme,
+// unknown themes fall back to DefaultTheme. Icons are taken from the patch of gameVersion.
+// Rendering happens in memory and is safe for concurrent use.
+func GameToImage(participant match.Participant, gameVersion, themeName string) (*bytes.Buffer, error) {
+	theme := GetTheme(themeName)
+	if theme == nil {
+		return nil, fmt.Errorf("theme %q is not available", themeName)
 	}
 
-	spellImages, err := assethelper.GetSpellFiles(participant.Spells.SpellIDs)
+	background, err := theme.canvasImage(assets.template)
 	if err != nil {
-		logger.Logger.Error("Failed to get spell files", zap.Error(err))
-		return nil, fmt.Errorf("failed to get spell files: %w", err)
+		logger.Logger.Error("Failed to load theme canvas", zap.String("theme", theme.Name), zap.Error(err))
+		return nil, fmt.Errorf("failed to load canvas of theme %s: %w", theme.Name, err)
 	}
 
-	// Adding Spells
-	for i, spell := range spellImages {
-		// Log the spell image path
-		logger.Logger.Info("Spell image path", zap.String("path", spell.Name()))
-
-		spellimage, _, err := image.Decode(spell)
-		if err != nil {
-			logger.Logger.Error("Failed to decode spell image", zap.Error(err))
-			return nil, fmt.Errorf("failed to decode spell image: %w", err)
+	// Icons are loaded before drawing, so a broken asset fails the render instead of leaving a gap
+	icons := make(map[int]image.Image)
+	for i, slot := range theme.Slots {
+		if slot.Kind == slotText {
+			continue
 		}
-		// Resize the spell image to 31x31
-		resizedSpell := resize.Resize(32, 32, spellimage, resize.Lanczos3)
-		spellFile, err := os.CreateTemp("", "resized_spell_*.png")
-		if err != nil {
-			logger.Logger.Error("Failed to create temp file for resized spell", zap.Error(err))
-			return nil, fmt.Errorf("failed to create temp file for resized spell: %w", err)
-		}
-
-		err = png.Encode(spellFile, resizedSpell)
+		icon, err := slotImage(participant, gameVersion, slot)
 		if err != nil {
-			logger.Logger.Error("Failed to encode resized spell to file", zap.Error(err))
-			return nil, fmt.Errorf("failed to encode resized spell to file: %w", err)
-		}
-
-		_, err = spellFile.Seek(0, 0)
-		if err != nil {
-			logger.Logger.Error("Failed to seek to beginning of spell file", zap.Error(err))
-			return nil, fmt.Errorf("failed to seek to beginning of spell file: %w", err)
+			logger.Logger.Error("Failed to load slot image", zap.String("theme", theme.Name), zap.String("kind", slot.Kind), zap.Int("index", slot.Index), zap.Error(err))
+			return nil, fmt.Errorf("failed to load %s image: %w", slot.Kind, err)
 		}
+		icons[i] = icon
+	}
 
-		err = builder.AddImage(spellFile, float64(i*32), 0, 32, 32)
-		if err != nil {
-			logger.Logger.Error("Failed to add spell image", zap.Error(err))
-			return nil, fmt.Errorf("failed to add spell image: %w", err)
-		}
+	champions := assets.catalog(gameVersion)
+	text := func(field string) string {
+		return participantText(participant, champions, field)
 	}
 
-	perkImages, err := assethelper.GetPerkFiles(participant.Perks)
+	var buf bytes.Buffer
+	err = gg.NewContextForImage(theme.compose(background, func(i int, _ Slot) image.Image { return icons[i] }, text)).EncodePNG(&buf)
 	if err != nil {
-		logger.Logger.Error("Failed to get perk files", zap.Error(err))
-		return nil, fmt.Errorf("failed to get perk files: %w", err)
+		logger.Logger.Error("Failed to encode build image", zap.Error(err))
+		return nil, fmt.Errorf("failed to encode build image: %w", err)
 	}
+	return &buf, nil
+}
 
-	// Adding Perks
-	for i, perk := range perkImages {
-		// Log the perk image path
-		logger.Logger.Info("Perk image path", zap.String("path", perk.Name()))
-
-		// Check the image format
-		_, format, err := image.Decode(perk)
-		if err != nil {
-			logger.Logger.Error("Failed to decode perk image", zap.Error(err))
-			return nil, fmt.Errorf("failed to decode perk image: %w", err)
+// slotImage loads the icon of an icon slot in the slot size, nil if the participant has nothing to show there
+func slotImage(participant match.Participant, gameVersion string, slot Slot) (image.Image, error) {
+	switch slot.Kind {
+	case slotItem:
+		// Empty slots and unknown items show the empty slot image
+		itemID := 0
+		if slot.Index < len(participant.Items.ItemIDs) {
+			itemID = participant.Items.ItemIDs[slot.Index]
 		}
-		// Log the error and the first few bytes of the image file for debugging
-		buf := make([]byte, 512)
-		perk.Seek(0, 0) // Reset the reader to the beginning
-		n, _ := perk.Read(buf)
-		logger.Logger.Debug("First bytes of the image", zap.Int("bytesRead", n), zap.ByteString("bytes", buf[:n]))
-		logger.Logger.Info("Perk image format", zap.String("format", format))
-
-		// Reset the reader to the beginning before adding the image
-		perk.Seek(0, 0)
-
-		perkimage, _, err := image.Decode(perk)
-		if err != nil {
-			logger.Logger.Error("Failed to decode perk image", zap.Error(err))
-			return nil, fmt.Errorf("failed to decode perk image: %w", err)
+		return itemImage(gameVersion, itemID, slot.Size)
+	case slotSpell:
+		if slot.Index >= len(participant.Spells.SpellIDs) {
+			return nil, nil
 		}
-		// Resize the perk image to 28x28
-		resizedPerk := resize.Resize(28, 28, perkimage, resize.Lanczos3)
-		perkFile, err := os.CreateTemp("", "resized_perk_*.png")
+		files, err := assets.spellFiles(gameVersion, []int{participant.Spells.SpellIDs[slot.Index]})
 		if err != nil {
-			logger.Logger.Error("Failed to create temp file for resized perk", zap.Error(err))
-			return nil, fmt.Errorf("failed to create temp file for resized perk: %w", err)
+			return nil, err
 		}
-
-		err = png.Encode(perkFile, resizedPerk)
-		if err != nil {
-			logger.Logger.Error("Failed to encode resized perk to file", zap.Error(err))
-			return nil, fmt.Errorf("failed to encode resized perk to file: %w", err)
+		return cachedImage(files[0], slot.Size)
+	case slotRune:
+		runeIDs := keystones(participant.Perks)
+		if slot.Index >= len(runeIDs) {
+			return nil, nil
 		}
-
-		_, err = perkFile.Seek(0, 0)
+		file, err := assets.runeFile(gameVersion, runeIDs[slot.Index])
 		if err != nil {
-			logger.Logger.Error("Failed to seek to beginning of perk file", zap.Error(err))
-			return nil, fmt.Errorf("failed to seek to beginning of perk file: %w", err)
+			return nil, err
 		}
-
-		err = builder.AddImage(perkFile, float64((i*28)+2+i*4), 34, 28, 28)
+		return cachedImage(file, slot.Size)
+	case slotChampion:
+		file, err := assets.championSquare(participant.ChampionID)
 		if err != nil {
-			logger.Logger.Error("Failed to add perk image", zap.Error(err))
-			return nil, fmt.Errorf("failed to add perk image: %w", err)
+			logger.Logger.Warn("Failed to get champion square, using empty slot", zap.Int("championID", participant.ChampionID), zap.Error(err))
+			if file, err = assets.placeholder(); err != nil {
+				return nil, err
+			}
 		}
+		return cachedImage(file, slot.Size)
 	}
-
-	err = builder.Save("output.png")
-	if err != nil {
-		logger.Logger.Error("Failed to save image", zap.Error(err))
-		return nil, fmt.Errorf("failed to save image: %w", err)
-	}
-
-	output, err := os.Open("output.png")
-	if err != nil {
-		logger.Logger.Error("Failed to open output image", zap.Error(err))
-		return nil, fmt.Errorf("failed to open output image: %w", err)
-	}
-
-	// Close all item images
-	for _, itemImage := range itemImages {
-		itemImage.Close()
-	}
-
-	// Close all spell files
-	for _, spell := range spellImages {
-		spell.Close()
-	}
-
-	// Close all perk files
-	for _, perk := range perkImages {
-		perk.Close()
-	}
-
-	// Close the default image
-	defaultImage.Close()
-
-	return output, nil
+	return nil, nil
 }
 
-func NewImageBuilder() (*ImageBuilder, error) {
-	wd, err := os.Getwd()
-	if err != nil {
-		logger.Logger.Error("Failed to get working directory", zap.Error(err))
-		return nil, err
-	}
-	file, err := os.Open(wd + "/assets/15.1.1/template/template.png")
-	if err != nil {
-		logger.Logger.Error("Failed to open template image", zap.String("path", wd+"/assets/15.1.1/template/template.png"), zap.Error(err))
-		return nil, err
-	}
-	defer file.Close()
-
-	template, _, err := image.Decode(file)
-	if err != nil {
-		logger.Logger.Error("Failed to decode template image", zap.Error(err))
-		return nil, err
-	}
-
-	// Create a drawing context with the size of the template image.
-	dc := gg.NewContextForImage(template)
-
-	return &ImageBuilder{
-		Template: template,
-		Context:  dc,
-	}, nil
+// participantText returns the value of a text slot field, see textFields
+func participantText(participant match.Participant, champions *catalog.Catalog, field string) string {
+	stats := participant.Stats
+	switch field {
+	case "name":
+		return participant.Summoner.GetNameTag()
+	case "champion":
+		return champions.ChampionName(participant.ChampionID)
+	case "kda":
+		return fmt.Sprintf("%d / %d / %d", stats.Kills, stats.Deaths, stats.Assists)
+	case "cs":
+		return fmt.Sprintf("%d CS", stats.CreepScore)
+	case "gold":
+		return formatThousands(stats.GoldEarned) + " gold"
+	case "damage":
+		return formatThousands(stats.DamageDealt) + " damage"
+	}
+	return ""
 }
 
-func (ib *ImageBuilder) AddImage(newfile *os.File, x, y, width, height float64) error {
-	defer newfile.Close()
-
-	asset, _, err := image.Decode(newfile)
-	if err != nil {
-		logger.Logger.Error("Failed to decode image", zap.Error(err))
-		return err
-	}
-	// Resize the asset to the specified dimensions.
-	resizedAsset := resize.Resize(uint(width), uint(height), asset, resize.Lanczos3)
-
-	// Draw the resized asset onto the context.
-	ib.Context.DrawImage(resizedAsset, int(x), int(y))
-	return nil
-}
-
-func (ib *ImageBuilder) Save(outputPath string) error {
-	err := ib.Context.SavePNG(outputPath)
+// itemImage returns the icon of an item, the empty slot image for item 0 or items without an icon
+func itemImage(gameVersion string, itemID, size int) (image.Image, error) {
+	files, err := assets.itemFiles(gameVersion, []int{itemID})
 	if err != nil {
-		logger.Logger.Error("Failed to save PNG", zap.String("outputPath", outputPath), zap.Error(err))
+		logger.Logger.Warn("Failed to get item file, using empty slot", zap.Int("itemID", itemID), zap.Error(err))
+		placeholder, err := assets.placeholder()
+		if err != nil {
+			return nil, err
+		}
+		return cachedImage(placeholder, size)
 	}
-	return err
+	return cachedImage(files[0], size)
 }
@@ -0,0 +1,256 @@
+package gametoimage
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"discord-bot/types/catalog"
+)
+
+var update = flag.Bool("update", false, "update the golden images in testdata")
+
+func solidImage(width, height int, c color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// useFixtureAssets replaces the asset loader with one solid icon and an empty catalog, so renders do not download assets
+func useFixtureAssets(t *testing.T) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, solidImage(64, 64, color.RGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xff})); err != nil {
+		t.Fatalf("failed to encode fixture: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "icon.png")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	open := func() (*os.File, error) { return os.Open(path) }
+	openAll := func(ids []int) ([]*os.File, error) {
+		var files []*os.File
+		for range ids {
+			file, err := open()
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+		}
+		return files, nil
+	}
+
+	original := assets
+	assets = assetLoader{
+		catalog:        func(string) *catalog.Catalog { return catalog.New() },
+		itemFiles:      func(_ string, itemIDs []int) ([]*os.File, error) { return openAll(itemIDs) },
+		spellFiles:     func(_ string, spellIDs []int) ([]*os.File, error) { return openAll(spellIDs) },
+		runeFile:       func(string, int) (*os.File, error) { return open() },
+		championSquare: func(int) (*os.File, error) { return open() },
+		rankCrest:      func(string) (*os.File, error) { return open() },
+		tftUnit:        func(string, int) (*os.File, error) { return open() },
+		placeholder:    open,
+		template:       open,
+	}
+	t.Cleanup(func() { assets = original })
+}
+
+// assertGolden compares an image with testdata/<name>, run the tests with -update to rewrite it
+func assertGolden(t *testing.T, name string, img image.Image) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("failed to update golden image: %v", err)
+		}
+		return
+	}
+
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden image: %v", err)
+	}
+	expected, err := png.Decode(bytes.NewReader(golden))
+	if err != nil {
+		t.Fatalf("failed to decode golden image: %v", err)
+	}
+	if expected.Bounds() != img.Bounds() {
+		t.Fatalf("expected bounds %v, got %v", expected.Bounds(), img.Bounds())
+	}
+	for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+		for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+			r1, g1, b1, a1 := expected.At(x, y).RGBA()
+			r2, g2, b2, a2 := img.At(x, y).RGBA()
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				t.Fatalf("pixel (%d, %d) differs from %s", x, y, path)
+			}
+		}
+	}
+}
+
+// Sizes of the icons in the classic theme
+const (
+	buildItemSize  = 64
+	buildSpellSize = 32
+	buildPerkSize  = 28
+)
+
+func buildFixtures() (image.Image, []image.Image, []image.Image, []image.Image) {
+	template := solidImage(448, 64, color.RGBA{R: 0x10, G: 0x14, B: 0x1a, A: 0xff})
+	var items, spells, perks []image.Image
+	for i := 0; i < 6; i++ {
+		items = append(items, solidImage(buildItemSize, buildItemSize, color.RGBA{R: uint8(40 * i), G: 0x80, B: 0x40, A: 0xff}))
+	}
+	for i := 0; i < 2; i++ {
+		spells = append(spells, solidImage(buildSpellSize, buildSpellSize, color.RGBA{R: 0xf0, G: uint8(100 * i), B: 0x20, A: 0xff}))
+		perks = append(perks, solidImage(buildPerkSize, buildPerkSize, color.RGBA{R: 0x30, G: 0x30, B: uint8(0x80 + 100*i), A: 0xff}))
+	}
+	return template, items, spells, perks
+}
+
+// composeBuild draws the fixtures in the layout of the classic theme
+func composeBuild(template image.Image, items, spells, perks []image.Image) image.Image {
+	icons := map[string][]image.Image{slotItem: items, slotSpell: spells, slotRune: perks}
+	return GetTheme(DefaultTheme).compose(template, func(_ int, slot Slot) image.Image {
+		if slot.Index < len(icons[slot.Kind]) {
+			return icons[slot.Kind][slot.Index]
+		}
+		return nil
+	}, func(string) string { return "" })
+}
+
+func TestComposeBuildGolden(t *testing.T) {
+	template, items, spells, perks := buildFixtures()
+	assertGolden(t, "build.golden.png", composeBuild(template, items, spells, perks))
+}
+
+func TestComposeBuildKeepsTemplate(t *testing.T) {
+	template, items, spells, perks := buildFixtures()
+	before := template.At(buildItemSize+1, 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			composeBuild(template, items, spells, perks)
+		}()
+	}
+	wg.Wait()
+
+	if template.At(buildItemSize+1, 1) != before {
+		t.Errorf("composeBuild modified the shared template")
+	}
+}
+
+func TestCachedImageConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "asset.png")
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, solidImage(64, 64, color.White)); err != nil {
+		t.Fatalf("failed to encode asset: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("failed to write asset: %v", err)
+	}
+
+	results := make([]image.Image, 8)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			file, err := os.Open(path)
+			if err != nil {
+				t.Errorf("failed to open asset: %v", err)
+				return
+			}
+			results[i], err = cachedImage(file, 32)
+			if err != nil {
+				t.Errorf("failed to load asset: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, img := range results {
+		if img == nil || img.Bounds().Dx() != 32 {
+			t.Fatalf("expected a 32px image, got %v", img)
+		}
+		if img != results[0] {
+			t.Errorf("expected every render to share the cached image")
+		}
+	}
+}
+
+func TestBuiltinThemes(t *testing.T) {
+	for _, name := range []string{"classic", "card", "compact"} {
+		if !HasTheme(name) {
+			t.Errorf("expected built-in theme %s to be loaded", name)
+		}
+	}
+	if GetTheme("missing").Name != DefaultTheme {
+		t.Errorf("expected unknown themes to fall back to %s", DefaultTheme)
+	}
+}
+
+func TestParseThemeRejectsInvalid(t *testing.T) {
+	invalid := map[string]string{
+		"no name":      `{"canvas": {"width": 10, "height": 10}}`,
+		"no canvas":    `{"name": "a"}`,
+		"bad color":    `{"name": "a", "canvas": {"width": 10, "height": 10, "color": "red"}}`,
+		"unknown kind": `{"name": "a", "canvas": {"width": 10, "height": 10}, "slots": [{"kind": "ward", "size": 8}]}`,
+		"no size":      `{"name": "a", "canvas": {"width": 10, "height": 10}, "slots": [{"kind": "item"}]}`,
+		"unknown text": `{"name": "a", "canvas": {"width": 10, "height": 10}, "slots": [{"kind": "text", "text": "mmr"}]}`,
+	}
+	for name, data := range invalid {
+		if _, err := ParseTheme([]byte(data)); err == nil {
+			t.Errorf("%s: expected theme to be rejected", name)
+		}
+	}
+}
+
+func TestLoadThemes(t *testing.T) {
+	dir := t.TempDir()
+	theme := `{"name": "test-custom", "canvas": {"width": 40, "height": 20, "color": "#ff0000"}, "slots": [{"kind": "item", "x": 4, "y": 2, "size": 16}]}`
+	if err := os.WriteFile(filepath.Join(dir, "custom.json"), []byte(theme), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadThemes(dir); err != nil {
+		t.Fatalf("failed to load themes: %v", err)
+	}
+
+	custom := GetTheme("test-custom")
+	if custom.Name != "test-custom" {
+		t.Fatalf("expected custom theme to be loaded, got %s", custom.Name)
+	}
+	img := custom.compose(nil, func(int, Slot) image.Image { return solidImage(16, 16, color.White) }, func(string) string { return "" })
+	if img.Bounds().Dx() != 40 || img.Bounds().Dy() != 20 {
+		t.Errorf("expected a 40x20 canvas, got %v", img.Bounds())
+	}
+	if r, g, b, _ := img.At(0, 0).RGBA(); r>>8 != 0xff || g != 0 || b != 0 {
+		t.Errorf("expected canvas color, got %v", img.At(0, 0))
+	}
+	if r, g, b, _ := img.At(10, 10).RGBA(); r>>8 != 0xff || g>>8 != 0xff || b>>8 != 0xff {
+		t.Errorf("expected the item slot to be drawn, got %v", img.At(10, 10))
+	}
+}
@@ -0,0 +1,182 @@
+package gametoimage
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"os"
+	"strings"
+
+	"discord-bot/internal/logger"
+	"discord-bot/types/catalog"
+	"discord-bot/types/match"
+
+	"github.com/fogleman/gg"
+	"go.uber.org/zap"
+)
+
+// Layout of the loading-screen image
+const (
+	liveCardWidth    = 200
+	liveCardHeight   = 268
+	liveMargin       = 10
+	liveHeaderHeight = 36
+	liveBanRowHeight = 44
+	liveChampionSize = 120
+	liveIconSize     = 32
+)
+
+var (
+	liveBackground   = color.RGBA{R: 0x10, G: 0x14, B: 0x1a, A: 0xff}
+	liveTeamColors   = [2]color.RGBA{{R: 0x1e, G: 0x3a, B: 0x5f, A: 0xff}, {R: 0x5f, G: 0x1e, B: 0x24, A: 0xff}}
+	liveTextColor    = color.RGBA{R: 0xf0, G: 0xe6, B: 0xd2, A: 0xff}
+	liveSubTextColor = color.RGBA{R: 0xa0, G: 0x9b, B: 0x8c, A: 0xff}
+)
+
+// LiveGameToImage renders a loading-screen style overview of a running game with both teams,
+// their champions, summoner spells, runes, solo ranks and win rates as well as the bans.
+// Icons and champion names are taken from the latest patch. Participants whose PUUID is in tracked are highlighted.
+func LiveGameToImage(liveMatch *match.Match, tracked map[string]bool) (*bytes.Buffer, error) {
+	width := liveMargin + 5*(liveCardWidth+liveMargin)
+	teamHeight := liveBanRowHeight + liveCardHeight + liveMargin
+	height := liveHeaderHeight + 2*teamHeight
+
+	dc := gg.NewContext(width, height)
+	dc.SetColor(liveBackground)
+	dc.Clear()
+
+	// Header with game mode and elapsed time
+	dc.SetColor(liveTextColor)
+	dc.DrawStringAnchored(liveHeader(liveMatch), float64(width)/2, liveHeaderHeight/2, 0.5, 0.5)
+
+	champions := assets.catalog("")
+	for teamIndex, team := range liveMatch.Teams {
+		top := liveHeaderHeight + teamIndex*teamHeight
+
+		// Bans of the team
+		dc.SetColor(liveSubTextColor)
+		dc.DrawStringAnchored("Bans", liveMargin, float64(top+liveBanRowHeight/2), 0, 0.5)
+		for i, ban := range liveMatch.TeamBans(team.TeamID) {
+			if ban.ChampionID <= 0 {
+				continue
+			}
+			drawChampion(dc, ban.ChampionID, liveMargin+40+i*(liveIconSize+6), top+(liveBanRowHeight-liveIconSize)/2, liveIconSize)
+		}
+
+		for i, participant := range team.Participants {
+			if i >= 5 {
+				break
+			}
+			x := liveMargin + i*(liveCardWidth+liveMargin)
+			y := top + liveBanRowHeight
+			drawLiveCard(dc, champions, participant, liveTeamColors[teamIndex%2], tracked[participant.Summoner.PUUID], x, y)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := dc.EncodePNG(&buf); err != nil {
+		logger.Logger.Error("Failed to encode live game image", zap.Error(err))
+		return nil, fmt.Errorf("failed to encode live game image: %w", err)
+	}
+	return &buf, nil
+}
+
+func liveHeader(liveMatch *match.Match) string {
+	parts := []string{}
+	if liveMatch.GameMode != "" {
+		parts = append(parts, liveMatch.GameMode)
+	}
+	parts = append(parts, liveMatch.Queue.Name())
+	if elapsed := liveMatch.Elapsed(); elapsed > 0 {
+		parts = append(parts, fmt.Sprintf("%02d:%02d", int(elapsed.Minutes()), int(elapsed.Seconds())%60))
+	}
+	return strings.Join(parts, "  |  ")
+}
+
+func drawLiveCard(dc *gg.Context, champions *catalog.Catalog, participant match.Participant, background color.Color, highlighted bool, x, y int) {
+	dc.SetColor(background)
+	dc.DrawRectangle(float64(x), float64(y), liveCardWidth, liveCardHeight)
+	dc.Fill()
+	if highlighted {
+		dc.SetColor(boardHighlightColor)
+		dc.SetLineWidth(3)
+		dc.DrawRectangle(float64(x)+1.5, float64(y)+1.5, liveCardWidth-3, liveCardHeight-3)
+		dc.Stroke()
+	}
+
+	drawChampion(dc, participant.ChampionID, x+(liveCardWidth-liveChampionSize)/2, y+10, liveChampionSize)
+
+	// Summoner spells on the left, keystone and secondary tree on the right
+	iconY := y + liveChampionSize + 20
+	for i, spellID := range participant.Spells.SpellIDs {
+		if i >= 2 {
+			break
+		}
+		spellFiles, err := assets.spellFiles("", []int{spellID})
+		if err != nil {
+			logger.Logger.Warn("Failed to get spell file", zap.Int("spellID", spellID), zap.Error(err))
+			continue
+		}
+		drawFile(dc, spellFiles[0], x+20+i*(liveIconSize+4), iconY, liveIconSize)
+	}
+
+	for i, runeID := range keystones(participant.Perks) {
+		file, err := assets.runeFile("", runeID)
+		if err != nil {
+			logger.Logger.Warn("Failed to get rune icon", zap.Int("runeID", runeID), zap.Error(err))
+			continue
+		}
+		drawFile(dc, file, x+liveCardWidth-20-(2-i)*(liveIconSize+4), iconY, liveIconSize)
+	}
+
+	// Name, champion, solo rank and win rate
+	textX := float64(x) + liveCardWidth/2
+	dc.SetColor(liveTextColor)
+	dc.DrawStringAnchored(truncate(participant.Summoner.GetNameTag(), 28), textX, float64(iconY+liveIconSize+18), 0.5, 0.5)
+	dc.SetColor(liveSubTextColor)
+	dc.DrawStringAnchored(truncate(champions.ChampionName(participant.ChampionID), 28), textX, float64(iconY+liveIconSize+36), 0.5, 0.5)
+
+	rankText, winRateText := "UNRANKED", ""
+	if participant.SoloEntry != nil {
+		rankText = participant.SoloEntry.Rank().ToString()
+		winRateText = fmt.Sprintf("%.0f%% WR (%d games)", participant.SoloEntry.WinRate(), participant.SoloEntry.Games())
+	} else if participant.Summoner.SoloRank != 0 {
+		rankText = participant.Summoner.SoloRank.ToString()
+	}
+	dc.SetColor(liveTextColor)
+	dc.DrawStringAnchored(rankText, textX, float64(iconY+liveIconSize+54), 0.5, 0.5)
+	dc.SetColor(liveSubTextColor)
+	dc.DrawStringAnchored(winRateText, textX, float64(iconY+liveIconSize+72), 0.5, 0.5)
+}
+
+// drawChampion draws a champion square, falling back to the empty slot image if it is unavailable
+func drawChampion(dc *gg.Context, championID, x, y, size int) {
+	file, err := assets.championSquare(championID)
+	if err != nil {
+		logger.Logger.Warn("Failed to get champion square", zap.Int("championID", championID), zap.Error(err))
+		file, err = assets.placeholder()
+		if err != nil {
+			logger.Logger.Error("Failed to open placeholder image", zap.Error(err))
+			return
+		}
+	}
+	drawFile(dc, file, x, y, size)
+}
+
+// drawFile draws an image file in the given size using the asset cache and closes it
+func drawFile(dc *gg.Context, file *os.File, x, y, size int) {
+	img, err := cachedImage(file, size)
+	if err != nil {
+		logger.Logger.Warn("Failed to decode image", zap.Error(err))
+		return
+	}
+	dc.DrawImage(img, x, y)
+}
+
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-3]) + "..."
+}
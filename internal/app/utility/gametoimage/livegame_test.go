@@ -0,0 +1,42 @@
+package gametoimage
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"discord-bot/types/match"
+	"discord-bot/types/queue"
+	"discord-bot/types/summoner"
+)
+
+func TestLiveGameToImage(t *testing.T) {
+	useFixtureAssets(t)
+
+	liveMatch := &match.Match{
+		Queue: queue.Queue{ID: queue.RankedSoloID},
+		Teams: [2]match.Team{
+			{TeamID: 100, Participants: []match.Participant{{Summoner: summoner.Summoner{PUUID: "tracked", Name: "Tracked", TagLine: "EUW"}}}},
+			{TeamID: 200, Participants: []match.Participant{{Summoner: summoner.Summoner{PUUID: "other", Name: "Other", TagLine: "EUW"}}}},
+		},
+	}
+
+	buf, err := LiveGameToImage(liveMatch, map[string]bool{"tracked": true})
+	if err != nil {
+		t.Fatalf("failed to render live game: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("live game image is not a PNG: %v", err)
+	}
+
+	// The card border of the tracked player is highlighted, the other one keeps its team color
+	top := liveHeaderHeight + liveBanRowHeight
+	bottom := top + liveBanRowHeight + liveCardHeight + liveMargin
+	if r, g, b, _ := img.At(liveMargin+1, top+1).RGBA(); uint8(r>>8) != boardHighlightColor.R || uint8(g>>8) != boardHighlightColor.G || uint8(b>>8) != boardHighlightColor.B {
+		t.Errorf("expected tracked card to be highlighted, got %v", img.At(liveMargin+1, top+1))
+	}
+	if r, g, b, _ := img.At(liveMargin+1, bottom+1).RGBA(); uint8(r>>8) != liveTeamColors[1].R || uint8(g>>8) != liveTeamColors[1].G || uint8(b>>8) != liveTeamColors[1].B {
+		t.Errorf("expected untracked card in team color, got %v", img.At(liveMargin+1, bottom+1))
+	}
+}
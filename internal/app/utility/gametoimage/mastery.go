@@ -0,0 +1,62 @@
+package gametoimage
+
+import (
+	"bytes"
+	"fmt"
+
+	"discord-bot/internal/logger"
+	"discord-bot/types/mastery"
+
+	"github.com/fogleman/gg"
+	"go.uber.org/zap"
+)
+
+// Layout of the mastery image
+const (
+	masteryCardWidth    = 132
+	masteryCardHeight   = 176
+	masteryMargin       = 8
+	masteryChampionSize = 96
+)
+
+// MasteryToImage renders the given champion masteries side by side with champion portrait, name, level and points
+func MasteryToImage(masteries []mastery.ChampionMastery) (*bytes.Buffer, error) {
+	if len(masteries) == 0 {
+		return nil, fmt.Errorf("no champion masteries to render")
+	}
+
+	width := masteryMargin + len(masteries)*(masteryCardWidth+masteryMargin)
+	height := masteryCardHeight + 2*masteryMargin
+	dc := gg.NewContext(width, height)
+	dc.SetColor(liveBackground)
+	dc.Clear()
+
+	champions := assets.catalog("")
+	nameFace, textFace := boldFace(14), regularFace(12)
+	for i, m := range masteries {
+		x := masteryMargin + i*(masteryCardWidth+masteryMargin)
+		y := masteryMargin
+
+		dc.SetColor(liveTeamColors[0])
+		dc.DrawRectangle(float64(x), float64(y), masteryCardWidth, masteryCardHeight)
+		dc.Fill()
+		drawChampion(dc, m.ChampionID, x+(masteryCardWidth-masteryChampionSize)/2, y+8, masteryChampionSize)
+
+		textX := float64(x) + masteryCardWidth/2
+		textY := float64(y + masteryChampionSize + 22)
+		dc.SetFontFace(nameFace)
+		dc.SetColor(liveTextColor)
+		dc.DrawStringAnchored(truncate(champions.ChampionName(m.ChampionID), 16), textX, textY, 0.5, 0.5)
+		dc.SetFontFace(textFace)
+		dc.SetColor(liveSubTextColor)
+		dc.DrawStringAnchored(fmt.Sprintf("Level %d", m.ChampionLevel), textX, textY+20, 0.5, 0.5)
+		dc.DrawStringAnchored(fmt.Sprintf("%s points", formatThousands(m.ChampionPoints)), textX, textY+38, 0.5, 0.5)
+	}
+
+	var buf bytes.Buffer
+	if err := dc.EncodePNG(&buf); err != nil {
+		logger.Logger.Error("Failed to encode mastery image", zap.Error(err))
+		return nil, fmt.Errorf("failed to encode mastery image: %w", err)
+	}
+	return &buf, nil
+}
@@ -0,0 +1,251 @@
+package gametoimage
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+
+	"discord-bot/internal/logger"
+	"discord-bot/types/match"
+	"discord-bot/types/queue"
+	"discord-bot/types/rank"
+
+	"github.com/fogleman/gg"
+	"go.uber.org/zap"
+	"golang.org/x/image/font"
+)
+
+// Layout of the post-game scoreboard
+const (
+	boardWidth        = 1010
+	boardMargin       = 10
+	boardHeaderHeight = 44
+	boardTeamHeight   = 32
+	boardRowHeight    = 56
+	boardChampionSize = 48
+	boardSmallIcon    = 23
+	boardCrestSize    = 28
+	boardItemSize     = 24
+	boardDamageWidth  = 120
+)
+
+// Horizontal positions of the scoreboard columns
+const (
+	columnChampion = boardMargin
+	columnSpells   = columnChampion + boardChampionSize + 4
+	columnRunes    = columnSpells + boardSmallIcon + 2
+	columnCrest    = columnRunes + boardSmallIcon + 8
+	columnName     = columnCrest + boardCrestSize + 6
+	columnKDA      = 400
+	columnCS       = 520
+	columnGold     = 610
+	columnDamage   = 680
+	columnItems    = 820
+)
+
+var (
+	boardWinColor       = color.RGBA{R: 0x1e, G: 0x3a, B: 0x5f, A: 0xff}
+	boardLossColor      = color.RGBA{R: 0x5f, G: 0x1e, B: 0x24, A: 0xff}
+	boardHighlightColor = color.RGBA{R: 0xc8, G: 0x9b, B: 0x3c, A: 0xff}
+	boardDamageColor    = color.RGBA{R: 0xe8, G: 0x40, B: 0x57, A: 0xff}
+	boardBarBackground  = color.RGBA{R: 0x2a, G: 0x2e, B: 0x36, A: 0xff}
+)
+
+// ScoreboardToImage renders both teams of a finished game with champions, spells, keystones, ranks,
+// KDA, CS, gold, damage to champions and items. Participants whose PUUID is in tracked are highlighted.
+func ScoreboardToImage(finishedMatch *match.Match, tracked map[string]bool) (*bytes.Buffer, error) {
+	height := boardHeaderHeight + 2*(boardTeamHeight+5*boardRowHeight) + boardMargin
+
+	dc := gg.NewContext(boardWidth, height)
+	dc.SetColor(liveBackground)
+	dc.Clear()
+
+	titleFace, textFace, smallFace := boldFace(18), boldFace(14), regularFace(12)
+
+	dc.SetFontFace(titleFace)
+	dc.SetColor(liveTextColor)
+	dc.DrawStringAnchored(scoreboardHeader(finishedMatch), boardWidth/2, boardHeaderHeight/2, 0.5, 0.5)
+
+	maxDamage := 1
+	for _, team := range finishedMatch.Teams {
+		for _, participant := range team.Participants {
+			if participant.Stats.DamageDealt > maxDamage {
+				maxDamage = participant.Stats.DamageDealt
+			}
+		}
+	}
+
+	for teamIndex, team := range finishedMatch.Teams {
+		top := boardHeaderHeight + teamIndex*(boardTeamHeight+5*boardRowHeight)
+
+		result, background := "Defeat", boardLossColor
+		if team.Win {
+			result, background = "Victory", boardWinColor
+		}
+		dc.SetFontFace(textFace)
+		dc.SetColor(liveTextColor)
+		dc.DrawStringAnchored(fmt.Sprintf("%s  |  %s", teamName(team.TeamID), result), columnChampion, float64(top+boardTeamHeight/2), 0, 0.5)
+		dc.SetFontFace(smallFace)
+		dc.SetColor(liveSubTextColor)
+		dc.DrawStringAnchored("KDA", columnKDA, float64(top+boardTeamHeight/2), 0, 0.5)
+		dc.DrawStringAnchored("CS", columnCS, float64(top+boardTeamHeight/2), 0, 0.5)
+		dc.DrawStringAnchored("Gold", columnGold, float64(top+boardTeamHeight/2), 0, 0.5)
+		dc.DrawStringAnchored("Damage", columnDamage, float64(top+boardTeamHeight/2), 0, 0.5)
+		dc.DrawStringAnchored(fmt.Sprintf("%d kills", team.Kills()), columnItems, float64(top+boardTeamHeight/2), 0, 0.5)
+
+		for i, participant := range team.Participants {
+			if i >= 5 {
+				break
+			}
+			y := top + boardTeamHeight + i*boardRowHeight
+			drawScoreboardRow(dc, finishedMatch, participant, background, tracked[participant.Summoner.PUUID], maxDamage, y, textFace, smallFace)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := dc.EncodePNG(&buf); err != nil {
+		logger.Logger.Error("Failed to encode scoreboard image", zap.Error(err))
+		return nil, fmt.Errorf("failed to encode scoreboard image: %w", err)
+	}
+	return &buf, nil
+}
+
+func scoreboardHeader(finishedMatch *match.Match) string {
+	header := finishedMatch.Queue.Name()
+	if finishedMatch.Duration > 0 {
+		header += fmt.Sprintf("  |  %02d:%02d", int(finishedMatch.Duration.Minutes()), int(finishedMatch.Duration.Seconds())%60)
+	}
+	return header
+}
+
+func teamName(teamID int) string {
+	if teamID == 200 {
+		return "Red Team"
+	}
+	return "Blue Team"
+}
+
+func drawScoreboardRow(dc *gg.Context, finishedMatch *match.Match, participant match.Participant, background color.Color, highlighted bool, maxDamage, y int, textFace, smallFace font.Face) {
+	rowTop := float64(y + 2)
+	rowHeight := float64(boardRowHeight - 4)
+
+	dc.SetColor(background)
+	dc.DrawRectangle(boardMargin/2, rowTop, boardWidth-boardMargin, rowHeight)
+	dc.Fill()
+	if highlighted {
+		dc.SetColor(boardHighlightColor)
+		dc.SetLineWidth(2)
+		dc.DrawRectangle(boardMargin/2+1, rowTop+1, boardWidth-boardMargin-2, rowHeight-2)
+		dc.Stroke()
+	}
+
+	iconTop := y + (boardRowHeight-boardChampionSize)/2
+	drawChampion(dc, participant.ChampionID, columnChampion, iconTop, boardChampionSize)
+
+	// Summoner spells and keystone with secondary tree, stacked in two columns
+	for i, spellID := range participant.Spells.SpellIDs {
+		if i >= 2 {
+			break
+		}
+		spellFiles, err := assets.spellFiles(finishedMatch.GameVersion, []int{spellID})
+		if err != nil {
+			logger.Logger.Warn("Failed to get spell file", zap.Int("spellID", spellID), zap.Error(err))
+			continue
+		}
+		drawFile(dc, spellFiles[0], columnSpells, iconTop+i*(boardSmallIcon+2), boardSmallIcon)
+	}
+	for i, runeID := range keystones(participant.Perks) {
+		file, err := assets.runeFile(finishedMatch.GameVersion, runeID)
+		if err != nil {
+			logger.Logger.Warn("Failed to get rune icon", zap.Int("runeID", runeID), zap.Error(err))
+			continue
+		}
+		drawFile(dc, file, columnRunes, iconTop+i*(boardSmallIcon+2), boardSmallIcon)
+	}
+
+	// Rank crest, Riot ID and rank of the queue that was played
+	participantRank := participant.Summoner.SoloRank
+	if finishedMatch.Queue.Category() == queue.CategoryFlex {
+		participantRank = participant.Summoner.FlexRank
+	}
+	if tier := participantRank.Tier(); tier != "UNRANKED" {
+		if file, err := assets.rankCrest(tier); err == nil {
+			drawFile(dc, file, columnCrest, y+(boardRowHeight-boardCrestSize)/2, boardCrestSize)
+		} else {
+			logger.Logger.Warn("Failed to get rank crest", zap.String("tier", tier), zap.Error(err))
+		}
+	}
+
+	centerY := float64(y + boardRowHeight/2)
+	dc.SetFontFace(textFace)
+	dc.SetColor(liveTextColor)
+	dc.DrawStringAnchored(truncate(participant.Summoner.GetNameTag(), 26), columnName, centerY-8, 0, 0.5)
+	dc.SetFontFace(smallFace)
+	dc.SetColor(liveSubTextColor)
+	champion := assets.catalog(finishedMatch.GameVersion).ChampionName(participant.ChampionID)
+	dc.DrawStringAnchored(truncate(champion, 16)+"  |  "+rankText(participantRank), columnName, centerY+10, 0, 0.5)
+
+	// Statistics
+	stats := participant.Stats
+	dc.SetFontFace(textFace)
+	dc.SetColor(liveTextColor)
+	dc.DrawStringAnchored(fmt.Sprintf("%d / %d / %d", stats.Kills, stats.Deaths, stats.Assists), columnKDA, centerY-8, 0, 0.5)
+	dc.DrawStringAnchored(fmt.Sprintf("%d", stats.CreepScore), columnCS, centerY-8, 0, 0.5)
+	dc.DrawStringAnchored(formatThousands(stats.GoldEarned), columnGold, centerY-8, 0, 0.5)
+	dc.DrawStringAnchored(formatThousands(stats.DamageDealt), columnDamage, centerY-8, 0, 0.5)
+
+	dc.SetFontFace(smallFace)
+	dc.SetColor(liveSubTextColor)
+	dc.DrawStringAnchored(fmt.Sprintf("%.2f KDA", stats.KDA()), columnKDA, centerY+10, 0, 0.5)
+	if minutes := finishedMatch.Duration.Minutes(); minutes > 0 {
+		dc.DrawStringAnchored(fmt.Sprintf("%.1f/min", float64(stats.CreepScore)/minutes), columnCS, centerY+10, 0, 0.5)
+	}
+
+	// Damage bar relative to the highest damage of the game
+	dc.SetColor(boardBarBackground)
+	dc.DrawRectangle(columnDamage, centerY+6, boardDamageWidth, 8)
+	dc.Fill()
+	dc.SetColor(boardDamageColor)
+	dc.DrawRectangle(columnDamage, centerY+6, float64(boardDamageWidth*stats.DamageDealt/maxDamage), 8)
+	dc.Fill()
+
+	// Items with the trinket last
+	for i, itemID := range participant.Items.ItemIDs {
+		if i >= 7 {
+			break
+		}
+		itemFiles, err := assets.itemFiles(finishedMatch.GameVersion, []int{itemID})
+		if err != nil {
+			logger.Logger.Warn("Failed to get item file", zap.Int("itemID", itemID), zap.Error(err))
+			continue
+		}
+		drawFile(dc, itemFiles[0], columnItems+i*(boardItemSize+2), y+(boardRowHeight-boardItemSize)/2, boardItemSize)
+	}
+}
+
+// keystones returns the keystone and the secondary rune tree of a rune page
+func keystones(perks match.Perks) []int {
+	runeIDs := []int{}
+	if len(perks.PerkIDs) > 0 {
+		runeIDs = append(runeIDs, perks.PerkIDs[0])
+	}
+	if perks.PerkSubStyle != 0 {
+		runeIDs = append(runeIDs, perks.PerkSubStyle)
+	}
+	return runeIDs
+}
+
+func rankText(r rank.Rank) string {
+	if r == 0 {
+		return "UNRANKED"
+	}
+	return r.ToString()
+}
+
+// formatThousands shortens numbers like 12345 to "12.3k"
+func formatThousands(value int) string {
+	if value < 1000 {
+		return fmt.Sprintf("%d", value)
+	}
+	return fmt.Sprintf("%.1fk", float64(value)/1000)
+}
@@ -0,0 +1,50 @@
+package gametoimage
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+
+	"discord-bot/types/match"
+	"discord-bot/types/queue"
+)
+
+func TestFormatThousands(t *testing.T) {
+	tests := map[int]string{
+		0:     "0",
+		999:   "999",
+		1000:  "1.0k",
+		12345: "12.3k",
+	}
+	for value, expected := range tests {
+		if got := formatThousands(value); got != expected {
+			t.Errorf("formatThousands(%d): expected %s, got %s", value, expected, got)
+		}
+	}
+}
+
+func TestScoreboardToImage(t *testing.T) {
+	useFixtureAssets(t)
+
+	finishedMatch := &match.Match{
+		Queue:    queue.Queue{ID: queue.RankedSoloID},
+		Duration: 31*time.Minute + 24*time.Second,
+		Teams:    [2]match.Team{{TeamID: 100, Win: true}, {TeamID: 200}},
+	}
+
+	buf, err := ScoreboardToImage(finishedMatch, nil)
+	if err != nil {
+		t.Fatalf("failed to render scoreboard: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("scoreboard is not a PNG: %v", err)
+	}
+	if img.Bounds().Dx() != boardWidth {
+		t.Errorf("expected width %d, got %d", boardWidth, img.Bounds().Dx())
+	}
+	if header := scoreboardHeader(finishedMatch); header != "Solo/Duo  |  31:24" {
+		t.Errorf("unexpected header %q", header)
+	}
+}
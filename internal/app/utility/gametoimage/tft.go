@@ -0,0 +1,144 @@
+package gametoimage
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+
+	"discord-bot/internal/logger"
+	"discord-bot/types/tft"
+
+	"github.com/fogleman/gg"
+	"go.uber.org/zap"
+)
+
+// Layout of the TFT board image
+const (
+	tftUnitsPerRow    = 5
+	tftMargin         = 10
+	tftHeaderHeight   = 36
+	tftTraitHeight    = 24
+	tftTraitsPerRow   = 5
+	tftTraitWidth     = 150
+	tftUnitCardWidth  = 110
+	tftUnitCardHeight = 148
+	tftUnitSize       = 72
+	tftBorderWidth    = 3
+)
+
+var (
+	// tftCostColors are the border colors of units by gold cost, index 0 is unused
+	tftCostColors = [6]color.RGBA{
+		{R: 0x80, G: 0x80, B: 0x80, A: 0xff},
+		{R: 0x80, G: 0x80, B: 0x80, A: 0xff},
+		{R: 0x11, G: 0xb2, B: 0x88, A: 0xff},
+		{R: 0x20, G: 0x7a, B: 0xc7, A: 0xff},
+		{R: 0xc4, G: 0x40, B: 0xda, A: 0xff},
+		{R: 0xff, G: 0xb9, B: 0x3b, A: 0xff},
+	}
+	// tftStyleColors are the chip colors of active traits by style
+	tftStyleColors = map[int]color.RGBA{
+		tft.StyleBronze: {R: 0x8c, G: 0x5a, B: 0x3c, A: 0xff},
+		tft.StyleSilver: {R: 0x7f, G: 0x8c, B: 0x99, A: 0xff},
+		tft.StyleGold:   {R: 0xb8, G: 0x96, B: 0x2e, A: 0xff},
+		tft.StylePrism:  {R: 0x9b, G: 0x59, B: 0xb6, A: 0xff},
+	}
+	tftStarColor = color.RGBA{R: 0xff, G: 0xd7, B: 0x00, A: 0xff}
+)
+
+// TFTBoardToImage renders the final board of a TFT participant with placement, level, active traits
+// and the units with their star level and items. Unit portraits are taken from the given set.
+func TFTBoardToImage(participant tft.Participant, setNumber int) (*bytes.Buffer, error) {
+	traits := participant.ActiveTraits()
+	traitRows := (len(traits) + tftTraitsPerRow - 1) / tftTraitsPerRow
+	unitRows := (len(participant.Units) + tftUnitsPerRow - 1) / tftUnitsPerRow
+	if unitRows == 0 {
+		unitRows = 1
+	}
+
+	width := tftMargin + tftUnitsPerRow*(tftUnitCardWidth+tftMargin)
+	if traitWidth := tftMargin + tftTraitsPerRow*(tftTraitWidth+tftMargin); len(traits) > 0 && traitWidth > width {
+		width = traitWidth
+	}
+	unitsTop := tftHeaderHeight + traitRows*(tftTraitHeight+tftMargin)
+	height := unitsTop + unitRows*(tftUnitCardHeight+tftMargin)
+
+	dc := gg.NewContext(width, height)
+	dc.SetColor(liveBackground)
+	dc.Clear()
+
+	// Header with placement and level
+	dc.SetFontFace(boldFace(16))
+	dc.SetColor(liveTextColor)
+	dc.DrawStringAnchored(fmt.Sprintf("%s place  |  Level %d", tft.Ordinal(participant.Placement), participant.Level), float64(width)/2, tftHeaderHeight/2, 0.5, 0.5)
+
+	dc.SetFontFace(regularFace(12))
+	for i, trait := range traits {
+		x := tftMargin + (i%tftTraitsPerRow)*(tftTraitWidth+tftMargin)
+		y := tftHeaderHeight + (i/tftTraitsPerRow)*(tftTraitHeight+tftMargin)
+		dc.SetColor(tftStyleColors[trait.Style])
+		dc.DrawRoundedRectangle(float64(x), float64(y), tftTraitWidth, tftTraitHeight, 6)
+		dc.Fill()
+		dc.SetColor(liveTextColor)
+		dc.DrawStringAnchored(truncate(fmt.Sprintf("%d %s", trait.NumUnits, tft.DisplayName(trait.Name)), 22), float64(x)+tftTraitWidth/2, float64(y)+tftTraitHeight/2, 0.5, 0.5)
+	}
+
+	for i, unit := range participant.Units {
+		x := tftMargin + (i%tftUnitsPerRow)*(tftUnitCardWidth+tftMargin)
+		y := unitsTop + (i/tftUnitsPerRow)*(tftUnitCardHeight+tftMargin)
+		drawTFTUnit(dc, unit, setNumber, x, y)
+	}
+
+	var buf bytes.Buffer
+	if err := dc.EncodePNG(&buf); err != nil {
+		logger.Logger.Error("Failed to encode TFT board image", zap.Error(err))
+		return nil, fmt.Errorf("failed to encode TFT board image: %w", err)
+	}
+	return &buf, nil
+}
+
+// drawTFTUnit draws a unit card with the portrait framed in its cost color, its stars and item names
+func drawTFTUnit(dc *gg.Context, unit tft.Unit, setNumber, x, y int) {
+	dc.SetColor(liveTeamColors[0])
+	dc.DrawRectangle(float64(x), float64(y), tftUnitCardWidth, tftUnitCardHeight)
+	dc.Fill()
+
+	cost := unit.Cost()
+	if cost < 1 || cost >= len(tftCostColors) {
+		cost = 1
+	}
+	unitX := x + (tftUnitCardWidth-tftUnitSize)/2
+	unitY := y + 6
+	dc.SetColor(tftCostColors[cost])
+	dc.DrawRectangle(float64(unitX-tftBorderWidth), float64(unitY-tftBorderWidth), tftUnitSize+2*tftBorderWidth, tftUnitSize+2*tftBorderWidth)
+	dc.Fill()
+
+	file, err := assets.tftUnit(unit.CharacterID, setNumber)
+	if err != nil {
+		logger.Logger.Warn("Failed to get TFT unit portrait", zap.String("characterID", unit.CharacterID), zap.Error(err))
+		file, err = assets.placeholder()
+	}
+	if err == nil {
+		drawFile(dc, file, unitX, unitY, tftUnitSize)
+	}
+
+	// One dot per star level below the portrait
+	dc.SetColor(tftStarColor)
+	starsY := float64(unitY + tftUnitSize + 10)
+	for star := 0; star < unit.Tier; star++ {
+		dc.DrawCircle(float64(x)+tftUnitCardWidth/2+float64(star*12)-float64((unit.Tier-1)*6), starsY, 4)
+		dc.Fill()
+	}
+
+	dc.SetFontFace(boldFace(12))
+	dc.SetColor(liveTextColor)
+	dc.DrawStringAnchored(truncate(tft.DisplayName(unit.CharacterID), 14), float64(x)+tftUnitCardWidth/2, starsY+14, 0.5, 0.5)
+	dc.SetFontFace(regularFace(10))
+	dc.SetColor(liveSubTextColor)
+	for i, item := range unit.Items {
+		if i >= 3 {
+			break
+		}
+		dc.DrawStringAnchored(truncate(tft.DisplayName(item), 18), float64(x)+tftUnitCardWidth/2, starsY+28+float64(i*12), 0.5, 0.5)
+	}
+}
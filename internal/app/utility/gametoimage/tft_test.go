@@ -0,0 +1,50 @@
+package gametoimage
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"discord-bot/types/tft"
+)
+
+func TestTFTBoardToImage(t *testing.T) {
+	useFixtureAssets(t)
+
+	participant := tft.Participant{
+		Placement: 2,
+		Level:     8,
+		Traits: []tft.Trait{
+			{Name: "TFT13_Sorcerer", NumUnits: 4, Style: tft.StyleGold},
+			{Name: "TFT13_Inactive", NumUnits: 1, Style: tft.StyleInactive},
+		},
+		Units: []tft.Unit{
+			{CharacterID: "TFT13_Jinx", Tier: 2, Rarity: 4, Items: []string{"TFT_Item_InfinityEdge"}},
+			{CharacterID: "TFT13_Vi", Tier: 3, Rarity: 0},
+		},
+	}
+
+	buf, err := TFTBoardToImage(participant, 13)
+	if err != nil {
+		t.Fatalf("failed to render TFT board: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("TFT board image is not a PNG: %v", err)
+	}
+
+	// One row of traits and one row of units
+	wantHeight := tftHeaderHeight + tftTraitHeight + tftMargin + tftUnitCardHeight + tftMargin
+	if img.Bounds().Dy() != wantHeight {
+		t.Errorf("expected height %d, got %d", wantHeight, img.Bounds().Dy())
+	}
+
+	// The portrait of the four cost unit is framed in its cost color
+	unitsTop := tftHeaderHeight + tftTraitHeight + tftMargin
+	x := tftMargin + (tftUnitCardWidth-tftUnitSize)/2 - tftBorderWidth
+	y := unitsTop + 6 - tftBorderWidth
+	want := tftCostColors[4]
+	if r, g, b, _ := img.At(x, y).RGBA(); uint8(r>>8) != want.R || uint8(g>>8) != want.G || uint8(b>>8) != want.B {
+		t.Errorf("expected border in cost color %v, got %v", want, img.At(x, y))
+	}
+}
@@ -0,0 +1,318 @@
+package gametoimage
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"discord-bot/internal/logger"
+
+	"github.com/fogleman/gg"
+	"go.uber.org/zap"
+)
+
+// DefaultTheme is the build image layout used unless a guild chooses another one
+const DefaultTheme = "classic"
+
+// templateImage is the canvas image of themes using the bundled build template
+const templateImage = "template"
+
+// Kinds of theme slots
+const (
+	slotItem     = "item"
+	slotSpell    = "spell"
+	slotRune     = "rune" // Index 0 is the keystone, index 1 the secondary tree
+	slotChampion = "champion"
+	slotText     = "text"
+)
+
+// textFields are the participant values a text slot can show
+var textFields = map[string]bool{
+	"name": true, "champion": true, "kda": true, "cs": true, "gold": true, "damage": true,
+}
+
+//go:embed themes/*.json
+var builtinThemes embed.FS
+
+// Theme describes the layout of a build image, themes are JSON files so they can be changed without recompiling
+type Theme struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Canvas      Canvas `json:"canvas"`
+	Slots       []Slot `json:"slots"`
+
+	dir string // Directory of the theme file, relative canvas images are resolved against it
+}
+
+// Canvas is the background of a theme, either an image or a solid color of the given size
+type Canvas struct {
+	Image  string `json:"image"` // "template" for the bundled build template, otherwise a PNG relative to the theme file
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Color  string `json:"color"` // Hex color like "#10141a", also drawn below a transparent image
+}
+
+// Slot is a position on the canvas showing an icon or a line of text
+type Slot struct {
+	Kind     string  `json:"kind"`
+	Index    int     `json:"index"` // Which item, spell or rune of the participant is shown
+	X        int     `json:"x"`
+	Y        int     `json:"y"`
+	Size     int     `json:"size"`     // Edge length of icons
+	Text     string  `json:"text"`     // Value shown by text slots, see textFields
+	Font     string  `json:"font"`     // "regular" or "bold"
+	FontSize float64 `json:"fontSize"` // Font size of text slots in points
+	Color    string  `json:"color"`    // Hex color of text slots
+	Align    string  `json:"align"`    // "left", "center" or "right" of X, text is vertically centered on Y
+}
+
+var (
+	themesMu sync.RWMutex
+	themes   = make(map[string]*Theme)
+)
+
+func init() {
+	entries, err := fs.ReadDir(builtinThemes, "themes")
+	if err != nil {
+		logger.Logger.Error("Failed to read built-in themes", zap.Error(err))
+		return
+	}
+	for _, entry := range entries {
+		data, err := builtinThemes.ReadFile("themes/" + entry.Name())
+		if err != nil {
+			logger.Logger.Error("Failed to read built-in theme", zap.String("file", entry.Name()), zap.Error(err))
+			continue
+		}
+		theme, err := ParseTheme(data)
+		if err != nil {
+			logger.Logger.Error("Invalid built-in theme", zap.String("file", entry.Name()), zap.Error(err))
+			continue
+		}
+		themes[theme.Name] = theme
+	}
+}
+
+// InitializeThemes loads the custom themes from THEME_DIR, if it is set
+func InitializeThemes() {
+	dir := os.Getenv("THEME_DIR")
+	if dir == "" {
+		return
+	}
+	if err := LoadThemes(dir); err != nil {
+		logger.Logger.Error("Failed to load custom themes", zap.String("dir", dir), zap.Error(err))
+	}
+}
+
+// LoadThemes adds every *.json theme in dir, replacing themes with the same name.
+// Invalid themes are logged and skipped so one broken file does not disable the others.
+func LoadThemes(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to list themes: %w", err)
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logger.Logger.Error("Failed to read theme", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		theme, err := ParseTheme(data)
+		if err != nil {
+			logger.Logger.Error("Invalid theme", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		theme.dir = dir
+
+		themesMu.Lock()
+		themes[theme.Name] = theme
+		themesMu.Unlock()
+		logger.Logger.Info("Loaded theme", zap.String("theme", theme.Name), zap.String("path", path))
+	}
+	return nil
+}
+
+// ParseTheme decodes and validates a theme
+func ParseTheme(data []byte) (*Theme, error) {
+	var theme Theme
+	if err := json.Unmarshal(data, &theme); err != nil {
+		return nil, fmt.Errorf("failed to decode theme: %w", err)
+	}
+	if err := theme.validate(); err != nil {
+		return nil, fmt.Errorf("theme %q: %w", theme.Name, err)
+	}
+	return &theme, nil
+}
+
+func (t *Theme) validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("name is missing")
+	}
+	if t.Canvas.Image == "" && (t.Canvas.Width <= 0 || t.Canvas.Height <= 0) {
+		return fmt.Errorf("canvas needs an image or a width and height")
+	}
+	if t.Canvas.Color != "" {
+		if _, err := parseHexColor(t.Canvas.Color); err != nil {
+			return fmt.Errorf("canvas: %w", err)
+		}
+	}
+
+	for i, slot := range t.Slots {
+		switch slot.Kind {
+		case slotItem, slotSpell, slotRune, slotChampion:
+			if slot.Size <= 0 {
+				return fmt.Errorf("slot %d: size must be positive", i)
+			}
+			if slot.Index < 0 {
+				return fmt.Errorf("slot %d: index must not be negative", i)
+			}
+		case slotText:
+			if !textFields[slot.Text] {
+				return fmt.Errorf("slot %d: unknown text %q", i, slot.Text)
+			}
+			if slot.FontSize < 0 {
+				return fmt.Errorf("slot %d: font size must not be negative", i)
+			}
+			if slot.Color != "" {
+				if _, err := parseHexColor(slot.Color); err != nil {
+					return fmt.Errorf("slot %d: %w", i, err)
+				}
+			}
+		default:
+			return fmt.Errorf("slot %d: unknown kind %q", i, slot.Kind)
+		}
+	}
+	return nil
+}
+
+// GetTheme returns a theme by name, the default theme if it does not exist
+func GetTheme(name string) *Theme {
+	themesMu.RLock()
+	defer themesMu.RUnlock()
+	if theme, ok := themes[name]; ok {
+		return theme
+	}
+	return themes[DefaultTheme]
+}
+
+// HasTheme reports whether a theme with the given name is loaded
+func HasTheme(name string) bool {
+	themesMu.RLock()
+	defer themesMu.RUnlock()
+	_, ok := themes[name]
+	return ok
+}
+
+// Themes returns all loaded themes sorted by name
+func Themes() []*Theme {
+	themesMu.RLock()
+	defer themesMu.RUnlock()
+	list := make([]*Theme, 0, len(themes))
+	for _, theme := range themes {
+		list = append(list, theme)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// compose draws the slots of the theme onto a copy of background. icon returns the image of the i-th slot,
+// nil leaves the slot empty. text returns the value of a text slot. background is not modified.
+func (t *Theme) compose(background image.Image, icon func(i int, slot Slot) image.Image, text func(string) string) image.Image {
+	dc := gg.NewContext(t.Canvas.Width, t.Canvas.Height)
+	if background != nil {
+		dc = gg.NewContext(background.Bounds().Dx(), background.Bounds().Dy())
+	}
+	if t.Canvas.Color != "" {
+		c, _ := parseHexColor(t.Canvas.Color)
+		dc.SetColor(c)
+		dc.Clear()
+	}
+	if background != nil {
+		dc.DrawImage(background, 0, 0)
+	}
+
+	for i, slot := range t.Slots {
+		if slot.Kind != slotText {
+			if img := icon(i, slot); img != nil {
+				dc.DrawImage(img, slot.X, slot.Y)
+			}
+			continue
+		}
+
+		value := text(slot.Text)
+		if value == "" {
+			continue
+		}
+		fontSize := slot.FontSize
+		if fontSize == 0 {
+			fontSize = 14
+		}
+		if slot.Font == "bold" {
+			dc.SetFontFace(boldFace(fontSize))
+		} else {
+			dc.SetFontFace(regularFace(fontSize))
+		}
+		textColor := color.Color(color.White)
+		if slot.Color != "" {
+			textColor, _ = parseHexColor(slot.Color)
+		}
+		dc.SetColor(textColor)
+		dc.DrawStringAnchored(value, float64(slot.X), float64(slot.Y), alignment(slot.Align), 0.5)
+	}
+	return dc.Image()
+}
+
+// canvasImage loads the background image of the theme, nil if the theme uses a solid color
+func (t *Theme) canvasImage(open func() (*os.File, error)) (image.Image, error) {
+	switch t.Canvas.Image {
+	case "":
+		return nil, nil
+	case templateImage:
+		file, err := open()
+		if err != nil {
+			return nil, err
+		}
+		return cachedImage(file, 0)
+	default:
+		file, err := os.Open(filepath.Join(t.dir, filepath.Clean(t.Canvas.Image)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open canvas image: %w", err)
+		}
+		return cachedImage(file, 0)
+	}
+}
+
+func alignment(align string) float64 {
+	switch align {
+	case "center":
+		return 0.5
+	case "right":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// parseHexColor parses colors like "#10141a" or "#10141aff"
+func parseHexColor(value string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) != 6 && len(hex) != 8 {
+		return color.RGBA{}, fmt.Errorf("invalid color %q", value)
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	rgba, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color %q", value)
+	}
+	return color.RGBA{R: uint8(rgba >> 24), G: uint8(rgba >> 16), B: uint8(rgba >> 8), A: uint8(rgba)}, nil
+}
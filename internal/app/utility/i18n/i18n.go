@@ -0,0 +1,136 @@
+package i18n
+
+import "fmt"
+
+// DefaultLanguage is used for unknown languages and missing translations
+const DefaultLanguage = "en"
+
+// Keys of the translated notification texts
+const (
+	RankUpdateTitle   = "rank_update_title"
+	MatchStartedTitle = "match_started_title"
+	SoloRank          = "solo_rank"
+	FlexRank          = "flex_rank"
+	YourTeamAverage   = "your_team_average"
+	EnemyTeamAverage  = "enemy_team_average"
+	Champion          = "champion"
+	NameChanged       = "name_changed"
+	RegionTransferred = "region_transferred"
+	MasteryLevel      = "mastery_level"
+	MasteryPoints     = "mastery_points"
+	MasteryPointsSum  = "mastery_points_sum"
+	TFTStartedTitle   = "tft_started_title"
+	TFTResultTitle    = "tft_result_title"
+	TFTRank           = "tft_rank"
+	TFTPlacement      = "tft_placement"
+	QueueResultTitle  = "queue_result_title"
+	ArenaResultTitle  = "arena_result_title"
+	Victory           = "victory"
+	Defeat            = "defeat"
+	KDA               = "kda"
+	ClashRegistered   = "clash_registered"
+	ClashDayTitle     = "clash_day_title"
+	ClashTeam         = "clash_team"
+	ClashStart        = "clash_start"
+	ClashPosition     = "clash_position"
+	StatusIncident    = "status_incident"
+	StatusMaintenance = "status_maintenance"
+	StatusState       = "status_state"
+	StatusScheduled   = "status_scheduled"
+	StatusInProgress  = "status_in_progress"
+	StatusResolved    = "status_resolved"
+)
+
+var translations = map[string]map[string]string{
+	"en": {
+		RankUpdateTitle:   "%v-Rank Update | %v LP",
+		MatchStartedTitle: "A %v-Match has started!",
+		SoloRank:          "Solo/Duo-Rank",
+		FlexRank:          "Flex-Rank",
+		YourTeamAverage:   "Your Team Average Rank",
+		EnemyTeamAverage:  "Enemy Team Average Rank",
+		Champion:          "Champion",
+		NameChanged:       "%v is now known as %v",
+		RegionTransferred: "%v moved from %v to %v",
+		MasteryLevel:      "%v reached mastery level %v on %v",
+		MasteryPoints:     "%v passed %v mastery points on %v",
+		MasteryPointsSum:  "Mastery points",
+		TFTStartedTitle:   "A ranked TFT game has started!",
+		TFTResultTitle:    "TFT-Rank Update | %v | %v LP",
+		TFTRank:           "TFT-Rank",
+		TFTPlacement:      "Placement",
+		QueueResultTitle:  "%v | %v",
+		ArenaResultTitle:  "Arena | Place %v",
+		Victory:           "Victory",
+		Defeat:            "Defeat",
+		KDA:               "KDA",
+		ClashRegistered:   "Registered for %v!",
+		ClashDayTitle:     "%v starts soon!",
+		ClashTeam:         "Clash team",
+		ClashStart:        "Start",
+		ClashPosition:     "Position",
+		StatusIncident:    "Riot incident on %v",
+		StatusMaintenance: "Riot maintenance on %v",
+		StatusState:       "Status",
+		StatusScheduled:   "Scheduled",
+		StatusInProgress:  "In progress",
+		StatusResolved:    "Resolved",
+	},
+	"de": {
+		RankUpdateTitle:   "%v-Rang Update | %v LP",
+		MatchStartedTitle: "Ein %v-Match hat begonnen!",
+		SoloRank:          "Solo/Duo-Rang",
+		FlexRank:          "Flex-Rang",
+		YourTeamAverage:   "Durchschnittsrang deines Teams",
+		EnemyTeamAverage:  "Durchschnittsrang des Gegnerteams",
+		Champion:          "Champion",
+		NameChanged:       "%v heißt jetzt %v",
+		RegionTransferred: "%v ist von %v nach %v umgezogen",
+		MasteryLevel:      "%v hat Meisterschaftsstufe %v auf %v erreicht",
+		MasteryPoints:     "%v hat %v Meisterschaftspunkte auf %v überschritten",
+		MasteryPointsSum:  "Meisterschaftspunkte",
+		TFTStartedTitle:   "Ein Ranked-TFT-Spiel hat begonnen!",
+		TFTResultTitle:    "TFT-Rang Update | %v | %v LP",
+		TFTRank:           "TFT-Rang",
+		TFTPlacement:      "Platzierung",
+		QueueResultTitle:  "%v | %v",
+		ArenaResultTitle:  "Arena | Platz %v",
+		Victory:           "Sieg",
+		Defeat:            "Niederlage",
+		KDA:               "KDA",
+		ClashRegistered:   "Für %v angemeldet!",
+		ClashDayTitle:     "%v beginnt bald!",
+		ClashTeam:         "Clash-Team",
+		ClashStart:        "Beginn",
+		ClashPosition:     "Position",
+		StatusIncident:    "Riot-Störung auf %v",
+		StatusMaintenance: "Riot-Wartung auf %v",
+		StatusState:       "Status",
+		StatusScheduled:   "Geplant",
+		StatusInProgress:  "Läuft",
+		StatusResolved:    "Behoben",
+	},
+}
+
+// Languages returns the supported language codes
+func Languages() []string {
+	return []string{"en", "de"}
+}
+
+// IsSupported reports whether texts are available in the given language
+func IsSupported(language string) bool {
+	_, ok := translations[language]
+	return ok
+}
+
+// T returns the text for key in the given language, formatted with args
+func T(language, key string, args ...interface{}) string {
+	text, ok := translations[language][key]
+	if !ok {
+		text = translations[DefaultLanguage][key]
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket per key, e.g. per user or per guild.
+// Every bucket holds up to capacity tokens and regains one token every refill interval.
+type Limiter struct {
+	mu       sync.Mutex
+	capacity int
+	refill   time.Duration
+	buckets  map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter creates a limiter allowing bursts of capacity and one request per refill interval afterwards
+func NewLimiter(capacity int, refill time.Duration) *Limiter {
+	return &Limiter{
+		capacity: capacity,
+		refill:   refill,
+		buckets:  make(map[string]*bucket),
+	}
+}
+
+// Allow consumes a token of key if available, otherwise it returns the time until the next token
+func (l *Limiter) Allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.capacity), last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens += float64(now.Sub(b.last)) / float64(l.refill)
+	if b.tokens > float64(l.capacity) {
+		b.tokens = float64(l.capacity)
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) * float64(l.refill))
+}
+
+// Cleanup removes buckets that are full again so idle keys do not pile up
+func (l *Limiter) Cleanup(now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	full := time.Duration(l.capacity) * l.refill
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= full {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterBurstAndRefill(t *testing.T) {
+	l := NewLimiter(3, 10*time.Second)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.Allow("user", now); !ok {
+			t.Fatalf("Expected request %d of the burst to be allowed", i+1)
+		}
+	}
+
+	ok, wait := l.Allow("user", now)
+	if ok {
+		t.Fatalf("Expected the fourth request to be rejected")
+	}
+	if wait != 10*time.Second {
+		t.Errorf("Expected to wait 10s, got %v", wait)
+	}
+
+	if ok, _ := l.Allow("other", now); !ok {
+		t.Errorf("Expected keys to have separate buckets")
+	}
+
+	if ok, _ := l.Allow("user", now.Add(10*time.Second)); !ok {
+		t.Errorf("Expected a token after one refill interval")
+	}
+	if ok, _ := l.Allow("user", now.Add(10*time.Second)); ok {
+		t.Errorf("Expected only one token after one refill interval")
+	}
+}
+
+func TestLimiterCleanup(t *testing.T) {
+	l := NewLimiter(2, time.Second)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	l.Allow("idle", now)
+	l.Allow("busy", now.Add(2*time.Second))
+	l.Cleanup(now.Add(2 * time.Second))
+
+	if _, ok := l.buckets["idle"]; ok {
+		t.Errorf("Expected the idle bucket to be removed")
+	}
+	if _, ok := l.buckets["busy"]; !ok {
+		t.Errorf("Expected the busy bucket to be kept")
+	}
+}
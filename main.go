@@ -2,19 +2,26 @@ package main
 
 import (
 	"flag"
-	"fmt"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
-	"sort"
 	"time"
 
-	"discord-bot/internal/app/constants"
+	"discord-bot/internal/app/commands"
 	"discord-bot/internal/app/features/checkforsummonerupdate"
+	"discord-bot/internal/app/features/clash"
+	"discord-bot/internal/app/features/digest"
+	"discord-bot/internal/app/features/identity"
+	"discord-bot/internal/app/features/mastery"
 	"discord-bot/internal/app/features/offboarding"
-	"discord-bot/internal/app/features/onboarding"
+	"discord-bot/internal/app/features/permissions"
+	"discord-bot/internal/app/features/roles"
+	"discord-bot/internal/app/features/status"
+	"discord-bot/internal/app/features/tft"
+	"discord-bot/internal/app/helper/assetprovider"
 	databaseHelper "discord-bot/internal/app/helper/database"
+	"discord-bot/internal/app/utility/gametoimage"
 	"discord-bot/internal/logger"
 
 	"github.com/bwmarrin/discordgo"
@@ -55,180 +62,19 @@ func init() {
 	}
 }
 
-var (
-	// commands is a slice of ApplicationCommand pointers that defines the available commands for the Discord bot.
-	// The following commands are included:
-	// - add: Adds a new summoner with the required options "name" (Ingame Name) and "tag" (Your Riot Tag).
-	// - ping: Responds with "Pong!".
-	// - delete: Deletes a summoner with the required options "name" (Ingame Name) and "tag" (Your Riot Tag).
-	commands = []*discordgo.ApplicationCommand{
-		{
-			Name:        "add",
-			Description: "Add a new Summoner",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "region",
-					Description: "Your League Region",
-					Required:    true,
-					Choices: func() []*discordgo.ApplicationCommandOptionChoice {
-						choices := []*discordgo.ApplicationCommandOptionChoice{}
-						for _, key := range constants.GetPlatformKeys() {
-							choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
-								Name:  key,
-								Value: key,
-							})
-						}
-						sort.Slice(choices, func(i, j int) bool {
-							return choices[i].Name < choices[j].Name
-						})
-						return choices
-					}(),
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "name",
-					Description: "Ingame Name",
-					Required:    true,
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "tag",
-					Description: "Your Riot Tag",
-					Required:    true,
-				},
-			},
-		},
-
-		// - ping: Responds with "Pong!".
-		{
-			Name:        "ping",
-			Description: "Responds with Pong!",
-		},
-
-		// - delete: Deletes a summoner with the required options "name" (Ingame Name) and "tag" (Your Riot Tag).
-		{
-			Name:        "delete",
-			Description: "Delete a summoner",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "name",
-					Description: "Ingame Name",
-					Required:    true,
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "tag",
-					Description: "Your Riot Tag",
-					Required:    true,
-				},
-			},
-		},
-	}
-
-	commandHandlers = map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate){
-		"add": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			options := i.ApplicationCommandData().Options
-			region := options[0].StringValue()
-			name := options[1].StringValue()
-			tag := options[2].StringValue()
-			go func() {
-				s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-					Type: discordgo.InteractionResponseChannelMessageWithSource,
-					Data: &discordgo.InteractionResponseData{
-						Content: "Adding " + name + " " + tag + "... Waiting for RIOT API. Depending on the server load, this may take a while.",
-					},
-				})
-			}()
-			message, err := onboarding.OnboardSummoner(name, tag, region, i.ChannelID, i.GuildID)
-			if err != nil {
-				errormessage := fmt.Sprintf("Failed to onboard summoner: %v", err)
-				s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-					Content: &errormessage,
-				})
-				return
-			}
-			s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-				Content: new(string),
-				Embeds:  &[]*discordgo.MessageEmbed{message},
-			})
-		},
-		"ping": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			logger.Logger.Debug("Ping command received")
-			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{
-					Content: "Pong!",
-				},
-			})
-		},
-		"delete": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			options := i.ApplicationCommandData().Options
-			gameName := options[0].StringValue()
-			tag := options[1].StringValue()
-			summonerNameTag := fmt.Sprintf("%s#%s", gameName, tag)
-			logger.Logger.Info("Deleting summoner", zap.String("summoner", summonerNameTag))
-
-			err := offboarding.DeleteSummoner(gameName, tag, i.ChannelID)
-			if err != nil {
-				s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-					Type: discordgo.InteractionResponseChannelMessageWithSource,
-					Data: &discordgo.InteractionResponseData{
-						Content: fmt.Sprintf("Failed to delete summoner: %v", err),
-					},
-				})
-				return
-			}
-
-			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{
-					Content: fmt.Sprintf("Summoner %v has been deleted", summonerNameTag),
-				},
-			})
-		},
-	}
-)
-
-func addCommands(s *discordgo.Session, commands []*discordgo.ApplicationCommand) error {
-	for _, guild := range s.State.Guilds {
-		addCommandsForGuild(s, commands, guild.ID)
-	}
-	return nil
-}
-
-func addCommandsForGuild(s *discordgo.Session, commands []*discordgo.ApplicationCommand, guildid string) error {
-
-	logger.Logger.Info("Registering commands for guild", zap.String("guildID", guildid))
-	existingCommands, err := s.ApplicationCommands(s.State.User.ID, guildid)
-	if err != nil {
-		return err
-	}
-
-	// Delete all existing commands
-	for _, cmd := range existingCommands {
-		err := s.ApplicationCommandDelete(s.State.User.ID, guildid, cmd.ID)
-		if err != nil {
-			return err
-		}
-	}
-
-	// Add all new commands
-	for _, cmd := range commands {
-		_, err := s.ApplicationCommandCreate(s.State.User.ID, guildid, cmd)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func onGuildCreate(s *discordgo.Session, g *discordgo.GuildCreate) {
 	logger.Logger.Info("Bot added to a new server", zap.String("server_name", g.Name), zap.String("server_id", g.ID))
 	// Add any additional logic you want to execute when the bot is added to a new server
-	addCommandsForGuild(s, commands, g.ID)
+	// GuildCreate also fires for every guild on connect, so this keeps the commands of all guilds up to date
+	var err error
+	if commands.Global() {
+		err = commands.Clear(s, g.ID)
+	} else {
+		err = commands.Sync(s, g.ID)
+	}
+	if err != nil {
+		logger.Logger.Error("Failed to sync commands for guild", zap.String("server_id", g.ID), zap.Error(err))
+	}
 }
 
 func onGuildDelete(s *discordgo.Session, g *discordgo.GuildDelete) {
@@ -263,16 +109,19 @@ func main() {
 
 	s.AddHandler(func(s *discordgo.Session, r *discordgo.Ready) {
 		logger.Logger.Info("Logged in as", zap.String("username", s.State.User.Username), zap.String("discriminator", s.State.User.Discriminator))
+		if commands.Global() {
+			if err := commands.Sync(s, ""); err != nil {
+				logger.Logger.Error("Failed to sync global commands", zap.Error(err))
+			}
+		}
 	})
 
 	// Add the new interaction handler
-	s.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		if h, ok := commandHandlers[i.ApplicationCommandData().Name]; ok {
-			h(s, i)
-		}
-	})
+	s.AddHandler(commands.HandleInteraction)
 
-	addCommands(s, commands)
+	permissions.Initialize()
+	assetprovider.Initialize()
+	gametoimage.InitializeThemes()
 
 	s.AddHandler(onGuildCreate)
 	s.AddHandler(onGuildDelete)
@@ -286,11 +135,30 @@ func main() {
 	// Initialize the checkforsummonerupdate package
 	logger.Logger.Info("Initializing checkforsummonerupdate package")
 	checkforsummonerupdate.Initialize(s)
+	identity.Initialize(s)
+	mastery.Initialize(s)
+	tft.Initialize(s)
+	clash.Initialize(s)
 
 	// Start the rank checking in a separate goroutine
 	logger.Logger.Info("Starting rank checking goroutine")
 	go checkforsummonerupdate.CheckForUpdates()
 
+	// Start the digest scheduler in a separate goroutine
+	logger.Logger.Info("Starting digest scheduler goroutine")
+	digest.Initialize(s)
+	go digest.RunScheduler()
+
+	// Start the periodic rank role sync in a separate goroutine
+	logger.Logger.Info("Starting rank role sweep goroutine")
+	roles.Initialize(s)
+	go roles.RunSweep()
+
+	// Start the Riot status poller in a separate goroutine
+	logger.Logger.Info("Starting status poller goroutine")
+	status.Initialize(s)
+	go status.RunPoller()
+
 	defer func() {
 		logger.Logger.Info("Closing session")
 		s.Close()
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE RankHistory (
+    HistoryID SERIAL PRIMARY KEY,
+    SummonerPUUID VARCHAR(255) NOT NULL,
+    GameID VARCHAR(255) NOT NULL,
+    QueueType VARCHAR(255) NOT NULL,
+    ChampionID INT NOT NULL DEFAULT 0,
+    OldRank INT NOT NULL,
+    NewRank INT NOT NULL,
+    Recorded TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (SummonerPUUID) REFERENCES Summoner(PUUID)
+);
+
+CREATE INDEX RankHistory_Recorded_Idx ON RankHistory (SummonerPUUID, Recorded);
+
+CREATE TABLE DigestSchedule (
+    ChannelID VARCHAR(255) NOT NULL,
+    GuildID VARCHAR(255) NOT NULL,
+    Period VARCHAR(32) NOT NULL,
+    CronExpression VARCHAR(255) NOT NULL,
+    Timezone VARCHAR(255) NOT NULL DEFAULT 'UTC',
+    Content VARCHAR(255) NOT NULL,
+    Created TIMESTAMP NOT NULL DEFAULT NOW(),
+    LastRun TIMESTAMP,
+    PRIMARY KEY (ChannelID, Period)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS DigestSchedule;
+DROP TABLE IF EXISTS RankHistory;
+-- +goose StatementEnd
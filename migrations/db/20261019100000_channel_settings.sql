@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE ChannelSettings (
+    ChannelID VARCHAR(255) PRIMARY KEY,
+    GuildID VARCHAR(255) NOT NULL,
+    NotifyStart BOOLEAN NOT NULL DEFAULT TRUE,
+    NotifyEnd BOOLEAN NOT NULL DEFAULT TRUE,
+    QueueFilter VARCHAR(32) NOT NULL DEFAULT 'all',
+    MinLPChange INT NOT NULL DEFAULT 0,
+    ImagesEnabled BOOLEAN NOT NULL DEFAULT TRUE,
+    Language VARCHAR(8) NOT NULL DEFAULT 'en',
+    QuietStart INT NOT NULL DEFAULT -1,
+    QuietEnd INT NOT NULL DEFAULT -1,
+    Timezone VARCHAR(255) NOT NULL DEFAULT 'UTC'
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ChannelSettings;
+-- +goose StatementEnd
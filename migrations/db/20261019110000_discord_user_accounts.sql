@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE DiscordUser (
+    UserID VARCHAR(255) PRIMARY KEY,
+    MentionOptIn BOOLEAN NOT NULL DEFAULT FALSE,
+    Created TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE DiscordUserAccount (
+    UserID VARCHAR(255) NOT NULL,
+    SummonerPUUID VARCHAR(255) NOT NULL,
+    Linked TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (UserID, SummonerPUUID),
+    FOREIGN KEY (UserID) REFERENCES DiscordUser(UserID),
+    FOREIGN KEY (SummonerPUUID) REFERENCES Summoner(PUUID)
+);
+
+CREATE INDEX idx_discorduseraccount_summoner ON DiscordUserAccount (SummonerPUUID);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS DiscordUserAccount;
+DROP TABLE IF EXISTS DiscordUser;
+-- +goose StatementEnd
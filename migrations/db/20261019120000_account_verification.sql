@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE DiscordUserAccount ADD COLUMN Verified BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE DiscordUserAccount ADD COLUMN VerifiedAt TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE DiscordUserAccount DROP COLUMN IF EXISTS VerifiedAt;
+ALTER TABLE DiscordUserAccount DROP COLUMN IF EXISTS Verified;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE GuildRankRole (
+    GuildID VARCHAR(255) NOT NULL,
+    QueueType VARCHAR(32) NOT NULL,
+    Tier VARCHAR(32) NOT NULL,
+    RoleID VARCHAR(255) NOT NULL,
+    PRIMARY KEY (GuildID, QueueType, Tier)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS GuildRankRole;
+-- +goose StatementEnd
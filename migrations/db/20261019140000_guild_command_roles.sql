@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE GuildCommandRole (
+    GuildID VARCHAR(255) NOT NULL,
+    RoleID VARCHAR(255) NOT NULL,
+    PRIMARY KEY (GuildID, RoleID)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS GuildCommandRole;
+-- +goose StatementEnd
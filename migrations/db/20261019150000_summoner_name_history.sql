@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE SummonerNameHistory (
+    SummonerPUUID VARCHAR(255) NOT NULL,
+    Name VARCHAR(255) NOT NULL,
+    TagLine VARCHAR(255) NOT NULL,
+    Region VARCHAR(255) NOT NULL,
+    Changed TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (SummonerPUUID, Changed),
+    FOREIGN KEY (SummonerPUUID) REFERENCES Summoner(PUUID)
+);
+
+CREATE INDEX idx_summonernamehistory_name ON SummonerNameHistory (LOWER(Name), LOWER(TagLine));
+
+ALTER TABLE Summoner ADD COLUMN IdentityChecked TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE Summoner DROP COLUMN IF EXISTS IdentityChecked;
+DROP TABLE IF EXISTS SummonerNameHistory;
+-- +goose StatementEnd
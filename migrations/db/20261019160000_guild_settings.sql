@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE GuildSettings (
+    GuildID VARCHAR(255) PRIMARY KEY,
+    ProfileSite VARCHAR(32) NOT NULL DEFAULT 'opgg'
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS GuildSettings;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE GuildSettings ADD COLUMN ImageTheme VARCHAR(64) NOT NULL DEFAULT 'scoreboard';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE GuildSettings DROP COLUMN IF EXISTS ImageTheme;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE ChampionMastery (
+    SummonerPUUID VARCHAR(255) NOT NULL,
+    ChampionID INT NOT NULL,
+    ChampionLevel INT NOT NULL,
+    ChampionPoints INT NOT NULL,
+    LastPlayTime BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (SummonerPUUID, ChampionID),
+    FOREIGN KEY (SummonerPUUID) REFERENCES Summoner(PUUID)
+);
+
+ALTER TABLE Summoner ADD COLUMN MasteryChecked TIMESTAMP;
+ALTER TABLE ChannelSettings ADD COLUMN NotifyMastery BOOLEAN NOT NULL DEFAULT TRUE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ChannelSettings DROP COLUMN IF EXISTS NotifyMastery;
+ALTER TABLE Summoner DROP COLUMN IF EXISTS MasteryChecked;
+DROP TABLE IF EXISTS ChampionMastery;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE SummonerChannel ADD COLUMN Games VARCHAR(8) NOT NULL DEFAULT 'lol';
+
+ALTER TABLE Summoner ADD COLUMN TFTRank INT NOT NULL DEFAULT 0;
+ALTER TABLE Summoner ADD COLUMN LastTFTMatchID VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE Summoner ADD COLUMN ActiveTFTGameID BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE Summoner DROP COLUMN IF EXISTS ActiveTFTGameID;
+ALTER TABLE Summoner DROP COLUMN IF EXISTS LastTFTMatchID;
+ALTER TABLE Summoner DROP COLUMN IF EXISTS TFTRank;
+
+ALTER TABLE SummonerChannel DROP COLUMN IF EXISTS Games;
+-- +goose StatementEnd
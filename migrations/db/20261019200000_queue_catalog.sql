@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE Match ADD COLUMN QueueID INT NOT NULL DEFAULT 0;
+UPDATE Match SET QueueID = 420 WHERE GameType = 'Solo/Duo';
+UPDATE Match SET QueueID = 440 WHERE GameType = 'Flex';
+
+-- Games of other queues were stored as UNRANKED and never finished, their queue is unknown
+DELETE FROM Participant WHERE GameID IN (SELECT GameID FROM Match WHERE GameType = 'UNRANKED');
+DELETE FROM Match WHERE GameType = 'UNRANKED';
+
+ALTER TABLE ChannelSettings ADD COLUMN OptInQueues TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ChannelSettings DROP COLUMN IF EXISTS OptInQueues;
+ALTER TABLE Match DROP COLUMN IF EXISTS QueueID;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE ClashAnnouncement (
+    ChannelID VARCHAR(255) NOT NULL,
+    AnnouncementKey VARCHAR(255) NOT NULL,
+    AnnouncedAt TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ChannelID, AnnouncementKey)
+);
+
+ALTER TABLE Summoner ADD COLUMN ClashChecked TIMESTAMP;
+ALTER TABLE ChannelSettings ADD COLUMN NotifyClash BOOLEAN NOT NULL DEFAULT TRUE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ChannelSettings DROP COLUMN IF EXISTS NotifyClash;
+ALTER TABLE Summoner DROP COLUMN IF EXISTS ClashChecked;
+DROP TABLE IF EXISTS ClashAnnouncement;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE StatusMessage (
+    ChannelID VARCHAR(255) NOT NULL,
+    Platform VARCHAR(16) NOT NULL,
+    EntryKey VARCHAR(64) NOT NULL,
+    MessageID VARCHAR(255) NOT NULL,
+    Version VARCHAR(255) NOT NULL,
+    PRIMARY KEY (ChannelID, Platform, EntryKey)
+);
+
+ALTER TABLE ChannelSettings ADD COLUMN NotifyStatus BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ChannelSettings DROP COLUMN IF EXISTS NotifyStatus;
+DROP TABLE IF EXISTS StatusMessage;
+-- +goose StatementEnd
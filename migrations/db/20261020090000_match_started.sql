@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Running games whose result never appears are expired by their start
+ALTER TABLE Match ADD COLUMN Started TIMESTAMP NOT NULL DEFAULT NOW();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE Match DROP COLUMN IF EXISTS Started;
+-- +goose StatementEnd
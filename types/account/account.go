@@ -0,0 +1,43 @@
+package account
+
+import (
+	"discord-bot/types/summoner"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Profile icons every account owns and can switch to, used for ownership challenges
+const (
+	StarterIconMin = 0
+	StarterIconMax = 28
+)
+
+// DiscordUser holds the preferences of a Discord user who linked Riot accounts
+type DiscordUser struct {
+	UserID       string
+	MentionOptIn bool
+}
+
+// LinkedAccount is a Riot account linked to a Discord user
+type LinkedAccount struct {
+	UserID   string
+	Summoner summoner.Summoner
+	Linked   time.Time
+	Verified bool
+}
+
+// Mention returns the Discord mention of a user ID
+func Mention(userID string) string {
+	return fmt.Sprintf("<@%s>", userID)
+}
+
+// RandomStarterIcon picks a random starter profile icon different from the current one
+func RandomStarterIcon(current int) int {
+	for {
+		icon := StarterIconMin + rand.Intn(StarterIconMax-StarterIconMin+1)
+		if icon != current {
+			return icon
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package account
+
+import "testing"
+
+func TestRandomStarterIcon(t *testing.T) {
+	for current := StarterIconMin - 1; current <= StarterIconMax+1; current++ {
+		for i := 0; i < 100; i++ {
+			icon := RandomStarterIcon(current)
+			if icon == current {
+				t.Fatalf("Expected an icon different from %d, got %d", current, icon)
+			}
+			if icon < StarterIconMin || icon > StarterIconMax {
+				t.Fatalf("Expected a starter icon, got %d", icon)
+			}
+		}
+	}
+}
+
+func TestMention(t *testing.T) {
+	if got := Mention("123"); got != "<@123>" {
+		t.Errorf("Expected <@123>, got %s", got)
+	}
+}
@@ -0,0 +1,341 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Champion is a playable champion
+type Champion struct {
+	ID    int
+	Key   string // Data Dragon identifier, e.g. "MonkeyKing" for Wukong
+	Name  string
+	Title string
+	Tags  []string // Roles like "Fighter" or "Mage"
+	Image string
+}
+
+// Item is a purchasable item with its build path
+type Item struct {
+	ID          int
+	Name        string
+	Plaintext   string // Short summary of the item
+	Description string // Full tooltip, may contain markup
+	Gold        int    // Total cost including components
+	From        []int  // Components the item is built from
+	Into        []int  // Items the item builds into
+	Image       string
+}
+
+// Rune is a rune or a rune tree, trees have their own ID as TreeID
+type Rune struct {
+	ID        int
+	TreeID    int
+	Key       string
+	Name      string
+	ShortDesc string
+	LongDesc  string
+	Icon      string
+}
+
+// Spell is a summoner spell
+type Spell struct {
+	ID          int
+	Key         string // Data Dragon identifier, e.g. "SummonerFlash"
+	Name        string
+	Description string
+	Image       string
+}
+
+// Catalog holds the static data of one patch with lookups by ID and name.
+// A catalog is filled once by the Decode functions and read-only afterwards, so it is safe for concurrent use.
+type Catalog struct {
+	Champions map[int]Champion
+	Items     map[int]Item
+	Runes     map[int]Rune
+	Spells    map[int]Spell
+
+	championNames map[string]int
+	itemNames     map[string]int
+	runeNames     map[string]int
+	spellNames    map[string]int
+}
+
+// New creates an empty catalog
+func New() *Catalog {
+	return &Catalog{
+		Champions:     make(map[int]Champion),
+		Items:         make(map[int]Item),
+		Runes:         make(map[int]Rune),
+		Spells:        make(map[int]Spell),
+		championNames: make(map[string]int),
+		itemNames:     make(map[string]int),
+		runeNames:     make(map[string]int),
+		spellNames:    make(map[string]int),
+	}
+}
+
+// DecodeChampions adds the champions of a Data Dragon champion.json
+func (c *Catalog) DecodeChampions(data []byte) error {
+	var champions struct {
+		Data map[string]struct {
+			ID    string   `json:"id"`
+			Key   string   `json:"key"`
+			Name  string   `json:"name"`
+			Title string   `json:"title"`
+			Tags  []string `json:"tags"`
+			Image struct {
+				Full string `json:"full"`
+			} `json:"image"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &champions); err != nil {
+		return fmt.Errorf("failed to decode champions: %w", err)
+	}
+	for _, champion := range champions.Data {
+		id, err := strconv.Atoi(champion.Key)
+		if err != nil {
+			continue
+		}
+		c.Champions[id] = Champion{ID: id, Key: champion.ID, Name: champion.Name, Title: champion.Title, Tags: champion.Tags, Image: champion.Image.Full}
+		c.championNames[normalize(champion.Name)] = id
+		c.championNames[normalize(champion.ID)] = id
+	}
+	return nil
+}
+
+// DecodeItems adds the items of a Data Dragon item.json
+func (c *Catalog) DecodeItems(data []byte) error {
+	var items struct {
+		Data map[string]struct {
+			Name        string   `json:"name"`
+			Plaintext   string   `json:"plaintext"`
+			Description string   `json:"description"`
+			From        []string `json:"from"`
+			Into        []string `json:"into"`
+			Gold        struct {
+				Total int `json:"total"`
+			} `json:"gold"`
+			Image struct {
+				Full string `json:"full"`
+			} `json:"image"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return fmt.Errorf("failed to decode items: %w", err)
+	}
+	for key, item := range items.Data {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		c.Items[id] = Item{
+			ID:          id,
+			Name:        item.Name,
+			Plaintext:   item.Plaintext,
+			Description: item.Description,
+			Gold:        item.Gold.Total,
+			From:        atoiAll(item.From),
+			Into:        atoiAll(item.Into),
+			Image:       item.Image.Full,
+		}
+		// Item names are not unique (e.g. Ornn upgrades and mode variants), the lowest ID wins
+		name := normalize(item.Name)
+		if existing, ok := c.itemNames[name]; !ok || id < existing {
+			c.itemNames[name] = id
+		}
+	}
+	return nil
+}
+
+// DecodeRunes adds the rune trees and runes of a Data Dragon runesReforged.json
+func (c *Catalog) DecodeRunes(data []byte) error {
+	var trees []struct {
+		ID    int    `json:"id"`
+		Key   string `json:"key"`
+		Name  string `json:"name"`
+		Icon  string `json:"icon"`
+		Slots []struct {
+			Runes []struct {
+				ID        int    `json:"id"`
+				Key       string `json:"key"`
+				Name      string `json:"name"`
+				Icon      string `json:"icon"`
+				ShortDesc string `json:"shortDesc"`
+				LongDesc  string `json:"longDesc"`
+			} `json:"runes"`
+		} `json:"slots"`
+	}
+	if err := json.Unmarshal(data, &trees); err != nil {
+		return fmt.Errorf("failed to decode runes: %w", err)
+	}
+	for _, tree := range trees {
+		c.Runes[tree.ID] = Rune{ID: tree.ID, TreeID: tree.ID, Key: tree.Key, Name: tree.Name, Icon: tree.Icon}
+		c.runeNames[normalize(tree.Name)] = tree.ID
+		for _, slot := range tree.Slots {
+			for _, r := range slot.Runes {
+				c.Runes[r.ID] = Rune{ID: r.ID, TreeID: tree.ID, Key: r.Key, Name: r.Name, ShortDesc: r.ShortDesc, LongDesc: r.LongDesc, Icon: r.Icon}
+				c.runeNames[normalize(r.Name)] = r.ID
+			}
+		}
+	}
+	return nil
+}
+
+// DecodeSpells adds the summoner spells of a Data Dragon summoner.json
+func (c *Catalog) DecodeSpells(data []byte) error {
+	var spells struct {
+		Data map[string]struct {
+			ID          string `json:"id"`
+			Key         string `json:"key"`
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			Image       struct {
+				Full string `json:"full"`
+			} `json:"image"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &spells); err != nil {
+		return fmt.Errorf("failed to decode spells: %w", err)
+	}
+	for _, spell := range spells.Data {
+		id, err := strconv.Atoi(spell.Key)
+		if err != nil {
+			continue
+		}
+		c.Spells[id] = Spell{ID: id, Key: spell.ID, Name: spell.Name, Description: spell.Description, Image: spell.Image.Full}
+		// Some modes have their own variant of a spell, keep the name pointing at the lowest ID
+		name := normalize(spell.Name)
+		if existing, ok := c.spellNames[name]; !ok || id < existing {
+			c.spellNames[name] = id
+		}
+		c.spellNames[normalize(spell.ID)] = id
+	}
+	return nil
+}
+
+// ChampionByName finds a champion by name or Data Dragon key, ignoring case, spaces and punctuation
+func (c *Catalog) ChampionByName(name string) (Champion, bool) {
+	id, ok := c.championNames[normalize(name)]
+	if !ok {
+		return Champion{}, false
+	}
+	return c.Champions[id], true
+}
+
+// ItemByName finds an item by name, ignoring case, spaces and punctuation
+func (c *Catalog) ItemByName(name string) (Item, bool) {
+	id, ok := c.itemNames[normalize(name)]
+	if !ok {
+		return Item{}, false
+	}
+	return c.Items[id], true
+}
+
+// RuneByName finds a rune or rune tree by name, ignoring case, spaces and punctuation
+func (c *Catalog) RuneByName(name string) (Rune, bool) {
+	id, ok := c.runeNames[normalize(name)]
+	if !ok {
+		return Rune{}, false
+	}
+	return c.Runes[id], true
+}
+
+// SpellByName finds a summoner spell by name or Data Dragon key, ignoring case, spaces and punctuation
+func (c *Catalog) SpellByName(name string) (Spell, bool) {
+	id, ok := c.spellNames[normalize(name)]
+	if !ok {
+		return Spell{}, false
+	}
+	return c.Spells[id], true
+}
+
+// ChampionName returns the name of a champion, "Champion <id>" if it is unknown
+func (c *Catalog) ChampionName(id int) string {
+	if champion, ok := c.Champions[id]; ok {
+		return champion.Name
+	}
+	return fmt.Sprintf("Champion %d", id)
+}
+
+// ItemName returns the name of an item, "Item <id>" if it is unknown
+func (c *Catalog) ItemName(id int) string {
+	if item, ok := c.Items[id]; ok {
+		return item.Name
+	}
+	return fmt.Sprintf("Item %d", id)
+}
+
+// RuneName returns the name of a rune or rune tree, "Rune <id>" if it is unknown
+func (c *Catalog) RuneName(id int) string {
+	if r, ok := c.Runes[id]; ok {
+		return r.Name
+	}
+	return fmt.Sprintf("Rune %d", id)
+}
+
+// SpellName returns the name of a summoner spell, "Spell <id>" if it is unknown
+func (c *Catalog) SpellName(id int) string {
+	if spell, ok := c.Spells[id]; ok {
+		return spell.Name
+	}
+	return fmt.Sprintf("Spell %d", id)
+}
+
+// Tooltip returns the name, cost and summary of an item like "Blasting Wand (850g): Moderately increases Ability Power"
+func (i Item) Tooltip() string {
+	tooltip := fmt.Sprintf("%s (%dg)", i.Name, i.Gold)
+	if i.Plaintext != "" {
+		tooltip += ": " + i.Plaintext
+	}
+	return tooltip
+}
+
+// Tooltip returns the name and short description of a rune without markup
+func (r Rune) Tooltip() string {
+	if r.ShortDesc == "" {
+		return r.Name
+	}
+	return r.Name + ": " + StripMarkup(r.ShortDesc)
+}
+
+// Tooltip returns the name and description of a summoner spell
+func (s Spell) Tooltip() string {
+	if s.Description == "" {
+		return s.Name
+	}
+	return s.Name + ": " + StripMarkup(s.Description)
+}
+
+var markupPattern = regexp.MustCompile(`<[^>]*>`)
+
+// StripMarkup removes the HTML-like tags Data Dragon uses in descriptions
+func StripMarkup(text string) string {
+	text = strings.ReplaceAll(text, "<br>", " ")
+	return strings.Join(strings.Fields(markupPattern.ReplaceAllString(text, "")), " ")
+}
+
+// normalize reduces a name to lower case letters and digits so "Kai'Sa", "kaisa" and "Kai Sa" match
+func normalize(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func atoiAll(values []string) []int {
+	var ids []int
+	for _, value := range values {
+		if id, err := strconv.Atoi(value); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,97 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testCatalog(t *testing.T) *Catalog {
+	c := New()
+	champions := `{"data": {"MonkeyKing": {"id": "MonkeyKing", "key": "62", "name": "Wukong", "title": "the Monkey King", "tags": ["Fighter", "Tank"], "image": {"full": "MonkeyKing.png"}},
+		"Kaisa": {"id": "Kaisa", "key": "145", "name": "Kai'Sa", "title": "Daughter of the Void", "tags": ["Marksman"], "image": {"full": "Kaisa.png"}}}}`
+	items := `{"data": {"1026": {"name": "Blasting Wand", "plaintext": "Moderately increases Ability Power", "into": ["3135", "3165"], "gold": {"total": 850}, "image": {"full": "1026.png"}},
+		"3165": {"name": "Morellonomicon", "from": ["1026", "3916"], "gold": {"total": 2850}, "image": {"full": "3165.png"}}}}`
+	runes := `[{"id": 8100, "key": "Domination", "name": "Domination", "icon": "perk-images/Styles/7200_Domination.png", "slots": [{"runes": [
+		{"id": 8112, "key": "Electrocute", "name": "Electrocute", "icon": "Electrocute.png", "shortDesc": "Hitting a champion with 3 <b>separate</b> attacks deals bonus damage."}]}]}]`
+	spells := `{"data": {"SummonerFlash": {"id": "SummonerFlash", "key": "4", "name": "Flash", "description": "Teleports your champion a short distance.", "image": {"full": "SummonerFlash.png"}}}}`
+
+	for name, decode := range map[string]func([]byte) error{
+		champions: c.DecodeChampions,
+		items:     c.DecodeItems,
+		runes:     c.DecodeRunes,
+		spells:    c.DecodeSpells,
+	} {
+		if err := decode([]byte(name)); err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+	}
+	return c
+}
+
+func TestLookupByID(t *testing.T) {
+	c := testCatalog(t)
+
+	if got := c.ChampionName(62); got != "Wukong" {
+		t.Errorf("expected Wukong, got %s", got)
+	}
+	if got := c.ChampionName(9999); got != "Champion 9999" {
+		t.Errorf("expected fallback name, got %s", got)
+	}
+	if champion := c.Champions[62]; champion.Title != "the Monkey King" || !reflect.DeepEqual(champion.Tags, []string{"Fighter", "Tank"}) {
+		t.Errorf("unexpected champion %+v", champion)
+	}
+
+	item := c.Items[3165]
+	if item.Gold != 2850 || !reflect.DeepEqual(item.From, []int{1026, 3916}) {
+		t.Errorf("unexpected item %+v", item)
+	}
+	if !reflect.DeepEqual(c.Items[1026].Into, []int{3135, 3165}) {
+		t.Errorf("unexpected build path %+v", c.Items[1026].Into)
+	}
+
+	if r := c.Runes[8112]; r.TreeID != 8100 || c.RuneName(8100) != "Domination" {
+		t.Errorf("unexpected rune %+v", r)
+	}
+	if got := c.SpellName(4); got != "Flash" {
+		t.Errorf("expected Flash, got %s", got)
+	}
+}
+
+func TestLookupByName(t *testing.T) {
+	c := testCatalog(t)
+
+	for _, name := range []string{"Kai'Sa", "kaisa", "KAI SA"} {
+		if champion, ok := c.ChampionByName(name); !ok || champion.ID != 145 {
+			t.Errorf("ChampionByName(%q): expected Kai'Sa, got %+v", name, champion)
+		}
+	}
+	if champion, ok := c.ChampionByName("MonkeyKing"); !ok || champion.Name != "Wukong" {
+		t.Errorf("expected lookup by key, got %+v", champion)
+	}
+	if item, ok := c.ItemByName("blasting wand"); !ok || item.ID != 1026 {
+		t.Errorf("expected Blasting Wand, got %+v", item)
+	}
+	if r, ok := c.RuneByName("electrocute"); !ok || r.ID != 8112 {
+		t.Errorf("expected Electrocute, got %+v", r)
+	}
+	if spell, ok := c.SpellByName("SummonerFlash"); !ok || spell.Name != "Flash" {
+		t.Errorf("expected Flash, got %+v", spell)
+	}
+	if _, ok := c.ChampionByName("Teemo"); ok {
+		t.Error("expected unknown champion")
+	}
+}
+
+func TestTooltips(t *testing.T) {
+	c := testCatalog(t)
+
+	if got := c.Items[1026].Tooltip(); got != "Blasting Wand (850g): Moderately increases Ability Power" {
+		t.Errorf("unexpected item tooltip %q", got)
+	}
+	if got := c.Runes[8112].Tooltip(); got != "Electrocute: Hitting a champion with 3 separate attacks deals bonus damage." {
+		t.Errorf("unexpected rune tooltip %q", got)
+	}
+	if got := StripMarkup("<mainText><stats>45 Ability Power</stats><br><br></mainText>"); got != "45 Ability Power" {
+		t.Errorf("unexpected stripped text %q", got)
+	}
+}
@@ -0,0 +1,129 @@
+package clash
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Phase is one day of a Clash tournament
+type Phase struct {
+	ID               int
+	RegistrationTime time.Time
+	StartTime        time.Time
+	Cancelled        bool
+}
+
+// Tournament is a Clash tournament with its days
+type Tournament struct {
+	ID               int
+	ThemeID          int
+	NameKey          string // Like "bilgewater"
+	NameKeySecondary string // Like "day_4"
+	Schedule         []Phase
+}
+
+// Player is the registration of a summoner for a Clash tournament
+type Player struct {
+	PUUID    string
+	TeamID   string
+	Position string // "TOP", "JUNGLE", "MIDDLE", "BOTTOM", "UTILITY", "FILL" or "UNSELECTED"
+	Role     string // "CAPTAIN" or "MEMBER"
+}
+
+// Team is a Clash team with its registered players
+type Team struct {
+	ID           string
+	TournamentID int
+	Name         string
+	Abbreviation string
+	IconID       int
+	Tier         int
+	Captain      string // PUUID of the captain
+	Players      []Player
+}
+
+// Name returns a readable tournament name like "Bilgewater Cup Day 4"
+func (t *Tournament) Name() string {
+	name := titleCase(t.NameKey)
+	if t.NameKeySecondary != "" {
+		name += " " + titleCase(t.NameKeySecondary)
+	}
+	return name
+}
+
+// NextPhase returns the first day of the tournament that is not cancelled and starts after now, nil if there is none
+func (t *Tournament) NextPhase(now time.Time) *Phase {
+	var next *Phase
+	for i := range t.Schedule {
+		phase := &t.Schedule[i]
+		if phase.Cancelled || !phase.StartTime.After(now) {
+			continue
+		}
+		if next == nil || phase.StartTime.Before(next.StartTime) {
+			next = phase
+		}
+	}
+	return next
+}
+
+// Upcoming returns the tournaments with a day that starts after now, the earliest first
+func Upcoming(tournaments []Tournament, now time.Time) []Tournament {
+	var upcoming []Tournament
+	for _, tournament := range tournaments {
+		if tournament.NextPhase(now) != nil {
+			upcoming = append(upcoming, tournament)
+		}
+	}
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].NextPhase(now).StartTime.Before(upcoming[j].NextPhase(now).StartTime)
+	})
+	return upcoming
+}
+
+// Find returns the tournament with the given ID, nil if it is not in the list
+func Find(tournaments []Tournament, id int) *Tournament {
+	for i := range tournaments {
+		if tournaments[i].ID == id {
+			return &tournaments[i]
+		}
+	}
+	return nil
+}
+
+// TierName returns the tier of a team like "Tier I", tiers are written as roman numerals in the client
+func TierName(tier int) string {
+	numerals := []string{"", "I", "II", "III", "IV"}
+	if tier < 1 || tier >= len(numerals) {
+		return "Unknown tier"
+	}
+	return "Tier " + numerals[tier]
+}
+
+// PositionName returns a readable position like "Support", empty if the player has not chosen one
+func PositionName(position string) string {
+	switch position {
+	case "TOP":
+		return "Top"
+	case "JUNGLE":
+		return "Jungle"
+	case "MIDDLE":
+		return "Mid"
+	case "BOTTOM":
+		return "Bot"
+	case "UTILITY":
+		return "Support"
+	case "FILL":
+		return "Fill"
+	default:
+		return ""
+	}
+}
+
+func titleCase(key string) string {
+	words := strings.Fields(strings.ReplaceAll(key, "_", " "))
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,60 @@
+package clash
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTournamentName(t *testing.T) {
+	tournament := Tournament{NameKey: "bilgewater", NameKeySecondary: "day_4"}
+	if name := tournament.Name(); name != "Bilgewater Day 4" {
+		t.Errorf("Expected Bilgewater Day 4, got %s", name)
+	}
+}
+
+func TestNextPhase(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	tournament := Tournament{Schedule: []Phase{
+		{ID: 1, StartTime: now.Add(-time.Hour)},
+		{ID: 2, StartTime: now.Add(48 * time.Hour)},
+		{ID: 3, StartTime: now.Add(24 * time.Hour), Cancelled: true},
+		{ID: 4, StartTime: now.Add(30 * time.Hour)},
+	}}
+
+	phase := tournament.NextPhase(now)
+	if phase == nil || phase.ID != 4 {
+		t.Fatalf("Expected phase 4, got %+v", phase)
+	}
+	if tournament.NextPhase(now.Add(72*time.Hour)) != nil {
+		t.Errorf("Expected no phase after the tournament")
+	}
+}
+
+func TestUpcoming(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	tournaments := []Tournament{
+		{ID: 1, Schedule: []Phase{{StartTime: now.Add(-time.Hour)}}},
+		{ID: 2, Schedule: []Phase{{StartTime: now.Add(72 * time.Hour)}}},
+		{ID: 3, Schedule: []Phase{{StartTime: now.Add(24 * time.Hour)}}},
+	}
+
+	upcoming := Upcoming(tournaments, now)
+	if len(upcoming) != 2 || upcoming[0].ID != 3 || upcoming[1].ID != 2 {
+		t.Errorf("Expected tournaments 3 and 2, got %+v", upcoming)
+	}
+	if Find(tournaments, 2) == nil || Find(tournaments, 4) != nil {
+		t.Errorf("Expected to find tournament 2 only")
+	}
+}
+
+func TestTierName(t *testing.T) {
+	if TierName(1) != "Tier I" || TierName(4) != "Tier IV" || TierName(0) != "Unknown tier" {
+		t.Errorf("Unexpected tier names: %s, %s, %s", TierName(1), TierName(4), TierName(0))
+	}
+}
+
+func TestPositionName(t *testing.T) {
+	if PositionName("UTILITY") != "Support" || PositionName("MIDDLE") != "Mid" || PositionName("UNSELECTED") != "" {
+		t.Errorf("Unexpected position names: %q, %q, %q", PositionName("UTILITY"), PositionName("MIDDLE"), PositionName("UNSELECTED"))
+	}
+}
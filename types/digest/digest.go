@@ -0,0 +1,72 @@
+package digest
+
+import (
+	"strings"
+	"time"
+)
+
+// Periods a digest can summarize
+const (
+	PeriodWeekly = "weekly"
+	PeriodSeason = "season"
+)
+
+// Content sections a digest can contain
+const (
+	SectionGames      = "games"
+	SectionLP         = "lp"
+	SectionClimber    = "climber"
+	SectionStreak     = "streak"
+	SectionChampions  = "champions"
+	SectionPromotions = "promotions"
+)
+
+// AllSections lists every digest section in display order
+var AllSections = []string{SectionGames, SectionLP, SectionClimber, SectionStreak, SectionChampions, SectionPromotions}
+
+// Schedule describes when and what digest is posted to a channel
+type Schedule struct {
+	ChannelID      string
+	GuildID        string
+	Period         string
+	CronExpression string
+	Timezone       string
+	Content        []string
+	Created        time.Time
+	LastRun        *time.Time
+}
+
+// HasSection reports whether the given section is part of the schedule's content
+func (s *Schedule) HasSection(section string) bool {
+	for _, c := range s.Content {
+		if c == section {
+			return true
+		}
+	}
+	return false
+}
+
+// ContentString returns the content sections as a comma separated list
+func (s *Schedule) ContentString() string {
+	return strings.Join(s.Content, ",")
+}
+
+// ParseContent parses a comma separated list of sections, ignoring unknown entries.
+// An empty input selects all sections.
+func ParseContent(content string) []string {
+	if strings.TrimSpace(content) == "" {
+		return append([]string{}, AllSections...)
+	}
+
+	var sections []string
+	for _, part := range strings.Split(content, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		for _, section := range AllSections {
+			if part == section {
+				sections = append(sections, section)
+				break
+			}
+		}
+	}
+	return sections
+}
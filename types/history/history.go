@@ -0,0 +1,47 @@
+package history
+
+import (
+	"discord-bot/types/rank"
+	"time"
+)
+
+// RankChange is a single recorded rank change of a summoner after a ranked game
+type RankChange struct {
+	SummonerPUUID string
+	NameTag       string
+	GameID        string
+	QueueType     string // "Solo" or "Flex"
+	ChampionID    int
+	OldRank       rank.Rank
+	NewRank       rank.Rank
+	Recorded      time.Time
+}
+
+// LPChange returns the LP won or lost with this change
+func (c *RankChange) LPChange() int {
+	return rank.RankDifference(c.NewRank, c.OldRank)
+}
+
+// IsWin reports whether the game behind this change was won
+func (c *RankChange) IsWin() bool {
+	return c.LPChange() > 0 || c.NewRank.Division() > c.OldRank.Division()
+}
+
+// IsPromotion reports whether the summoner reached a higher division with this change
+func (c *RankChange) IsPromotion() bool {
+	return c.OldRank != 0 && c.NewRank.Division() > c.OldRank.Division()
+}
+
+// NameChange is a previous Riot ID and platform of a summoner, replaced at Changed
+type NameChange struct {
+	SummonerPUUID string
+	Name          string
+	TagLine       string
+	Region        string
+	Changed       time.Time
+}
+
+// NameTag returns the previous Riot ID in "Name#TAG" format
+func (c *NameChange) NameTag() string {
+	return c.Name + "#" + c.TagLine
+}
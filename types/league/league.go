@@ -0,0 +1,52 @@
+package league
+
+import (
+	"discord-bot/types/rank"
+	"fmt"
+)
+
+// Queue types as returned by league-v4 and tft-league-v1
+const (
+	QueueSolo = "RANKED_SOLO_5x5"
+	QueueFlex = "RANKED_FLEX_SR"
+	QueueTFT  = "RANKED_TFT"
+)
+
+// Entry is a summoner's league entry for a single ranked queue
+type Entry struct {
+	QueueType    string `json:"queueType"`
+	Tier         string `json:"tier"`
+	Division     string `json:"rank"`
+	LeaguePoints int    `json:"leaguePoints"`
+	Wins         int    `json:"wins"`
+	Losses       int    `json:"losses"`
+	HotStreak    bool   `json:"hotStreak"`
+}
+
+// Rank converts the entry into the internal rank representation
+func (e *Entry) Rank() rank.Rank {
+	return rank.FromString(fmt.Sprintf("%s %s %d LP", e.Tier, e.Division, e.LeaguePoints))
+}
+
+// Games returns the number of games played in the queue
+func (e *Entry) Games() int {
+	return e.Wins + e.Losses
+}
+
+// WinRate returns the win rate in percent
+func (e *Entry) WinRate() float64 {
+	if e.Games() == 0 {
+		return 0
+	}
+	return float64(e.Wins) * 100 / float64(e.Games())
+}
+
+// FindEntry returns the entry for the given queue type or nil if the summoner is unranked in it
+func FindEntry(entries []Entry, queueType string) *Entry {
+	for i := range entries {
+		if entries[i].QueueType == queueType {
+			return &entries[i]
+		}
+	}
+	return nil
+}
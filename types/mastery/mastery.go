@@ -0,0 +1,70 @@
+package mastery
+
+import "sort"
+
+// MinAnnouncedLevel is the lowest mastery level that is announced, lower levels are reached within a few games
+const MinAnnouncedLevel = 5
+
+// PointMilestones are the mastery point totals announced when a champion passes them
+var PointMilestones = []int{100000, 250000, 500000, 1000000, 2000000}
+
+// ChampionMastery is a summoner's mastery on a single champion
+type ChampionMastery struct {
+	ChampionID     int   `json:"championId"`
+	ChampionLevel  int   `json:"championLevel"`
+	ChampionPoints int   `json:"championPoints"`
+	LastPlayTime   int64 `json:"lastPlayTime"`
+}
+
+// Milestone is a mastery level or point total a summoner reached on a champion
+type Milestone struct {
+	ChampionID int
+	Level      int // New mastery level, 0 if no level worth announcing was reached
+	Points     int // Highest point milestone passed, 0 if none was
+}
+
+// Milestones compares two snapshots of a summoner and returns the milestones reached since previous.
+// Champions missing from previous were not played before and start at zero.
+func Milestones(previous, current []ChampionMastery) []Milestone {
+	before := make(map[int]ChampionMastery, len(previous))
+	for _, m := range previous {
+		before[m.ChampionID] = m
+	}
+
+	var milestones []Milestone
+	for _, m := range current {
+		old := before[m.ChampionID]
+		milestone := Milestone{ChampionID: m.ChampionID}
+		if m.ChampionLevel > old.ChampionLevel && m.ChampionLevel >= MinAnnouncedLevel {
+			milestone.Level = m.ChampionLevel
+		}
+		for _, points := range PointMilestones {
+			if old.ChampionPoints < points && m.ChampionPoints >= points {
+				milestone.Points = points
+			}
+		}
+		if milestone.Level > 0 || milestone.Points > 0 {
+			milestones = append(milestones, milestone)
+		}
+	}
+	return milestones
+}
+
+// Top returns the count champions with the most mastery points, highest first
+func Top(masteries []ChampionMastery, count int) []ChampionMastery {
+	sorted := append([]ChampionMastery(nil), masteries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ChampionPoints > sorted[j].ChampionPoints })
+	if count < len(sorted) {
+		sorted = sorted[:count]
+	}
+	return sorted
+}
+
+// TotalLevel returns the sum of the mastery levels of all champions
+func TotalLevel(masteries []ChampionMastery) int {
+	total := 0
+	for _, m := range masteries {
+		total += m.ChampionLevel
+	}
+	return total
+}
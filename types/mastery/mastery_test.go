@@ -0,0 +1,46 @@
+package mastery
+
+import "testing"
+
+func TestMilestones(t *testing.T) {
+	previous := []ChampionMastery{
+		{ChampionID: 1, ChampionLevel: 6, ChampionPoints: 95000},
+		{ChampionID: 2, ChampionLevel: 3, ChampionPoints: 9000},
+		{ChampionID: 3, ChampionLevel: 10, ChampionPoints: 240000},
+	}
+	current := []ChampionMastery{
+		{ChampionID: 1, ChampionLevel: 7, ChampionPoints: 101000},
+		{ChampionID: 2, ChampionLevel: 4, ChampionPoints: 12000},
+		{ChampionID: 3, ChampionLevel: 10, ChampionPoints: 520000},
+		{ChampionID: 4, ChampionLevel: 1, ChampionPoints: 600},
+	}
+
+	milestones := Milestones(previous, current)
+	if len(milestones) != 2 {
+		t.Fatalf("expected 2 milestones, got %+v", milestones)
+	}
+	if m := milestones[0]; m.ChampionID != 1 || m.Level != 7 || m.Points != 100000 {
+		t.Errorf("expected level 7 and 100k points on champion 1, got %+v", m)
+	}
+	if m := milestones[1]; m.ChampionID != 3 || m.Level != 0 || m.Points != 500000 {
+		t.Errorf("expected only the highest passed point milestone on champion 3, got %+v", m)
+	}
+}
+
+func TestTop(t *testing.T) {
+	masteries := []ChampionMastery{
+		{ChampionID: 1, ChampionPoints: 10},
+		{ChampionID: 2, ChampionPoints: 30},
+		{ChampionID: 3, ChampionPoints: 20},
+	}
+	top := Top(masteries, 2)
+	if len(top) != 2 || top[0].ChampionID != 2 || top[1].ChampionID != 3 {
+		t.Errorf("expected champions 2 and 3, got %+v", top)
+	}
+	if masteries[0].ChampionID != 1 {
+		t.Errorf("Top must not reorder its input")
+	}
+	if TotalLevel([]ChampionMastery{{ChampionLevel: 7}, {ChampionLevel: 3}}) != 10 {
+		t.Errorf("expected total level 10")
+	}
+}
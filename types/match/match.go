@@ -1,8 +1,11 @@
 package match
 
 import (
+	"discord-bot/types/league"
+	"discord-bot/types/queue"
 	"discord-bot/types/rank"
 	"discord-bot/types/summoner"
+	"time"
 )
 
 type Participant struct {
@@ -11,17 +14,43 @@ type Participant struct {
 	Spells     Spells
 	Perks      Perks
 	ChampionID int
+	SoloEntry  *league.Entry // Solo/Duo league entry, only set for live games
+	Stats      Stats         // End of game statistics, only set for finished games
+}
+
+// Stats are the end of game statistics of a participant
+type Stats struct {
+	Kills       int
+	Deaths      int
+	Assists     int
+	CreepScore  int
+	GoldEarned  int
+	DamageDealt int // Damage dealt to champions
+	Placement   int // Final placement in Arena, 0 in other queues
 }
 
 type Team struct {
 	TeamID       int
 	Participants []Participant
+	Win          bool // Only set for finished games
 }
 
 type Match struct {
-	GameID   string
-	Teams    [2]Team
-	GameType string // "Solo/Duo" or "Flex"
+	GameID      string
+	Teams       [2]Team
+	Queue       queue.Queue
+	GameMode    string
+	GameStart   time.Time
+	Duration    time.Duration // Only set for finished games
+	GameVersion string        // Patch the game was played on like "15.3.612.4321", only set for finished games
+	Bans        []Ban
+}
+
+// Ban is a champion banned during champion select
+type Ban struct {
+	ChampionID int
+	TeamID     int
+	PickTurn   int
 }
 
 type Perks struct {
@@ -38,6 +67,42 @@ type Spells struct {
 	SpellIDs []int
 }
 
+// TeamBans returns the bans of the team with the given team ID in pick order
+func (m *Match) TeamBans(teamID int) []Ban {
+	var bans []Ban
+	for _, ban := range m.Bans {
+		if ban.TeamID == teamID {
+			bans = append(bans, ban)
+		}
+	}
+	return bans
+}
+
+// Elapsed returns how long the game has been running
+func (m *Match) Elapsed() time.Duration {
+	if m.GameStart.IsZero() {
+		return 0
+	}
+	return time.Since(m.GameStart).Truncate(time.Second)
+}
+
+// KDA returns the kills and assists per death, the kills and assists if the participant never died
+func (s Stats) KDA() float64 {
+	if s.Deaths == 0 {
+		return float64(s.Kills + s.Assists)
+	}
+	return float64(s.Kills+s.Assists) / float64(s.Deaths)
+}
+
+// Kills returns the total kills of the team
+func (t *Team) Kills() int {
+	var kills int
+	for _, participant := range t.Participants {
+		kills += participant.Stats.Kills
+	}
+	return kills
+}
+
 // AverageRank calculates the average rank of the team
 func (t *Team) AverageRank() rank.Rank {
 	if len(t.Participants) == 0 {
@@ -0,0 +1,98 @@
+package profilelink
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Sites that profile links in embeds can point to
+const (
+	OPGG           = "opgg"
+	UGG            = "ugg"
+	LeagueOfGraphs = "leagueofgraphs"
+	DeepLoL        = "deeplol"
+	Porofessor     = "porofessor"
+)
+
+// Default is the site used by guilds that did not choose one
+const Default = OPGG
+
+// Site is a third-party profile site
+type Site struct {
+	ID   string
+	Name string
+}
+
+// Sites lists every supported site in the order they are offered
+var Sites = []Site{
+	{ID: OPGG, Name: "OP.GG"},
+	{ID: UGG, Name: "U.GG"},
+	{ID: LeagueOfGraphs, Name: "League of Graphs"},
+	{ID: DeepLoL, Name: "DeepLoL"},
+	{ID: Porofessor, Name: "Porofessor"},
+}
+
+// shortRegions maps platform IDs to the region names used in the URLs of most sites
+var shortRegions = map[string]string{
+	"BR1":  "br",
+	"EUN1": "eune",
+	"EUW1": "euw",
+	"JP1":  "jp",
+	"KR":   "kr",
+	"LA1":  "lan",
+	"LA2":  "las",
+	"ME1":  "me",
+	"NA1":  "na",
+	"OC1":  "oce",
+	"PH2":  "ph",
+	"RU":   "ru",
+	"SG2":  "sg",
+	"TH2":  "th",
+	"TR1":  "tr",
+	"TW2":  "tw",
+	"VN2":  "vn",
+}
+
+// IsSupported reports whether site is a known site ID
+func IsSupported(site string) bool {
+	for _, s := range Sites {
+		if s.ID == site {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the display name of a site, the name of the default site for unknown IDs
+func Name(site string) string {
+	for _, s := range Sites {
+		if s.ID == site {
+			return s.Name
+		}
+	}
+	return Name(Default)
+}
+
+// URL returns the profile page of a Riot ID on a platform like "EUW1", unknown sites link to the default site
+func URL(site, platform, name, tagLine string) string {
+	platform = strings.ToUpper(platform)
+	region, ok := shortRegions[platform]
+	if !ok {
+		region = strings.ToLower(strings.TrimRight(platform, "0123456789"))
+	}
+	riotID := url.PathEscape(name + "-" + tagLine)
+
+	switch site {
+	case UGG:
+		return fmt.Sprintf("https://u.gg/lol/profile/%s/%s/overview", strings.ToLower(platform), strings.ToLower(riotID))
+	case LeagueOfGraphs:
+		return fmt.Sprintf("https://www.leagueofgraphs.com/summoner/%s/%s", region, riotID)
+	case DeepLoL:
+		return fmt.Sprintf("https://www.deeplol.gg/summoner/%s/%s", strings.ToUpper(region), riotID)
+	case Porofessor:
+		return fmt.Sprintf("https://porofessor.gg/live/%s/%s", region, strings.ToLower(riotID))
+	default:
+		return fmt.Sprintf("https://www.op.gg/summoners/%s/%s", region, riotID)
+	}
+}
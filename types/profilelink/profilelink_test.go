@@ -0,0 +1,38 @@
+package profilelink
+
+import "testing"
+
+func TestURL(t *testing.T) {
+	tests := []struct {
+		site, platform, name, tag string
+		expected                  string
+	}{
+		{OPGG, "EUW1", "Faker", "KR1", "https://www.op.gg/summoners/euw/Faker-KR1"},
+		{OPGG, "na1", "Doublelift", "NA1", "https://www.op.gg/summoners/na/Doublelift-NA1"},
+		{OPGG, "KR", "Hide on bush", "KR1", "https://www.op.gg/summoners/kr/Hide%20on%20bush-KR1"},
+		{UGG, "LA2", "Name", "TAG", "https://u.gg/lol/profile/la2/name-tag/overview"},
+		{LeagueOfGraphs, "OC1", "Name", "OCE", "https://www.leagueofgraphs.com/summoner/oce/Name-OCE"},
+		{DeepLoL, "EUN1", "Name", "EUNE", "https://www.deeplol.gg/summoner/EUNE/Name-EUNE"},
+		{Porofessor, "TR1", "Name", "TR1", "https://porofessor.gg/live/tr/name-tr1"},
+		{"unknown", "JP1", "Name", "JP1", "https://www.op.gg/summoners/jp/Name-JP1"},
+	}
+	for _, test := range tests {
+		if got := URL(test.site, test.platform, test.name, test.tag); got != test.expected {
+			t.Errorf("URL(%s, %s, %s, %s): expected %s, got %s", test.site, test.platform, test.name, test.tag, test.expected, got)
+		}
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	for _, site := range Sites {
+		if !IsSupported(site.ID) {
+			t.Errorf("expected %s to be supported", site.ID)
+		}
+	}
+	if IsSupported("example") {
+		t.Error("expected unknown site to be unsupported")
+	}
+	if Name("example") != "OP.GG" {
+		t.Errorf("expected default name for unknown site, got %s", Name("example"))
+	}
+}
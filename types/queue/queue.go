@@ -0,0 +1,150 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Queue IDs of the ranked queues
+const (
+	RankedSoloID = 420
+	RankedFlexID = 440
+)
+
+// Categories group queues that are announced the same way
+const (
+	CategorySolo      = "solo"
+	CategoryFlex      = "flex"
+	CategoryNormal    = "normal"
+	CategoryARAM      = "aram"
+	CategoryArena     = "arena"
+	CategorySwiftplay = "swiftplay"
+	CategoryClash     = "clash"
+	CategoryURF       = "urf"
+	CategoryCustom    = "custom"
+	CategoryOther     = "other"
+)
+
+// NonRankedCategories are the categories a channel can opt in to, in the order they are offered.
+// Clash is not among them, it is announced without an opt-in like the ranked queues.
+var NonRankedCategories = []string{
+	CategoryNormal, CategoryARAM, CategoryArena, CategorySwiftplay, CategoryURF, CategoryCustom, CategoryOther,
+}
+
+// Queue is a League of Legends queue as listed in Riot's queues.json
+type Queue struct {
+	ID          int    `json:"queueId"`
+	Map         string `json:"map"`
+	Description string `json:"description"`
+	Notes       string `json:"notes"`
+}
+
+// Category returns the category of the queue, derived from its description so new queues in queues.json are grouped without code changes
+func (q Queue) Category() string {
+	description := strings.ToLower(q.Description)
+	switch {
+	case q.ID == 0 || strings.Contains(description, "custom") || q.Map == "Custom games":
+		return CategoryCustom
+	case q.ID == RankedSoloID || strings.Contains(description, "ranked solo"):
+		return CategorySolo
+	case q.ID == RankedFlexID || strings.Contains(description, "ranked flex"):
+		return CategoryFlex
+	case strings.Contains(description, "clash"):
+		return CategoryClash
+	case strings.Contains(description, "aram"):
+		return CategoryARAM
+	case strings.Contains(description, "arena") || q.Map == "Rings of Wrath":
+		return CategoryArena
+	case strings.Contains(description, "swiftplay"):
+		return CategorySwiftplay
+	case strings.Contains(description, "urf"):
+		return CategoryURF
+	case strings.Contains(description, "draft pick") || strings.Contains(description, "blind pick") || strings.Contains(description, "quickplay"):
+		return CategoryNormal
+	default:
+		return CategoryOther
+	}
+}
+
+// IsRanked reports whether the queue is Ranked Solo/Duo or Ranked Flex
+func (q Queue) IsRanked() bool {
+	category := q.Category()
+	return category == CategorySolo || category == CategoryFlex
+}
+
+// Name returns a short display name like "Solo/Duo", "ARAM" or "Draft Pick"
+func (q Queue) Name() string {
+	switch q.Category() {
+	case CategorySolo:
+		return "Solo/Duo"
+	case CategoryFlex:
+		return "Flex"
+	case CategoryARAM:
+		return "ARAM"
+	case CategoryArena:
+		return "Arena"
+	case CategorySwiftplay:
+		return "Swiftplay"
+	case CategoryClash:
+		return "Clash"
+	case CategoryURF:
+		return "URF"
+	case CategoryCustom:
+		return "Custom"
+	}
+
+	name := strings.TrimSuffix(strings.TrimPrefix(q.Description, "5v5 "), " games")
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "Normal ("), ")")
+	if name == "" {
+		return fmt.Sprintf("Queue %d", q.ID)
+	}
+	return name
+}
+
+// Catalog holds the known queues by ID. It is filled once by Decode and read-only afterwards, so it is safe for concurrent use.
+type Catalog struct {
+	queues map[int]Queue
+}
+
+// NewCatalog returns an empty catalog
+func NewCatalog() *Catalog {
+	return &Catalog{queues: make(map[int]Queue)}
+}
+
+// Decode adds the queues of a queues.json file
+func (c *Catalog) Decode(data []byte) error {
+	var queues []Queue
+	if err := json.Unmarshal(data, &queues); err != nil {
+		return fmt.Errorf("failed to decode queues: %w", err)
+	}
+	for _, q := range queues {
+		c.queues[q.ID] = q
+	}
+	return nil
+}
+
+// Get returns the queue with the given ID, unknown queues only have their ID and fall into CategoryOther
+func (c *Catalog) Get(id int) Queue {
+	if q, ok := c.queues[id]; ok {
+		return q
+	}
+	if id == RankedSoloID || id == RankedFlexID {
+		// Ranked games are always recognized, even without queues.json
+		return Queue{ID: id}
+	}
+	return Queue{ID: id, Description: fmt.Sprintf("Queue %d", id)}
+}
+
+// IsCategory reports whether category is one of the known categories
+func IsCategory(category string) bool {
+	if category == CategorySolo || category == CategoryFlex || category == CategoryClash {
+		return true
+	}
+	for _, c := range NonRankedCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package queue
+
+import (
+	"os"
+	"testing"
+)
+
+func TestBundledQueues(t *testing.T) {
+	data, err := os.ReadFile("../../assets/queues.json")
+	if err != nil {
+		t.Fatalf("failed to read bundled queues: %v", err)
+	}
+	catalog := NewCatalog()
+	if err := catalog.Decode(data); err != nil {
+		t.Fatalf("failed to decode bundled queues: %v", err)
+	}
+
+	tests := []struct {
+		id       int
+		category string
+		name     string
+	}{
+		{0, CategoryCustom, "Custom"},
+		{400, CategoryNormal, "Draft Pick"},
+		{420, CategorySolo, "Solo/Duo"},
+		{440, CategoryFlex, "Flex"},
+		{450, CategoryARAM, "ARAM"},
+		{480, CategorySwiftplay, "Swiftplay"},
+		{490, CategoryNormal, "Quickplay"},
+		{700, CategoryClash, "Clash"},
+		{720, CategoryClash, "Clash"},
+		{900, CategoryURF, "URF"},
+		{1020, CategoryOther, "One for All"},
+		{1700, CategoryArena, "Arena"},
+		{1900, CategoryURF, "URF"},
+		{4242, CategoryOther, "Queue 4242"},
+	}
+	for _, test := range tests {
+		q := catalog.Get(test.id)
+		if q.Category() != test.category {
+			t.Errorf("Queue %d: expected category %s, got %s", test.id, test.category, q.Category())
+		}
+		if q.Name() != test.name {
+			t.Errorf("Queue %d: expected name %q, got %q", test.id, test.name, q.Name())
+		}
+	}
+}
+
+func TestIsRanked(t *testing.T) {
+	catalog := NewCatalog()
+	if !catalog.Get(RankedSoloID).IsRanked() || !catalog.Get(RankedFlexID).IsRanked() {
+		t.Errorf("Expected ranked queues to be recognized without queues.json")
+	}
+	if catalog.Get(450).IsRanked() {
+		t.Errorf("Expected unknown queue not to be ranked")
+	}
+}
@@ -115,3 +115,25 @@ func abs(x int) int {
 	}
 	return x
 }
+
+// Division returns the division index of the rank (0 = UNRANKED, 1 = IRON IV ... 31 = CHALLENGER I)
+func (r Rank) Division() int {
+	if int(r)/100 > 28 {
+		return int(r) / 10000
+	}
+	return int(r) / 100
+}
+
+// Tier returns the tier name of the rank, e.g. "GOLD" for "GOLD II 42 LP"
+func (r Rank) Tier() string {
+	division, exists := divisions[r.Division()]
+	if !exists {
+		return "UNRANKED"
+	}
+	for i, c := range division {
+		if c == ' ' {
+			return division[:i]
+		}
+	}
+	return division
+}
@@ -0,0 +1,99 @@
+package riotid
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Length limits of the parts of a Riot ID in characters
+const (
+	MinNameLength = 3
+	MaxNameLength = 16
+	MinTagLength  = 3
+	MaxTagLength  = 5
+)
+
+// RiotID is a validated game name and tag line
+type RiotID struct {
+	Name string
+	Tag  string
+}
+
+// Parse parses a Riot ID in "Name#TAG" format
+func Parse(input string) (RiotID, error) {
+	index := strings.LastIndex(input, "#")
+	if index < 0 {
+		return RiotID{}, fmt.Errorf("riot ID %q must have the format Name#TAG", input)
+	}
+	return New(input[:index], input[index+1:])
+}
+
+// New validates a game name and tag line, if tag is empty the name may contain the whole "Name#TAG"
+func New(name, tag string) (RiotID, error) {
+	if strings.TrimSpace(tag) == "" {
+		if !strings.Contains(name, "#") {
+			return RiotID{}, fmt.Errorf("riot ID %q has no tag, use Name#TAG", strings.TrimSpace(name))
+		}
+		return Parse(name)
+	}
+
+	normalizedName, err := normalizeName(name)
+	if err != nil {
+		return RiotID{}, err
+	}
+	normalizedTag, err := normalizeTag(tag)
+	if err != nil {
+		return RiotID{}, err
+	}
+	return RiotID{Name: normalizedName, Tag: normalizedTag}, nil
+}
+
+// normalizeName trims a game name, collapses runs of whitespace and checks Riot's rules
+func normalizeName(name string) (string, error) {
+	if !utf8.ValidString(name) {
+		return "", fmt.Errorf("name is not valid UTF-8")
+	}
+	name = strings.Join(strings.Fields(name), " ")
+
+	length := utf8.RuneCountInString(name)
+	if length < MinNameLength || length > MaxNameLength {
+		return "", fmt.Errorf("name %q must be between %d and %d characters long", name, MinNameLength, MaxNameLength)
+	}
+	for _, r := range name {
+		if r == '#' || !unicode.IsPrint(r) {
+			return "", fmt.Errorf("name %q contains the invalid character %q", name, r)
+		}
+	}
+	return name, nil
+}
+
+// normalizeTag trims a tag line, drops a leading "#" and checks Riot's rules
+func normalizeTag(tag string) (string, error) {
+	if !utf8.ValidString(tag) {
+		return "", fmt.Errorf("tag is not valid UTF-8")
+	}
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+
+	length := utf8.RuneCountInString(tag)
+	if length < MinTagLength || length > MaxTagLength {
+		return "", fmt.Errorf("tag %q must be between %d and %d characters long", tag, MinTagLength, MaxTagLength)
+	}
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return "", fmt.Errorf("tag %q may only contain letters and digits", tag)
+		}
+	}
+	return tag, nil
+}
+
+// String returns the Riot ID in "Name#TAG" format
+func (id RiotID) String() string {
+	return id.Name + "#" + id.Tag
+}
+
+// Equal compares two Riot IDs case-insensitively like Riot does
+func (id RiotID) Equal(other RiotID) bool {
+	return strings.EqualFold(id.Name, other.Name) && strings.EqualFold(id.Tag, other.Tag)
+}
@@ -0,0 +1,66 @@
+package riotid
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    RiotID
+		wantErr bool
+	}{
+		{"Faker#KR1", RiotID{"Faker", "KR1"}, false},
+		{"  Hide on bush #KR1 ", RiotID{"Hide on bush", "KR1"}, false},
+		{"Hide   on bush#KR1", RiotID{"Hide on bush", "KR1"}, false},
+		{"Spieler Ä#EUW", RiotID{"Spieler Ä", "EUW"}, false},
+		{"이상혁#KR1", RiotID{"이상혁", "KR1"}, false},
+		{"O'Neil#1234", RiotID{"O'Neil", "1234"}, false},
+		{"a#b#EUW", RiotID{}, true},
+		{"Faker", RiotID{}, true},
+		{"Fa#KR1", RiotID{}, true},
+		{"ThisNameIsFarTooLong#EUW", RiotID{}, true},
+		{"Faker#K1", RiotID{}, true},
+		{"Faker#KR1234", RiotID{}, true},
+		{"Faker#K-1", RiotID{}, true},
+		{"Fa\tker#EUW", RiotID{"Fa ker", "EUW"}, false},
+		{"Fa\x00ker#EUW", RiotID{}, true},
+	}
+
+	for _, test := range tests {
+		got, err := Parse(test.input)
+		if (err != nil) != test.wantErr {
+			t.Errorf("Parse(%q): expected error %v, got %v", test.input, test.wantErr, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Parse(%q): expected %+v, got %+v", test.input, test.want, got)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	id, err := New("Faker", "#kr1")
+	if err != nil || id != (RiotID{"Faker", "kr1"}) {
+		t.Errorf("Expected a leading # in the tag to be dropped, got %+v, %v", id, err)
+	}
+
+	id, err = New("Faker#KR1", "")
+	if err != nil || id != (RiotID{"Faker", "KR1"}) {
+		t.Errorf("Expected the tag to be taken from the name, got %+v, %v", id, err)
+	}
+
+	if _, err := New("Faker", ""); err == nil {
+		t.Errorf("Expected an error for a missing tag")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	if !(RiotID{"Faker", "KR1"}).Equal(RiotID{"FAKER", "kr1"}) {
+		t.Errorf("Expected Riot IDs to be compared case-insensitively")
+	}
+	if (RiotID{"Faker", "KR1"}).Equal(RiotID{"Faker", "KR2"}) {
+		t.Errorf("Expected different tags to differ")
+	}
+	if got := (RiotID{"Faker", "KR1"}).String(); got != "Faker#KR1" {
+		t.Errorf("Expected Faker#KR1, got %s", got)
+	}
+}
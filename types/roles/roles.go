@@ -0,0 +1,60 @@
+package roles
+
+import "sort"
+
+// Queues a rank role can mirror
+const (
+	QueueSolo = "solo"
+	QueueFlex = "flex"
+)
+
+// Tiers lists the ranked tiers from lowest to highest
+var Tiers = []string{"IRON", "BRONZE", "SILVER", "GOLD", "PLATINUM", "EMERALD", "DIAMOND", "MASTER", "GRANDMASTER", "CHALLENGER"}
+
+// RankRole maps a ranked tier of a queue to a Discord role of a guild
+type RankRole struct {
+	GuildID   string
+	QueueType string
+	Tier      string
+	RoleID    string
+}
+
+// IsTier reports whether tier is a ranked tier
+func IsTier(tier string) bool {
+	for _, t := range Tiers {
+		if t == tier {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff returns the roles a member has to gain and lose so it holds exactly the mapped roles of its tier per queue.
+// tiers maps a queue type to the member's tier, roles that are not part of the mapping are never removed.
+func Diff(mapping []RankRole, tiers map[string]string, memberRoles []string) (add, remove []string) {
+	wanted := make(map[string]bool)
+	managed := make(map[string]bool)
+	for _, m := range mapping {
+		managed[m.RoleID] = true
+		if tiers[m.QueueType] == m.Tier {
+			wanted[m.RoleID] = true
+		}
+	}
+
+	has := make(map[string]bool)
+	for _, roleID := range memberRoles {
+		has[roleID] = true
+		if managed[roleID] && !wanted[roleID] {
+			remove = append(remove, roleID)
+		}
+	}
+	for roleID := range wanted {
+		if !has[roleID] {
+			add = append(add, roleID)
+		}
+	}
+
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
@@ -0,0 +1,47 @@
+package roles
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	mapping := []RankRole{
+		{QueueType: QueueSolo, Tier: "GOLD", RoleID: "gold"},
+		{QueueType: QueueSolo, Tier: "PLATINUM", RoleID: "plat"},
+		{QueueType: QueueFlex, Tier: "GOLD", RoleID: "flex-gold"},
+	}
+
+	tests := []struct {
+		name        string
+		tiers       map[string]string
+		memberRoles []string
+		add         []string
+		remove      []string
+	}{
+		{"new member", map[string]string{QueueSolo: "GOLD"}, nil, []string{"gold"}, nil},
+		{"promotion", map[string]string{QueueSolo: "PLATINUM"}, []string{"gold", "other"}, []string{"plat"}, []string{"gold"}},
+		{"in sync", map[string]string{QueueSolo: "GOLD", QueueFlex: "GOLD"}, []string{"gold", "flex-gold"}, nil, nil},
+		{"unranked", map[string]string{QueueSolo: "UNRANKED"}, []string{"plat", "other"}, nil, []string{"plat"}},
+		{"unmapped tier", map[string]string{QueueSolo: "IRON"}, []string{"other"}, nil, nil},
+	}
+
+	for _, test := range tests {
+		add, remove := Diff(mapping, test.tiers, test.memberRoles)
+		if !reflect.DeepEqual(add, test.add) {
+			t.Errorf("%s: expected to add %v, got %v", test.name, test.add, add)
+		}
+		if !reflect.DeepEqual(remove, test.remove) {
+			t.Errorf("%s: expected to remove %v, got %v", test.name, test.remove, remove)
+		}
+	}
+}
+
+func TestIsTier(t *testing.T) {
+	if !IsTier("EMERALD") {
+		t.Errorf("Expected EMERALD to be a tier")
+	}
+	if IsTier("UNRANKED") || IsTier("gold") {
+		t.Errorf("Expected only upper case ranked tiers")
+	}
+}
@@ -0,0 +1,139 @@
+package settings
+
+import (
+	"time"
+
+	"discord-bot/types/profilelink"
+)
+
+// Queue filters of a channel
+const (
+	QueueAll  = "all"
+	QueueSolo = "solo"
+	QueueFlex = "flex"
+)
+
+// ChannelSettings controls which notifications a channel receives and how they look
+type ChannelSettings struct {
+	ChannelID     string
+	GuildID       string
+	NotifyStart   bool
+	NotifyEnd     bool
+	NotifyMastery bool // Champion mastery level and point milestones
+	NotifyClash   bool // Clash registrations, tournament days and Clash games
+	NotifyStatus  bool // Riot incidents and maintenances of the platforms of tracked summoners, opt-in
+	QueueFilter   string
+	OptInQueues   []string // Non-ranked queue categories like queue.CategoryARAM the channel is notified about
+	MinLPChange   int
+	ImagesEnabled bool
+	Language      string
+	QuietStart    int // Hour of day quiet hours start, -1 if disabled
+	QuietEnd      int // Hour of day quiet hours end (exclusive), -1 if disabled
+	Timezone      string
+}
+
+// NewChannelSettings returns the default settings of a channel: every notification, no quiet hours
+func NewChannelSettings(channelID, guildID string) *ChannelSettings {
+	return &ChannelSettings{
+		ChannelID:     channelID,
+		GuildID:       guildID,
+		NotifyStart:   true,
+		NotifyEnd:     true,
+		NotifyMastery: true,
+		NotifyClash:   true,
+		QueueFilter:   QueueAll,
+		MinLPChange:   0,
+		ImagesEnabled: true,
+		Language:      "en",
+		QuietStart:    -1,
+		QuietEnd:      -1,
+		Timezone:      "UTC",
+	}
+}
+
+// AllowsQueue reports whether notifications for the given game type ("Solo/Duo", "Solo" or "Flex") are wanted
+func (s *ChannelSettings) AllowsQueue(gameType string) bool {
+	switch s.QueueFilter {
+	case QueueSolo:
+		return gameType == "Solo/Duo" || gameType == "Solo"
+	case QueueFlex:
+		return gameType == "Flex"
+	default:
+		return true
+	}
+}
+
+// AllowsNonRankedQueue reports whether notifications for a non-ranked queue category are wanted.
+// Non-ranked queues are opt-in and never reported while the channel filters for a ranked queue.
+func (s *ChannelSettings) AllowsNonRankedQueue(category string) bool {
+	if s.QueueFilter != QueueAll {
+		return false
+	}
+	for _, optIn := range s.OptInQueues {
+		if optIn == category {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsClash reports whether Clash notifications are wanted.
+// Clash games are announced like ranked games without an opt-in, but not while the channel filters for a ranked queue.
+func (s *ChannelSettings) AllowsClash() bool {
+	return s.NotifyClash && s.QueueFilter == QueueAll
+}
+
+// AllowsLPChange reports whether an LP change is big enough to be reported
+func (s *ChannelSettings) AllowsLPChange(lpChange int) bool {
+	if lpChange < 0 {
+		lpChange = -lpChange
+	}
+	return lpChange >= s.MinLPChange
+}
+
+// HasQuietHours reports whether quiet hours are configured
+func (s *ChannelSettings) HasQuietHours() bool {
+	return s.QuietStart >= 0 && s.QuietEnd >= 0 && s.QuietStart != s.QuietEnd
+}
+
+// IsQuiet reports whether t falls into the channel's quiet hours, which may wrap around midnight
+func (s *ChannelSettings) IsQuiet(t time.Time) bool {
+	if !s.HasQuietHours() {
+		return false
+	}
+
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	hour := t.In(loc).Hour()
+
+	if s.QuietStart < s.QuietEnd {
+		return hour >= s.QuietStart && hour < s.QuietEnd
+	}
+	return hour >= s.QuietStart || hour < s.QuietEnd
+}
+
+// ScoreboardTheme shows the scoreboard of all ten players instead of a themed build image of the tracked player
+const ScoreboardTheme = "scoreboard"
+
+// GuildSettings holds the settings shared by all channels of a guild
+type GuildSettings struct {
+	GuildID     string
+	ProfileSite string // Site profile links point to, see profilelink.Sites
+	ImageTheme  string // Image of rank update notifications, ScoreboardTheme or the name of a build image theme
+}
+
+// NewGuildSettings returns the default settings of a guild
+func NewGuildSettings(guildID string) *GuildSettings {
+	return &GuildSettings{
+		GuildID:     guildID,
+		ProfileSite: profilelink.Default,
+		ImageTheme:  ScoreboardTheme,
+	}
+}
+
+// ProfileURL returns the profile link of a Riot ID on the guild's profile site
+func (s *GuildSettings) ProfileURL(platform, name, tagLine string) string {
+	return profilelink.URL(s.ProfileSite, platform, name, tagLine)
+}
@@ -0,0 +1,119 @@
+package settings
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsQuiet(t *testing.T) {
+	tests := []struct {
+		start, end int
+		hour       int
+		expected   bool
+	}{
+		{-1, -1, 3, false},
+		{22, 22, 22, false},
+		{1, 6, 0, false},
+		{1, 6, 1, true},
+		{1, 6, 5, true},
+		{1, 6, 6, false},
+		{22, 7, 21, false},
+		{22, 7, 22, true},
+		{22, 7, 0, true},
+		{22, 7, 6, true},
+		{22, 7, 7, false},
+	}
+
+	for _, test := range tests {
+		s := NewChannelSettings("channel", "guild")
+		s.QuietStart = test.start
+		s.QuietEnd = test.end
+		result := s.IsQuiet(time.Date(2025, 1, 22, test.hour, 30, 0, 0, time.UTC))
+		if result != test.expected {
+			t.Errorf("Quiet hours %d-%d at %d:30: expected %v, got %v", test.start, test.end, test.hour, test.expected, result)
+		}
+	}
+}
+
+func TestAllowsQueue(t *testing.T) {
+	tests := []struct {
+		filter   string
+		gameType string
+		expected bool
+	}{
+		{QueueAll, "Solo/Duo", true},
+		{QueueAll, "Flex", true},
+		{QueueSolo, "Solo/Duo", true},
+		{QueueSolo, "Solo", true},
+		{QueueSolo, "Flex", false},
+		{QueueFlex, "Solo/Duo", false},
+		{QueueFlex, "Flex", true},
+	}
+
+	for _, test := range tests {
+		s := NewChannelSettings("channel", "guild")
+		s.QueueFilter = test.filter
+		if result := s.AllowsQueue(test.gameType); result != test.expected {
+			t.Errorf("Filter %s for %s: expected %v, got %v", test.filter, test.gameType, test.expected, result)
+		}
+	}
+}
+
+func TestAllowsNonRankedQueue(t *testing.T) {
+	s := NewChannelSettings("channel", "guild")
+	if s.AllowsNonRankedQueue("aram") {
+		t.Errorf("Expected non-ranked queues to be opt-in")
+	}
+
+	s.OptInQueues = []string{"aram", "arena"}
+	if !s.AllowsNonRankedQueue("aram") || !s.AllowsNonRankedQueue("arena") || s.AllowsNonRankedQueue("urf") {
+		t.Errorf("Expected only opted in queues to be allowed, got %v", s.OptInQueues)
+	}
+
+	s.QueueFilter = QueueSolo
+	if s.AllowsNonRankedQueue("aram") {
+		t.Errorf("Expected a ranked queue filter to hide non-ranked queues")
+	}
+}
+
+func TestAllowsClash(t *testing.T) {
+	s := NewChannelSettings("channel", "guild")
+	if !s.AllowsClash() {
+		t.Errorf("Expected Clash notifications to be enabled by default")
+	}
+
+	s.QueueFilter = QueueFlex
+	if s.AllowsClash() {
+		t.Errorf("Expected a ranked queue filter to hide Clash")
+	}
+
+	s.QueueFilter = QueueAll
+	s.NotifyClash = false
+	if s.AllowsClash() {
+		t.Errorf("Expected disabled Clash notifications to be respected")
+	}
+}
+
+func TestAllowsLPChange(t *testing.T) {
+	s := NewChannelSettings("channel", "guild")
+	s.MinLPChange = 15
+
+	if s.AllowsLPChange(14) || s.AllowsLPChange(-14) {
+		t.Errorf("Expected LP changes below 15 to be filtered")
+	}
+	if !s.AllowsLPChange(15) || !s.AllowsLPChange(-22) {
+		t.Errorf("Expected LP changes of at least 15 to be reported")
+	}
+}
+
+func TestGuildProfileURL(t *testing.T) {
+	s := NewGuildSettings("guild")
+	if url := s.ProfileURL("NA1", "Name", "TAG"); url != "https://www.op.gg/summoners/na/Name-TAG" {
+		t.Errorf("Expected op.gg link for the NA region by default, got %s", url)
+	}
+
+	s.ProfileSite = "leagueofgraphs"
+	if url := s.ProfileURL("KR", "Name", "TAG"); url != "https://www.leagueofgraphs.com/summoner/kr/Name-TAG" {
+		t.Errorf("Expected League of Graphs link, got %s", url)
+	}
+}
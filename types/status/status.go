@@ -0,0 +1,146 @@
+package status
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kinds of status entries
+const (
+	KindIncident    = "incident"
+	KindMaintenance = "maintenance"
+)
+
+// Maintenance states as returned by lol-status-v4
+const (
+	MaintenanceScheduled  = "scheduled"
+	MaintenanceInProgress = "in_progress"
+	MaintenanceComplete   = "complete"
+)
+
+// Incident severities as returned by lol-status-v4
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// DefaultLocale is used when an entry has no text in the requested locale
+const DefaultLocale = "en_US"
+
+// Translation is a text in one locale
+type Translation struct {
+	Locale  string `json:"locale"`
+	Content string `json:"content"`
+}
+
+// Update is a message Riot posted about an incident or maintenance
+type Update struct {
+	ID           int           `json:"id"`
+	Translations []Translation `json:"translations"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+// Entry is an incident or maintenance of a platform
+type Entry struct {
+	ID                int           `json:"id"`
+	Kind              string        `json:"-"` // KindIncident or KindMaintenance, set by the platform status
+	MaintenanceStatus string        `json:"maintenance_status"`
+	IncidentSeverity  string        `json:"incident_severity"`
+	Titles            []Translation `json:"titles"`
+	Updates           []Update      `json:"updates"`
+	CreatedAt         time.Time     `json:"created_at"`
+	UpdatedAt         time.Time     `json:"updated_at"`
+	ArchiveAt         time.Time     `json:"archive_at"`
+}
+
+// PlatformStatus is the lol-status-v4 platform data
+type PlatformStatus struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	Maintenances []Entry `json:"maintenances"`
+	Incidents    []Entry `json:"incidents"`
+}
+
+// Locale returns the lol-status-v4 locale of a bot language like "de"
+func Locale(language string) string {
+	switch language {
+	case "de":
+		return "de_DE"
+	default:
+		return DefaultLocale
+	}
+}
+
+// Entries returns the maintenances and incidents of the platform with their kind set
+func (p *PlatformStatus) Entries() []Entry {
+	var entries []Entry
+	for _, entry := range p.Maintenances {
+		entry.Kind = KindMaintenance
+		entries = append(entries, entry)
+	}
+	for _, entry := range p.Incidents {
+		entry.Kind = KindIncident
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// UnderMaintenance reports whether a maintenance of the platform is in progress
+func (p *PlatformStatus) UnderMaintenance() bool {
+	for _, entry := range p.Maintenances {
+		if entry.MaintenanceStatus == MaintenanceInProgress {
+			return true
+		}
+	}
+	return false
+}
+
+// Key identifies the entry on its platform, incident and maintenance IDs may overlap
+func (e *Entry) Key() string {
+	return fmt.Sprintf("%s:%d", e.Kind, e.ID)
+}
+
+// Title returns the title of the entry in the locale, falling back to DefaultLocale
+func (e *Entry) Title(locale string) string {
+	return translate(e.Titles, locale)
+}
+
+// LatestUpdate returns the text of the newest update in the locale, empty if Riot has not posted one
+func (e *Entry) LatestUpdate(locale string) string {
+	var latest *Update
+	for i := range e.Updates {
+		if latest == nil || e.Updates[i].CreatedAt.After(latest.CreatedAt) {
+			latest = &e.Updates[i]
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return translate(latest.Translations, locale)
+}
+
+// Version changes whenever Riot changes the entry, so an announcement is only edited if there is something new
+func (e *Entry) Version() string {
+	version := e.UpdatedAt
+	for _, update := range e.Updates {
+		if update.UpdatedAt.After(version) {
+			version = update.UpdatedAt
+		}
+	}
+	return fmt.Sprintf("%s|%d|%d", e.MaintenanceStatus, len(e.Updates), version.Unix())
+}
+
+func translate(translations []Translation, locale string) string {
+	var fallback string
+	for _, t := range translations {
+		if t.Locale == locale {
+			return t.Content
+		}
+		if t.Locale == DefaultLocale || fallback == "" {
+			fallback = t.Content
+		}
+	}
+	return fallback
+}
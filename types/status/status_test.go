@@ -0,0 +1,82 @@
+package status
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const platformData = `{
+	"id": "EUW1",
+	"name": "EU West",
+	"maintenances": [{
+		"id": 7,
+		"maintenance_status": "in_progress",
+		"incident_severity": null,
+		"titles": [{"locale": "de_DE", "content": "Wartung"}, {"locale": "en_US", "content": "Maintenance"}],
+		"updates": [
+			{"id": 1, "translations": [{"locale": "en_US", "content": "Starting"}], "created_at": "2026-10-19T10:00:00+00:00", "updated_at": "2026-10-19T10:00:00+00:00"},
+			{"id": 2, "translations": [{"locale": "en_US", "content": "Extended"}], "created_at": "2026-10-19T11:00:00.123000+00:00", "updated_at": "2026-10-19T11:00:00+00:00"}
+		],
+		"created_at": "2026-10-19T09:00:00+00:00",
+		"updated_at": null,
+		"archive_at": null
+	}],
+	"incidents": [{
+		"id": 7,
+		"incident_severity": "warning",
+		"titles": [{"locale": "en_US", "content": "Login issues"}],
+		"updates": [],
+		"created_at": "2026-10-19T09:30:00+00:00"
+	}]
+}`
+
+func TestPlatformStatus(t *testing.T) {
+	var p PlatformStatus
+	if err := json.Unmarshal([]byte(platformData), &p); err != nil {
+		t.Fatalf("Failed to decode platform data: %v", err)
+	}
+	if !p.UnderMaintenance() {
+		t.Errorf("Expected the platform to be under maintenance")
+	}
+
+	entries := p.Entries()
+	if len(entries) != 2 || entries[0].Kind != KindMaintenance || entries[1].Kind != KindIncident {
+		t.Fatalf("Expected a maintenance and an incident, got %+v", entries)
+	}
+	if entries[0].Key() == entries[1].Key() {
+		t.Errorf("Expected different keys for a maintenance and an incident with the same ID")
+	}
+
+	maintenance := entries[0]
+	if title := maintenance.Title(Locale("de")); title != "Wartung" {
+		t.Errorf("Expected Wartung, got %s", title)
+	}
+	if title := maintenance.Title("fr_FR"); title != "Maintenance" {
+		t.Errorf("Expected the en_US fallback, got %s", title)
+	}
+	if update := maintenance.LatestUpdate(Locale("de")); update != "Extended" {
+		t.Errorf("Expected the newest update, got %s", update)
+	}
+	if update := entries[1].LatestUpdate(DefaultLocale); update != "" {
+		t.Errorf("Expected no update, got %s", update)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	var p PlatformStatus
+	if err := json.Unmarshal([]byte(platformData), &p); err != nil {
+		t.Fatalf("Failed to decode platform data: %v", err)
+	}
+	entry := p.Entries()[0]
+	before := entry.Version()
+
+	entry.MaintenanceStatus = MaintenanceComplete
+	if entry.Version() == before {
+		t.Errorf("Expected a status change to change the version")
+	}
+	entry.MaintenanceStatus = MaintenanceInProgress
+	entry.Updates = entry.Updates[:1]
+	if entry.Version() == before {
+		t.Errorf("Expected a new update to change the version")
+	}
+}
@@ -0,0 +1,18 @@
+package subscription
+
+// Games a channel follows for a tracked summoner
+const (
+	GamesLoL  = "lol"
+	GamesTFT  = "tft"
+	GamesBoth = "both"
+)
+
+// IsValid reports whether games is one of the known values
+func IsValid(games string) bool {
+	return games == GamesLoL || games == GamesTFT || games == GamesBoth
+}
+
+// Follows reports whether a subscription following games wants notifications about game, which is GamesLoL or GamesTFT
+func Follows(games, game string) bool {
+	return games == GamesBoth || games == game
+}
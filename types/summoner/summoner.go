@@ -17,6 +17,7 @@ type Summoner struct {
 	FlexRank      rank.Rank
 	Updated       time.Time
 	Region        string
+	Level         int // Only set when fetched from the API, not persisted
 }
 
 // NewSummoner creates a new Summoner instance with mandatory fields name, tagLine, accountID, ID, puuid, Rank
@@ -57,3 +58,12 @@ func (s *Summoner) UpdateFlexRank(newFlexRank rank.Rank) {
 	s.FlexRank = newFlexRank
 	s.Updated = time.Now()
 }
+
+// TrackedSummoner is a summoner mapped to a channel together with the time of its last recorded game
+type TrackedSummoner struct {
+	Summoner  Summoner
+	ChannelID string
+	GuildID   string
+	Games     string // Games the channel follows for the summoner, see the subscription package
+	LastGame  *time.Time
+}
@@ -0,0 +1,135 @@
+package tft
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// QueueRanked is the queue ID of ranked TFT games in tft-match-v1
+const QueueRanked = 1100
+
+// Trait styles as returned by tft-match-v1, higher styles are better tiers of an active trait
+const (
+	StyleInactive = 0
+	StyleBronze   = 1
+	StyleSilver   = 2
+	StyleGold     = 3
+	StylePrism    = 4
+)
+
+// Match is a finished TFT game
+type Match struct {
+	MatchID      string
+	QueueID      int
+	SetNumber    int
+	GameDatetime time.Time
+	GameLength   time.Duration
+	Participants []Participant
+}
+
+// Participant is a player of a TFT game with the final board
+type Participant struct {
+	PUUID     string
+	Placement int
+	Level     int
+	Traits    []Trait
+	Units     []Unit
+}
+
+// Trait is a trait of a final board
+type Trait struct {
+	Name     string // API name like "TFT13_Sorcerer"
+	NumUnits int
+	Style    int
+}
+
+// Unit is a champion on a final board
+type Unit struct {
+	CharacterID string   // API name like "TFT13_Jinx"
+	Tier        int      // Star level
+	Rarity      int      // 0 for one cost units up to 6 for legendary ones
+	Items       []string // API names like "TFT_Item_InfinityEdge"
+}
+
+// IsRanked reports whether the match was played in the ranked queue
+func (m *Match) IsRanked() bool {
+	return m.QueueID == QueueRanked
+}
+
+// Participant returns the participant with the given PUUID or nil if they did not play in the match
+func (m *Match) Participant(puuid string) *Participant {
+	for i := range m.Participants {
+		if m.Participants[i].PUUID == puuid {
+			return &m.Participants[i]
+		}
+	}
+	return nil
+}
+
+// ActiveTraits returns the active traits of the board, best style first
+func (p *Participant) ActiveTraits() []Trait {
+	var active []Trait
+	for _, trait := range p.Traits {
+		if trait.Style > StyleInactive {
+			active = append(active, trait)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		if active[i].Style != active[j].Style {
+			return active[i].Style > active[j].Style
+		}
+		return active[i].NumUnits > active[j].NumUnits
+	})
+	return active
+}
+
+// IsTopFour reports whether the placement counts as a win
+func (p *Participant) IsTopFour() bool {
+	return p.Placement >= 1 && p.Placement <= 4
+}
+
+// Cost returns the gold cost of the unit
+func (u Unit) Cost() int {
+	switch {
+	case u.Rarity >= 6:
+		return 5
+	case u.Rarity >= 4:
+		return 4
+	default:
+		return u.Rarity + 1
+	}
+}
+
+// Ordinal formats a placement like "1st" or "4th"
+func Ordinal(placement int) string {
+	switch placement {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	default:
+		return fmt.Sprintf("%dth", placement)
+	}
+}
+
+// DisplayName turns API names like "TFT13_Jinx", "TFT_Item_InfinityEdge" or "Set13_Sorcerer" into readable names
+func DisplayName(apiName string) string {
+	name := apiName
+	if i := strings.LastIndex(name, "_"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	// Split CamelCase like "InfinityEdge" into words
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' && name[i-1] >= 'a' && name[i-1] <= 'z' {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
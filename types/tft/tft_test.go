@@ -0,0 +1,42 @@
+package tft
+
+import "testing"
+
+func TestDisplayName(t *testing.T) {
+	tests := map[string]string{
+		"TFT13_Jinx":            "Jinx",
+		"TFT_Item_InfinityEdge": "Infinity Edge",
+		"TFT13_Sorcerer":        "Sorcerer",
+		"Kaisa":                 "Kaisa",
+	}
+	for apiName, expected := range tests {
+		if got := DisplayName(apiName); got != expected {
+			t.Errorf("DisplayName(%s): expected %s, got %s", apiName, expected, got)
+		}
+	}
+}
+
+func TestActiveTraits(t *testing.T) {
+	p := Participant{Traits: []Trait{
+		{Name: "A", NumUnits: 2, Style: StyleBronze},
+		{Name: "B", NumUnits: 1, Style: StyleInactive},
+		{Name: "C", NumUnits: 6, Style: StyleGold},
+		{Name: "D", NumUnits: 4, Style: StyleBronze},
+	}}
+	active := p.ActiveTraits()
+	if len(active) != 3 || active[0].Name != "C" || active[1].Name != "D" || active[2].Name != "A" {
+		t.Errorf("expected traits C, D, A, got %+v", active)
+	}
+}
+
+func TestPlacement(t *testing.T) {
+	if Ordinal(1) != "1st" || Ordinal(2) != "2nd" || Ordinal(3) != "3rd" || Ordinal(8) != "8th" {
+		t.Errorf("unexpected ordinals")
+	}
+	if !(&Participant{Placement: 4}).IsTopFour() || (&Participant{Placement: 5}).IsTopFour() {
+		t.Errorf("expected placements 1-4 to be top four")
+	}
+	if (Unit{Rarity: 0}).Cost() != 1 || (Unit{Rarity: 4}).Cost() != 4 || (Unit{Rarity: 6}).Cost() != 5 {
+		t.Errorf("unexpected unit costs")
+	}
+}
@@ -0,0 +1,251 @@
+package transfer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"discord-bot/types/settings"
+)
+
+// Formats of an export file
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// csvHeader names the columns of a CSV export, the settings columns are empty for channels without stored settings
+var csvHeader = []string{
+	"riot_id", "region", "puuid", "channel_id", "games",
+	"notify_start", "notify_end", "queue", "min_lp", "images", "language", "quiet_start", "quiet_end", "timezone",
+	"notify_mastery", "other_queues", "notify_clash", "notify_status",
+}
+
+// otherQueuesSeparator joins the opted in queues in the other_queues CSV column
+const otherQueuesSeparator = ";"
+
+// Entry is one exported subscription of a summoner to a channel
+type Entry struct {
+	RiotID    string    `json:"riotId"`
+	Region    string    `json:"region"`
+	PUUID     string    `json:"puuid,omitempty"`
+	ChannelID string    `json:"channelId,omitempty"`
+	Games     string    `json:"games,omitempty"` // Games the channel follows, empty for files exported before games could be chosen
+	Settings  *Settings `json:"settings,omitempty"`
+}
+
+// Settings are the notification settings of the channel of an entry
+type Settings struct {
+	NotifyStart   bool     `json:"notifyStart"`
+	NotifyEnd     bool     `json:"notifyEnd"`
+	NotifyMastery bool     `json:"notifyMastery"`
+	NotifyClash   bool     `json:"notifyClash"`
+	NotifyStatus  bool     `json:"notifyStatus"`
+	QueueFilter   string   `json:"queue"`
+	OptInQueues   []string `json:"otherQueues"`
+	MinLPChange   int      `json:"minLp"`
+	ImagesEnabled bool     `json:"images"`
+	Language      string   `json:"language"`
+	QuietStart    int      `json:"quietStart"`
+	QuietEnd      int      `json:"quietEnd"`
+	Timezone      string   `json:"timezone"`
+}
+
+// FromChannelSettings copies the exportable fields of channel settings
+func FromChannelSettings(s *settings.ChannelSettings) *Settings {
+	return &Settings{
+		NotifyStart:   s.NotifyStart,
+		NotifyEnd:     s.NotifyEnd,
+		NotifyMastery: s.NotifyMastery,
+		NotifyClash:   s.NotifyClash,
+		NotifyStatus:  s.NotifyStatus,
+		QueueFilter:   s.QueueFilter,
+		OptInQueues:   s.OptInQueues,
+		MinLPChange:   s.MinLPChange,
+		ImagesEnabled: s.ImagesEnabled,
+		Language:      s.Language,
+		QuietStart:    s.QuietStart,
+		QuietEnd:      s.QuietEnd,
+		Timezone:      s.Timezone,
+	}
+}
+
+// defaultSettings are the exported fields of the default channel settings, used for fields missing in older export files
+func defaultSettings() *Settings {
+	return FromChannelSettings(settings.NewChannelSettings("", ""))
+}
+
+// UnmarshalJSON keeps the defaults for fields missing in the file, so files exported before a setting existed do not turn it off
+func (s *Settings) UnmarshalJSON(data []byte) error {
+	type plain Settings
+	decoded := plain(*defaultSettings())
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*s = Settings(decoded)
+	return nil
+}
+
+// ChannelSettings returns the settings for a channel of a guild, settings that are not exported keep their defaults
+func (s *Settings) ChannelSettings(channelID, guildID string) *settings.ChannelSettings {
+	c := settings.NewChannelSettings(channelID, guildID)
+	c.NotifyStart = s.NotifyStart
+	c.NotifyEnd = s.NotifyEnd
+	c.NotifyMastery = s.NotifyMastery
+	c.NotifyClash = s.NotifyClash
+	c.NotifyStatus = s.NotifyStatus
+	c.QueueFilter = s.QueueFilter
+	c.OptInQueues = s.OptInQueues
+	c.MinLPChange = s.MinLPChange
+	c.ImagesEnabled = s.ImagesEnabled
+	c.Language = s.Language
+	c.QuietStart = s.QuietStart
+	c.QuietEnd = s.QuietEnd
+	c.Timezone = s.Timezone
+	return c
+}
+
+// Encode writes entries in the given format
+func Encode(entries []Entry, format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(entries, "", "  ")
+	case FormatCSV:
+		return encodeCSV(entries)
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+func encodeCSV(entries []Entry) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		record := []string{e.RiotID, e.Region, e.PUUID, e.ChannelID, e.Games}
+		if s := e.Settings; s != nil {
+			record = append(record,
+				strconv.FormatBool(s.NotifyStart), strconv.FormatBool(s.NotifyEnd), s.QueueFilter, strconv.Itoa(s.MinLPChange),
+				strconv.FormatBool(s.ImagesEnabled), s.Language, strconv.Itoa(s.QuietStart), strconv.Itoa(s.QuietEnd), s.Timezone,
+				strconv.FormatBool(s.NotifyMastery), strings.Join(s.OptInQueues, otherQueuesSeparator), strconv.FormatBool(s.NotifyClash), strconv.FormatBool(s.NotifyStatus))
+		} else {
+			record = append(record, make([]string, len(csvHeader)-len(record))...)
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// Decode reads entries from an export file, the format is detected from the content
+func Decode(data []byte) ([]Entry, error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("the file is empty")
+	}
+	if trimmed[0] == '[' {
+		var entries []Entry
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %v", err)
+		}
+		return entries, nil
+	}
+	return decodeCSV(trimmed)
+}
+
+func decodeCSV(data []byte) ([]Entry, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %v", err)
+	}
+	columns := make(map[string]int, len(header))
+	for index, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = index
+	}
+	if _, ok := columns["riot_id"]; !ok {
+		return nil, fmt.Errorf("invalid CSV: missing riot_id column")
+	}
+	if _, ok := columns["region"]; !ok {
+		return nil, fmt.Errorf("invalid CSV: missing region column")
+	}
+
+	var entries []Entry
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %v", err)
+		}
+		field := func(name string) string {
+			if index, ok := columns[name]; ok && index < len(record) {
+				return strings.TrimSpace(record[index])
+			}
+			return ""
+		}
+
+		entry := Entry{
+			RiotID:    field("riot_id"),
+			Region:    field("region"),
+			PUUID:     field("puuid"),
+			ChannelID: field("channel_id"),
+			Games:     field("games"),
+		}
+		if field("queue") != "" {
+			s, err := parseSettings(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid settings in line %d: %v", line, err)
+			}
+			entry.Settings = s
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func parseSettings(field func(string) string) (*Settings, error) {
+	s := defaultSettings()
+	s.QueueFilter = field("queue")
+	s.Language = field("language")
+	s.Timezone = field("timezone")
+	if otherQueues := field("other_queues"); otherQueues != "" {
+		s.OptInQueues = strings.Split(otherQueues, otherQueuesSeparator)
+	}
+
+	var err error
+	bools := map[string]*bool{"notify_start": &s.NotifyStart, "notify_end": &s.NotifyEnd, "images": &s.ImagesEnabled}
+	for name, target := range bools {
+		if *target, err = strconv.ParseBool(field(name)); err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	// Columns added after the first export format are optional and keep their defaults
+	optionalBools := map[string]*bool{"notify_mastery": &s.NotifyMastery, "notify_clash": &s.NotifyClash, "notify_status": &s.NotifyStatus}
+	for name, target := range optionalBools {
+		if field(name) == "" {
+			continue
+		}
+		if *target, err = strconv.ParseBool(field(name)); err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	ints := map[string]*int{"min_lp": &s.MinLPChange, "quiet_start": &s.QuietStart, "quiet_end": &s.QuietEnd}
+	for name, target := range ints {
+		if *target, err = strconv.Atoi(field(name)); err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,96 @@
+package transfer
+
+import (
+	"reflect"
+	"testing"
+
+	"discord-bot/types/settings"
+)
+
+func testEntries() []Entry {
+	return []Entry{
+		{
+			RiotID:    "Faker#KR1",
+			Region:    "KR",
+			PUUID:     "puuid-1",
+			ChannelID: "123",
+			Games:     "tft",
+			Settings: &Settings{
+				NotifyStart: true, NotifyEnd: true, NotifyMastery: false, NotifyClash: true, NotifyStatus: true,
+				QueueFilter: "all", OptInQueues: []string{"aram", "arena"}, MinLPChange: 10,
+				ImagesEnabled: false, Language: "de", QuietStart: 22, QuietEnd: 7, Timezone: "Europe/Berlin",
+			},
+		},
+		{RiotID: "Grüße, Welt#EUW", Region: "EUW1", PUUID: "puuid-2", ChannelID: "456"},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatCSV} {
+		data, err := Encode(testEntries(), format)
+		if err != nil {
+			t.Fatalf("%s: failed to encode: %v", format, err)
+		}
+		entries, err := Decode(data)
+		if err != nil {
+			t.Fatalf("%s: failed to decode: %v", format, err)
+		}
+		if !reflect.DeepEqual(entries, testEntries()) {
+			t.Errorf("%s: expected %+v, got %+v", format, testEntries(), entries)
+		}
+	}
+}
+
+func TestDecodeMinimalCSV(t *testing.T) {
+	entries, err := Decode([]byte("Riot_ID,Region\nFaker#KR1,KR\n"))
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	expected := []Entry{{RiotID: "Faker#KR1", Region: "KR"}}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("expected %+v, got %+v", expected, entries)
+	}
+}
+
+func TestChannelSettingsRoundTrip(t *testing.T) {
+	stored := settings.NewChannelSettings("123", "guild")
+	stored.NotifyMastery = false
+	stored.NotifyStatus = true
+	stored.OptInQueues = []string{"aram"}
+
+	restored := FromChannelSettings(stored).ChannelSettings("123", "guild")
+	if !reflect.DeepEqual(restored, stored) {
+		t.Errorf("expected %+v, got %+v", stored, restored)
+	}
+}
+
+func TestDecodeOlderExportKeepsDefaults(t *testing.T) {
+	inputs := map[string]string{
+		FormatJSON: `[{"riotId": "Faker#KR1", "region": "KR", "settings": {"notifyStart": true, "notifyEnd": true, "queue": "all", "images": true, "language": "en", "quietStart": -1, "quietEnd": -1, "timezone": "UTC"}}]`,
+		FormatCSV:  "riot_id,region,notify_start,notify_end,queue,min_lp,images,language,quiet_start,quiet_end,timezone\nFaker#KR1,KR,true,true,all,0,true,en,-1,-1,UTC\n",
+	}
+	for format, input := range inputs {
+		entries, err := Decode([]byte(input))
+		if err != nil {
+			t.Fatalf("%s: failed to decode: %v", format, err)
+		}
+		restored := entries[0].Settings.ChannelSettings("123", "guild")
+		if expected := settings.NewChannelSettings("123", "guild"); !reflect.DeepEqual(restored, expected) {
+			t.Errorf("%s: expected the defaults %+v, got %+v", format, expected, restored)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	inputs := map[string]string{
+		"empty":          "  ",
+		"missing region": "riot_id\nFaker#KR1\n",
+		"broken json":    "[{",
+		"bad settings":   "riot_id,region,queue,min_lp\nFaker#KR1,KR,solo,many\n",
+	}
+	for name, input := range inputs {
+		if _, err := Decode([]byte(input)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}